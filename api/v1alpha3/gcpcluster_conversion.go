@@ -52,6 +52,43 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 		dst.Spec.CredentialsRef = restored.Spec.CredentialsRef
 	}
 
+	if restored.Spec.ServiceAccountImpersonation != nil {
+		dst.Spec.ServiceAccountImpersonation = restored.Spec.ServiceAccountImpersonation.DeepCopy()
+	}
+
+	if restored.Spec.IdentityRef != nil {
+		dst.Spec.IdentityRef = restored.Spec.IdentityRef.DeepCopy()
+	}
+
+	if restored.Spec.FailureDomainSpecs != nil {
+		dst.Spec.FailureDomainSpecs = restored.Spec.FailureDomainSpecs
+	}
+
+	if restored.Spec.Network.Routes != nil {
+		dst.Spec.Network.Routes = restored.Spec.Network.Routes
+	}
+
+	if restored.Spec.Network.FirewallPolicy != nil {
+		dst.Spec.Network.FirewallPolicy = restored.Spec.Network.FirewallPolicy
+	}
+
+	if restored.Spec.Network.Router != nil {
+		dst.Spec.Network.Router = restored.Spec.Network.Router
+	}
+
+	if restored.Spec.LoadBalancer != nil {
+		dst.Spec.LoadBalancer = restored.Spec.LoadBalancer.DeepCopy()
+	}
+
+	if restored.Status.Network.FirewallPolicy != nil {
+		dst.Status.Network.FirewallPolicy = restored.Status.Network.FirewallPolicy
+	}
+
+	dst.Status.PendingOperations = restored.Status.PendingOperations
+	dst.Status.LabelKeys = restored.Status.LabelKeys
+	dst.Status.ResourceManagerTagValues = restored.Status.ResourceManagerTagValues
+	dst.Status.Conditions = restored.Status.Conditions
+
 	return nil
 }
 
@@ -123,3 +160,13 @@ func Convert_v1beta1_GCPClusterStatus_To_v1alpha3_GCPClusterStatus(in *v1beta1.G
 func Convert_v1beta1_SubnetSpec_To_v1alpha3_SubnetSpec(in *v1beta1.SubnetSpec, out *SubnetSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_SubnetSpec_To_v1alpha3_SubnetSpec(in, out, s)
 }
+
+// Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec.
+func Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in *v1beta1.NetworkSpec, out *NetworkSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in, out, s)
+}
+
+// Convert_v1beta1_Network_To_v1alpha3_Network.
+func Convert_v1beta1_Network_To_v1alpha3_Network(in *v1beta1.Network, out *Network, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Network_To_v1alpha3_Network(in, out, s)
+}
@@ -53,6 +53,28 @@ func (src *GCPMachine) ConvertTo(dstRaw conversion.Hub) error { // nolint
 		dst.Spec.ConfidentialCompute = restored.Spec.ConfidentialCompute
 	}
 
+	if restored.Spec.BootstrapCheck != nil {
+		dst.Spec.BootstrapCheck = restored.Spec.BootstrapCheck
+	}
+
+	if restored.Spec.ExistingInstance != nil {
+		dst.Spec.ExistingInstance = restored.Spec.ExistingInstance
+	}
+
+	if restored.Status.Zone != nil {
+		dst.Status.Zone = restored.Status.Zone
+	}
+
+	if restored.Status.AdoptedInstance != nil {
+		dst.Status.AdoptedInstance = restored.Status.AdoptedInstance
+	}
+
+	dst.Status.PendingOperations = restored.Status.PendingOperations
+	dst.Status.BootstrapSerialOffset = restored.Status.BootstrapSerialOffset
+	dst.Status.LabelKeys = restored.Status.LabelKeys
+	dst.Status.ResourceManagerTagValues = restored.Status.ResourceManagerTagValues
+	dst.Status.Conditions = restored.Status.Conditions
+
 	return nil
 }
 
@@ -87,3 +109,8 @@ func (dst *GCPMachineList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
 func Convert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in *v1beta1.GCPMachineSpec, out *GCPMachineSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in, out, s)
 }
+
+// Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus is an autogenerated conversion function.
+func Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in *v1beta1.GCPMachineStatus, out *GCPMachineStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in, out, s)
+}
@@ -120,11 +120,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.GCPMachineStatus)(nil), (*GCPMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(a.(*v1beta1.GCPMachineStatus), b.(*GCPMachineStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*GCPMachineTemplate)(nil), (*v1beta1.GCPMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_GCPMachineTemplate_To_v1beta1_GCPMachineTemplate(a.(*GCPMachineTemplate), b.(*v1beta1.GCPMachineTemplate), scope)
 	}); err != nil {
@@ -175,21 +170,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Network)(nil), (*Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Network_To_v1alpha3_Network(a.(*v1beta1.Network), b.(*Network), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkSpec)(nil), (*v1beta1.NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_NetworkSpec_To_v1beta1_NetworkSpec(a.(*NetworkSpec), b.(*v1beta1.NetworkSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkSpec)(nil), (*NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(a.(*v1beta1.NetworkSpec), b.(*NetworkSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ServiceAccount)(nil), (*v1beta1.ServiceAccount)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ServiceAccount_To_v1beta1_ServiceAccount(a.(*ServiceAccount), b.(*v1beta1.ServiceAccount), scope)
 	}); err != nil {
@@ -230,11 +215,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.GCPMachineStatus)(nil), (*GCPMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(a.(*v1beta1.GCPMachineStatus), b.(*GCPMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.GCPMachineTemplateResource)(nil), (*GCPMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_GCPMachineTemplateResource_To_v1alpha3_GCPMachineTemplateResource(a.(*v1beta1.GCPMachineTemplateResource), b.(*GCPMachineTemplateResource), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkSpec)(nil), (*NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(a.(*v1beta1.NetworkSpec), b.(*NetworkSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.Network)(nil), (*Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Network_To_v1alpha3_Network(a.(*v1beta1.Network), b.(*Network), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SubnetSpec)(nil), (*SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SubnetSpec_To_v1alpha3_SubnetSpec(a.(*v1beta1.SubnetSpec), b.(*SubnetSpec), scope)
 	}); err != nil {
@@ -412,10 +412,14 @@ func autoConvert_v1beta1_GCPClusterSpec_To_v1alpha3_GCPClusterSpec(in *v1beta1.G
 	if err := Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(&in.Network, &out.Network, s); err != nil {
 		return err
 	}
+	// WARNING: in.LoadBalancer requires manual conversion: does not exist in peer-type
 	out.FailureDomains = *(*[]string)(unsafe.Pointer(&in.FailureDomains))
+	// WARNING: in.FailureDomainSpecs requires manual conversion: does not exist in peer-type
 	out.AdditionalLabels = *(*Labels)(unsafe.Pointer(&in.AdditionalLabels))
 	// WARNING: in.ResourceManagerTags requires manual conversion: does not exist in peer-type
 	// WARNING: in.CredentialsRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.ServiceAccountImpersonation requires manual conversion: does not exist in peer-type
+	// WARNING: in.IdentityRef requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -457,7 +461,11 @@ func autoConvert_v1beta1_GCPClusterStatus_To_v1alpha3_GCPClusterStatus(in *v1bet
 	if err := Convert_v1beta1_Network_To_v1alpha3_Network(&in.Network, &out.Network, s); err != nil {
 		return err
 	}
+	// WARNING: in.PendingOperations requires manual conversion: does not exist in peer-type
+	// WARNING: in.LabelKeys requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResourceManagerTagValues requires manual conversion: does not exist in peer-type
 	out.Ready = in.Ready
+	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -562,6 +570,7 @@ func autoConvert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in *v1beta1.G
 	out.InstanceType = in.InstanceType
 	out.Subnet = (*string)(unsafe.Pointer(in.Subnet))
 	out.ProviderID = (*string)(unsafe.Pointer(in.ProviderID))
+	// WARNING: in.ExistingInstance requires manual conversion: does not exist in peer-type
 	out.ImageFamily = (*string)(unsafe.Pointer(in.ImageFamily))
 	out.Image = (*string)(unsafe.Pointer(in.Image))
 	out.AdditionalLabels = *(*Labels)(unsafe.Pointer(&in.AdditionalLabels))
@@ -578,6 +587,7 @@ func autoConvert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in *v1beta1.G
 	// WARNING: in.ShieldedInstanceConfig requires manual conversion: does not exist in peer-type
 	// WARNING: in.OnHostMaintenance requires manual conversion: does not exist in peer-type
 	// WARNING: in.ConfidentialCompute requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapCheck requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -599,16 +609,18 @@ func autoConvert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in *v1bet
 	out.Ready = in.Ready
 	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
 	out.InstanceStatus = (*InstanceStatus)(unsafe.Pointer(in.InstanceStatus))
+	// WARNING: in.Zone requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdoptedInstance requires manual conversion: does not exist in peer-type
+	// WARNING: in.PendingOperations requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapSerialOffset requires manual conversion: does not exist in peer-type
+	// WARNING: in.LabelKeys requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResourceManagerTagValues requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
+	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus is an autogenerated conversion function.
-func Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in *v1beta1.GCPMachineStatus, out *GCPMachineStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in, out, s)
-}
-
 func autoConvert_v1alpha3_GCPMachineTemplate_To_v1beta1_GCPMachineTemplate(in *GCPMachineTemplate, out *v1beta1.GCPMachineTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_GCPMachineTemplateSpec_To_v1beta1_GCPMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -771,14 +783,10 @@ func autoConvert_v1beta1_Network_To_v1alpha3_Network(in *v1beta1.Network, out *N
 	out.APIServerBackendService = (*string)(unsafe.Pointer(in.APIServerBackendService))
 	out.APIServerTargetProxy = (*string)(unsafe.Pointer(in.APIServerTargetProxy))
 	out.APIServerForwardingRule = (*string)(unsafe.Pointer(in.APIServerForwardingRule))
+	// WARNING: in.FirewallPolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Network_To_v1alpha3_Network is an autogenerated conversion function.
-func Convert_v1beta1_Network_To_v1alpha3_Network(in *v1beta1.Network, out *Network, s conversion.Scope) error {
-	return autoConvert_v1beta1_Network_To_v1alpha3_Network(in, out, s)
-}
-
 func autoConvert_v1alpha3_NetworkSpec_To_v1beta1_NetworkSpec(in *NetworkSpec, out *v1beta1.NetworkSpec, s conversion.Scope) error {
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.AutoCreateSubnetworks = (*bool)(unsafe.Pointer(in.AutoCreateSubnetworks))
@@ -817,14 +825,12 @@ func autoConvert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in *v1beta1.Network
 		out.Subnets = nil
 	}
 	out.LoadBalancerBackendPort = (*int32)(unsafe.Pointer(in.LoadBalancerBackendPort))
+	// WARNING: in.Routes requires manual conversion: does not exist in peer-type
+	// WARNING: in.FirewallPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.Router requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec is an autogenerated conversion function.
-func Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in *v1beta1.NetworkSpec, out *NetworkSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_ServiceAccount_To_v1beta1_ServiceAccount(in *ServiceAccount, out *v1beta1.ServiceAccount, s conversion.Scope) error {
 	out.Email = in.Email
 	out.Scopes = *(*[]string)(unsafe.Pointer(&in.Scopes))
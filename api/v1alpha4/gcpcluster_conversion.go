@@ -52,10 +52,47 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 		dst.Spec.CredentialsRef = restored.Spec.CredentialsRef.DeepCopy()
 	}
 
+	if restored.Spec.ServiceAccountImpersonation != nil {
+		dst.Spec.ServiceAccountImpersonation = restored.Spec.ServiceAccountImpersonation.DeepCopy()
+	}
+
+	if restored.Spec.IdentityRef != nil {
+		dst.Spec.IdentityRef = restored.Spec.IdentityRef.DeepCopy()
+	}
+
 	for _, restoredTag := range restored.Spec.ResourceManagerTags {
 		dst.Spec.ResourceManagerTags = append(dst.Spec.ResourceManagerTags, *restoredTag.DeepCopy())
 	}
 
+	if restored.Spec.FailureDomainSpecs != nil {
+		dst.Spec.FailureDomainSpecs = restored.Spec.FailureDomainSpecs
+	}
+
+	if restored.Spec.Network.Routes != nil {
+		dst.Spec.Network.Routes = restored.Spec.Network.Routes.DeepCopy()
+	}
+
+	if restored.Spec.Network.FirewallPolicy != nil {
+		dst.Spec.Network.FirewallPolicy = restored.Spec.Network.FirewallPolicy.DeepCopy()
+	}
+
+	if restored.Spec.Network.Router != nil {
+		dst.Spec.Network.Router = restored.Spec.Network.Router
+	}
+
+	if restored.Spec.LoadBalancer != nil {
+		dst.Spec.LoadBalancer = restored.Spec.LoadBalancer.DeepCopy()
+	}
+
+	if restored.Status.Network.FirewallPolicy != nil {
+		dst.Status.Network.FirewallPolicy = restored.Status.Network.FirewallPolicy
+	}
+
+	dst.Status.PendingOperations = restored.Status.PendingOperations
+	dst.Status.LabelKeys = restored.Status.LabelKeys
+	dst.Status.ResourceManagerTagValues = restored.Status.ResourceManagerTagValues
+	dst.Status.Conditions = restored.Status.Conditions
+
 	return nil
 }
 
@@ -96,3 +133,18 @@ func Convert_v1beta1_SubnetSpec_To_v1alpha4_SubnetSpec(in *infrav1beta1.SubnetSp
 func Convert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(in *v1beta1.GCPClusterSpec, out *GCPClusterSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(in, out, s)
 }
+
+// Convert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec is an autogenerated conversion function.
+func Convert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in *infrav1beta1.NetworkSpec, out *NetworkSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in, out, s)
+}
+
+// Convert_v1beta1_Network_To_v1alpha4_Network is an autogenerated conversion function.
+func Convert_v1beta1_Network_To_v1alpha4_Network(in *infrav1beta1.Network, out *Network, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Network_To_v1alpha4_Network(in, out, s)
+}
+
+// Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus is an autogenerated conversion function.
+func Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in *infrav1beta1.GCPClusterStatus, out *GCPClusterStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in, out, s)
+}
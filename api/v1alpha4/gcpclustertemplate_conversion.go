@@ -54,10 +54,38 @@ func (src *GCPClusterTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 		dst.Spec.Template.Spec.CredentialsRef = restored.Spec.Template.Spec.CredentialsRef.DeepCopy()
 	}
 
+	if restored.Spec.Template.Spec.ServiceAccountImpersonation != nil {
+		dst.Spec.Template.Spec.ServiceAccountImpersonation = restored.Spec.Template.Spec.ServiceAccountImpersonation.DeepCopy()
+	}
+
+	if restored.Spec.Template.Spec.IdentityRef != nil {
+		dst.Spec.Template.Spec.IdentityRef = restored.Spec.Template.Spec.IdentityRef.DeepCopy()
+	}
+
 	for _, restoredTag := range restored.Spec.Template.Spec.ResourceManagerTags {
 		dst.Spec.Template.Spec.ResourceManagerTags = append(dst.Spec.Template.Spec.ResourceManagerTags, *restoredTag.DeepCopy())
 	}
 
+	if restored.Spec.Template.Spec.FailureDomainSpecs != nil {
+		dst.Spec.Template.Spec.FailureDomainSpecs = restored.Spec.Template.Spec.FailureDomainSpecs
+	}
+
+	if restored.Spec.Template.Spec.Network.Routes != nil {
+		dst.Spec.Template.Spec.Network.Routes = restored.Spec.Template.Spec.Network.Routes.DeepCopy()
+	}
+
+	if restored.Spec.Template.Spec.Network.FirewallPolicy != nil {
+		dst.Spec.Template.Spec.Network.FirewallPolicy = restored.Spec.Template.Spec.Network.FirewallPolicy.DeepCopy()
+	}
+
+	if restored.Spec.Template.Spec.Network.Router != nil {
+		dst.Spec.Template.Spec.Network.Router = restored.Spec.Template.Spec.Network.Router
+	}
+
+	if restored.Spec.Template.Spec.LoadBalancer != nil {
+		dst.Spec.Template.Spec.LoadBalancer = restored.Spec.Template.Spec.LoadBalancer.DeepCopy()
+	}
+
 	return nil
 }
 
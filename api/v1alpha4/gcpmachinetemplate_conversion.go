@@ -54,6 +54,14 @@ func (src *GCPMachineTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 		dst.Spec.Template.Spec.ConfidentialCompute = restored.Spec.Template.Spec.ConfidentialCompute
 	}
 
+	if restored.Spec.Template.Spec.BootstrapCheck != nil {
+		dst.Spec.Template.Spec.BootstrapCheck = restored.Spec.Template.Spec.BootstrapCheck
+	}
+
+	if restored.Spec.Template.Spec.ExistingInstance != nil {
+		dst.Spec.Template.Spec.ExistingInstance = restored.Spec.Template.Spec.ExistingInstance
+	}
+
 	if restored.Spec.Template.Spec.ResourceManagerTags != nil {
 		dst.Spec.Template.Spec.ResourceManagerTags = restored.Spec.Template.Spec.ResourceManagerTags
 	}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	// NetworkInfrastructureReadyCondition reports on the successful reconciliation of the GCE resources of a cluster:
+	// network, firewall rules, load balancer, subnets and routes.
+	NetworkInfrastructureReadyCondition clusterv1.ConditionType = "NetworkInfrastructureReady"
+	// InstanceReadyCondition reports on the successful reconciliation of the GCE instance of a machine.
+	InstanceReadyCondition clusterv1.ConditionType = "InstanceReady"
+	// InstanceAdoptedCondition reports whether the existing instance adopted by a machine matches its spec.
+	InstanceAdoptedCondition clusterv1.ConditionType = "InstanceAdopted"
+	// CredentialsValidCondition reports whether the credentials of a cluster can authenticate with GCP.
+	CredentialsValidCondition clusterv1.ConditionType = "CredentialsValid"
+	// PreflightChecksPassedCondition reports whether the project of a cluster has the APIs, permissions and quotas
+	// needed to create its resources. It is checked until the cluster is ready.
+	PreflightChecksPassedCondition clusterv1.ConditionType = "PreflightChecksPassed"
+	// BootstrapSucceededCondition reports whether the instance of a machine with a bootstrap check reported the
+	// success of its bootstrap.
+	BootstrapSucceededCondition clusterv1.ConditionType = "BootstrapSucceeded"
+
+	// The reasons of the conditions reporting GCP API errors are the categories of the errors,
+	// see sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors.Category.
+
+	// ReconcileFailedReason used when the reconciliation failed with an error which is not classified.
+	ReconcileFailedReason = "ReconcileFailed"
+	// InstanceNotRunningReason used when the GCE instance of a machine is not running yet.
+	InstanceNotRunningReason = "InstanceNotRunning"
+	// InstanceSpecMismatchReason used when the existing instance adopted by a machine differs from its spec.
+	InstanceSpecMismatchReason = "InstanceSpecMismatch"
+	// CredentialsInvalidReason used when the credentials of a cluster cannot get an access token.
+	CredentialsInvalidReason = "CredentialsInvalid"
+	// PreflightChecksFailedReason used when the project of a cluster lacks an API, a permission or a quota, which
+	// are listed in the message of the condition.
+	PreflightChecksFailedReason = "PreflightChecksFailed"
+	// WaitingForBootstrapReason used while the instance of a machine has not reported the result of its bootstrap.
+	WaitingForBootstrapReason = "WaitingForBootstrap"
+	// BootstrapFailedReason used when the instance of a machine reported the failure of its bootstrap.
+	BootstrapFailedReason = "BootstrapFailed"
+	// BootstrapTimedOutReason used when the instance of a machine did not report the success of its bootstrap
+	// before the timeout of its bootstrap check.
+	BootstrapTimedOutReason = "BootstrapTimedOut"
+)
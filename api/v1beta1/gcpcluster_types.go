@@ -25,6 +25,37 @@ const (
 	// ClusterFinalizer allows ReconcileGCPCluster to clean up GCP resources associated with GCPCluster before
 	// removing it from the apiserver.
 	ClusterFinalizer = "gcpcluster.infrastructure.cluster.x-k8s.io"
+
+	// ExternalResourceGCAnnotation is the annotation used to opt a GCPCluster out of the garbage collection of
+	// the resources created by its cloud controller manager and CSI driver, by setting it to "false".
+	ExternalResourceGCAnnotation = "infrastructure.cluster.x-k8s.io/external-resource-gc"
+
+	// DryRunAnnotation is the annotation making the reconciles of a GCPCluster or GCPMachine plan the changes of
+	// their GCP resources instead of applying them, when set to "true". The plan is written to a ConfigMap and the
+	// object itself is not patched. The machines of a cluster in dry run are in dry run too.
+	DryRunAnnotation = "infrastructure.cluster.x-k8s.io/dry-run"
+
+	// OrphanOnDeleteAnnotation is the annotation making the delete of a GCPCluster, GCPMachine or of the GKE types
+	// keep their GCP resources, when set to "true". The CAPG ownership labels of the resources are removed and the
+	// finalizer is removed without deleting them. The machines of an orphaned cluster are orphaned too.
+	OrphanOnDeleteAnnotation = "infrastructure.cluster.x-k8s.io/orphan-on-delete"
+
+	// SkipPreflightChecksAnnotation is the annotation making the reconciles of a GCPCluster or of a
+	// GCPManagedControlPlane skip the preflight checks of their project, when set to "true". The GKE clusters of a
+	// GCPManagedCluster with the annotation skip them too.
+	SkipPreflightChecksAnnotation = "infrastructure.cluster.x-k8s.io/skip-preflight-checks"
+
+	// ResourceNamingAnnotation is the annotation recording the scheme of the names of the GCE resources of a cluster.
+	// It is set when the cluster is first reconciled: to ResourceNamingLegacy for the clusters created before the
+	// namespaced scheme, so their resources keep their names, and to ResourceNamingNamespaced otherwise.
+	ResourceNamingAnnotation = "infrastructure.cluster.x-k8s.io/resource-naming"
+
+	// ResourceNamingLegacy names the resources after the cluster or machine name only.
+	ResourceNamingLegacy = "legacy"
+
+	// ResourceNamingNamespaced appends a hash of the namespace and name to the names of the resources, so the
+	// clusters with the same name in different namespaces of a project do not collide.
+	ResourceNamingNamespaced = "namespaced"
 )
 
 // GCPClusterSpec defines the desired state of GCPCluster.
@@ -43,12 +74,23 @@ type GCPClusterSpec struct {
 	// +optional
 	Network NetworkSpec `json:"network"`
 
+	// LoadBalancer references existing components of the control plane load balancer to use instead of
+	// creating them.
+	// +optional
+	LoadBalancer *LoadBalancerSpec `json:"loadBalancer,omitempty"`
+
 	// FailureDomains is an optional field which is used to assign selected availability zones to a cluster
 	// FailureDomains if empty, defaults to all the zones in the selected region and if specified would override
 	// the default zones.
 	// +optional
 	FailureDomains []string `json:"failureDomains,omitempty"`
 
+	// FailureDomainSpecs is an optional field which is used to assign selected availability zones to a cluster
+	// along with their control plane suitability, weight and attributes. It replaces FailureDomains and cannot
+	// be set with it.
+	// +optional
+	FailureDomainSpecs []FailureDomainSpec `json:"failureDomainSpecs,omitempty"`
+
 	// AdditionalLabels is an optional set of tags to add to GCP resources managed by the GCP provider, in addition to the
 	// ones added by default.
 	// +optional
@@ -64,6 +106,17 @@ type GCPClusterSpec struct {
 	// supplied then the credentials of the controller will be used.
 	// +optional
 	CredentialsRef *ObjectReference `json:"credentialsRef,omitempty"`
+
+	// ServiceAccountImpersonation is an optional service account impersonated with the credentials to provision this
+	// cluster, which lets a single identity of the controller manage the clusters of many projects without storing
+	// their keys.
+	// +optional
+	ServiceAccountImpersonation *ServiceAccountImpersonation `json:"serviceAccountImpersonation,omitempty"`
+
+	// IdentityRef is an optional reference to a cluster identity to use for provisioning this cluster, instead of
+	// CredentialsRef and ServiceAccountImpersonation. The namespace of the cluster must be allowed by the identity.
+	// +optional
+	IdentityRef *GCPIdentityReference `json:"identityRef,omitempty"`
 }
 
 // GCPClusterStatus defines the observed state of GCPCluster.
@@ -71,8 +124,28 @@ type GCPClusterStatus struct {
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Network        Network                  `json:"network,omitempty"`
 
+	// PendingOperations are the GCE operations started for the cluster that are not done yet.
+	// +optional
+	PendingOperations []Operation `json:"pendingOperations,omitempty"`
+
+	// LabelKeys are the keys of the labels set by CAPG on the address and the forwarding rule created for the control
+	// plane load balancer. Only these labels are removed when they are removed from the spec, the labels set by other
+	// means are left untouched.
+	// +optional
+	LabelKeys []string `json:"labelKeys,omitempty"`
+
+	// ResourceManagerTagValues are the resource names of the tag values bound by CAPG to the resources created for
+	// the cluster, e.g. tagValues/123. Only the bindings of these tag values are deleted when tags are removed from
+	// the spec, the bindings created by other means are left untouched.
+	// +optional
+	ResourceManagerTagValues []string `json:"resourceManagerTagValues,omitempty"`
+
 	// Bastion Instance `json:"bastion,omitempty"`
 	Ready bool `json:"ready"`
+
+	// Conditions defines current service state of the GCPCluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -102,6 +175,16 @@ type GCPClusterList struct {
 	Items           []GCPCluster `json:"items"`
 }
 
+// GetConditions returns the observations of the operational state of the GCPCluster resource.
+func (r *GCPCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPCluster to the predescribed clusterv1.Conditions.
+func (r *GCPCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&GCPCluster{}, &GCPClusterList{})
 }
@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -52,8 +54,16 @@ func (c *GCPCluster) Default() {
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (c *GCPCluster) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", c.Name)
+	allErrs := validateFailureDomains(c.Spec.Region, c.Spec.FailureDomains, c.Spec.FailureDomainSpecs, field.NewPath("spec"))
+	allErrs = append(allErrs, ValidateIdentityRef(c.Spec.IdentityRef, c.Spec.CredentialsRef, c.Spec.ServiceAccountImpersonation, field.NewPath("spec"))...)
+	allErrs = append(allErrs, ValidateCredentialsRef(c.Namespace, c.Spec.CredentialsRef, field.NewPath("spec", "credentialsRef"))...)
+	allErrs = append(allErrs, validateLoadBalancer(c.Spec.LoadBalancer, field.NewPath("spec", "loadBalancer"))...)
+	allErrs = append(allErrs, validateRoutes(c.Spec.Network.Routes, field.NewPath("spec", "network", "routes"))...)
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
 
-	return nil, nil
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPCluster").GroupKind(), c.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -83,6 +93,42 @@ func (c *GCPCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings,
 		)
 	}
 
+	if !reflect.DeepEqual(c.Spec.ServiceAccountImpersonation, old.Spec.ServiceAccountImpersonation) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "ServiceAccountImpersonation"),
+				c.Spec.ServiceAccountImpersonation, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.IdentityRef, old.Spec.IdentityRef) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "IdentityRef"),
+				c.Spec.IdentityRef, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.LoadBalancer, old.Spec.LoadBalancer) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "loadBalancer"),
+				c.Spec.LoadBalancer, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.Network.Router, old.Spec.Network.Router) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "router"),
+				c.Spec.Network.Router, "field is immutable"),
+		)
+	}
+
+	allErrs = append(allErrs, ValidateResourceNamingUpdate(c.Annotations, old.Annotations)...)
+
+	allErrs = append(allErrs, validateFailureDomains(c.Spec.Region, c.Spec.FailureDomains, c.Spec.FailureDomainSpecs, field.NewPath("spec"))...)
+
+	allErrs = append(allErrs, validateLoadBalancer(c.Spec.LoadBalancer, field.NewPath("spec", "loadBalancer"))...)
+
+	allErrs = append(allErrs, validateRoutes(c.Spec.Network.Routes, field.NewPath("spec", "network", "routes"))...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -96,3 +142,124 @@ func (c *GCPCluster) ValidateDelete() (admission.Warnings, error) {
 
 	return nil, nil
 }
+
+// ValidateResourceNamingUpdate checks that the naming scheme of the resources of a cluster is not changed once recorded.
+func ValidateResourceNamingUpdate(annotations, oldAnnotations map[string]string) field.ErrorList {
+	oldNaming, ok := oldAnnotations[ResourceNamingAnnotation]
+	if !ok || annotations[ResourceNamingAnnotation] == oldNaming {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Invalid(field.NewPath("metadata", "annotations", ResourceNamingAnnotation),
+			annotations[ResourceNamingAnnotation], "annotation is immutable"),
+	}
+}
+
+// ValidateCredentialsRef checks that the credentials Secret of a cluster is in the namespace of the cluster.
+// Credentials shared by the clusters of several namespaces are provided by identities instead.
+func ValidateCredentialsRef(namespace string, credentialsRef *ObjectReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if credentialsRef != nil && credentialsRef.Namespace != namespace {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), credentialsRef.Namespace, "must be the namespace of the cluster, use identityRef to share credentials across namespaces"))
+	}
+
+	return allErrs
+}
+
+// ValidateIdentityRef checks that a cluster referencing an identity does not also reference credentials
+// or a service account to impersonate, which are provided by the identity.
+func ValidateIdentityRef(identityRef *GCPIdentityReference, credentialsRef *ObjectReference, impersonation *ServiceAccountImpersonation, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if identityRef == nil {
+		return allErrs
+	}
+
+	if credentialsRef != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("credentialsRef"), "cannot be set with identityRef"))
+	}
+
+	if impersonation != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("serviceAccountImpersonation"), "cannot be set with identityRef"))
+	}
+
+	return allErrs
+}
+
+// validateFailureDomains checks that only one of failureDomains and failureDomainSpecs is set
+// and that the failure domains are distinct zones of the region.
+func validateFailureDomains(region string, failureDomains []string, failureDomainSpecs []FailureDomainSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(failureDomains) > 0 && len(failureDomainSpecs) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("failureDomainSpecs"), "cannot be set with failureDomains"))
+	}
+
+	zones := map[string]bool{}
+	for i, zone := range failureDomains {
+		allErrs = append(allErrs, validateZone(region, zone, zones, fldPath.Child("failureDomains").Index(i))...)
+	}
+
+	for i, fd := range failureDomainSpecs {
+		allErrs = append(allErrs, validateZone(region, fd.Zone, zones, fldPath.Child("failureDomainSpecs").Index(i).Child("zone"))...)
+	}
+
+	return allErrs
+}
+
+// validateZone checks that the zone belongs to the region and was not already seen.
+func validateZone(region, zone string, seen map[string]bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !strings.HasPrefix(zone, region+"-") {
+		allErrs = append(allErrs, field.Invalid(fldPath, zone, fmt.Sprintf("zone must be in region %s", region)))
+	}
+
+	if seen[zone] {
+		allErrs = append(allErrs, field.Duplicate(fldPath, zone))
+	}
+	seen[zone] = true
+
+	return allErrs
+}
+
+// validateLoadBalancer checks that the health check is only referenced for the backend service created by CAPG.
+func validateLoadBalancer(lb *LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil {
+		return allErrs
+	}
+
+	if lb.HealthCheck != nil && lb.BackendService != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("healthCheck"), "cannot be set with backendService, which has its own health checks"))
+	}
+
+	return allErrs
+}
+
+// validateRoutes checks that every static route has exactly one next hop and a distinct name.
+func validateRoutes(routes *RoutesSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if routes == nil {
+		return allErrs
+	}
+
+	names := make(map[string]bool, len(routes.StaticRoutes))
+	for i, route := range routes.StaticRoutes {
+		routePath := fldPath.Child("staticRoutes").Index(i)
+		if names[route.Name] {
+			allErrs = append(allErrs, field.Duplicate(routePath.Child("name"), route.Name))
+		}
+		names[route.Name] = true
+
+		nextHops := 0
+		for _, nextHop := range []*string{route.NextHopInstance, route.NextHopIP, route.NextHopGateway} {
+			if nextHop != nil && *nextHop != "" {
+				nextHops++
+			}
+		}
+		if nextHops != 1 {
+			allErrs = append(allErrs, field.Invalid(routePath, route.Name, "exactly one of nextHopInstance, nextHopIP or nextHopGateway must be set"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGCPCluster_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name       string
+		newCluster *GCPCluster
+		wantErr    bool
+	}{
+		{
+			name: "GCPCluster with failure domains in the region",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					FailureDomainSpecs: []FailureDomainSpec{
+						{Zone: "us-central1-a"},
+						{Zone: "us-central1-d", ControlPlane: pointer.Bool(false)},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with a failure domain outside the region",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					FailureDomains: []string{"us-east1-b"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a duplicate failure domain",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					FailureDomainSpecs: []FailureDomainSpec{
+						{Zone: "us-central1-a"},
+						{Zone: "us-central1-a"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with failure domains and failure domain specs",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:             "us-central1",
+					FailureDomains:     []string{"us-central1-a"},
+					FailureDomainSpecs: []FailureDomainSpec{{Zone: "us-central1-b"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an identity",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:      "us-central1",
+					IdentityRef: &GCPIdentityReference{Kind: GCPClusterStaticIdentityKind, Name: "tenant"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with credentials in its namespace",
+			newCluster: &GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					CredentialsRef: &ObjectReference{Namespace: "default", Name: "credentials"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with credentials in another namespace",
+			newCluster: &GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					CredentialsRef: &ObjectReference{Namespace: "capg-system", Name: "credentials"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an identity and credentials",
+			newCluster: &GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					IdentityRef:    &GCPIdentityReference{Kind: GCPClusterStaticIdentityKind, Name: "tenant"},
+					CredentialsRef: &ObjectReference{Namespace: "default", Name: "credentials"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an existing backend service",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:       "us-central1",
+					LoadBalancer: &LoadBalancerSpec{BackendService: pointer.String("apiserver")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with an existing backend service and health check",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					LoadBalancer: &LoadBalancerSpec{
+						BackendService: pointer.String("apiserver"),
+						HealthCheck:    pointer.String("apiserver"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a static route with one next hop",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{Routes: &RoutesSpec{StaticRoutes: []StaticRouteSpec{
+						{Name: "egress", DestRange: "0.0.0.0/0", NextHopGateway: pointer.String("global/gateways/default-internet-gateway")},
+					}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with a static route without next hop",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{Routes: &RoutesSpec{StaticRoutes: []StaticRouteSpec{
+						{Name: "egress", DestRange: "0.0.0.0/0"},
+					}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a static route with two next hops",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{Routes: &RoutesSpec{StaticRoutes: []StaticRouteSpec{
+						{Name: "egress", DestRange: "0.0.0.0/0", NextHopIP: pointer.String("10.0.0.2"), NextHopGateway: pointer.String("global/gateways/default-internet-gateway")},
+					}}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			warn, err := test.newCluster.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
+
+func TestGCPCluster_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name       string
+		oldCluster *GCPCluster
+		newCluster *GCPCluster
+		wantErr    bool
+	}{
+		{
+			name: "GCPCluster with unchanged service account impersonation",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:                      "us-central1",
+					ServiceAccountImpersonation: &ServiceAccountImpersonation{TargetServiceAccount: "capg@my-proj.iam.gserviceaccount.com"},
+				},
+			},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:                      "us-central1",
+					ServiceAccountImpersonation: &ServiceAccountImpersonation{TargetServiceAccount: "capg@my-proj.iam.gserviceaccount.com"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with a changed service account impersonation",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+				},
+			},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:                      "us-central1",
+					ServiceAccountImpersonation: &ServiceAccountImpersonation{TargetServiceAccount: "capg@my-proj.iam.gserviceaccount.com"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a changed load balancer",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:       "us-central1",
+					LoadBalancer: &LoadBalancerSpec{BackendService: pointer.String("shared-apiserver")},
+				},
+			},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:       "us-central1",
+					LoadBalancer: &LoadBalancerSpec{BackendService: pointer.String("other-apiserver")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an added router",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+				},
+			},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:  "us-central1",
+					Network: NetworkSpec{Router: pointer.String("shared-router")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster recording its resource naming scheme",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+				},
+			},
+			newCluster: &GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ResourceNamingAnnotation: ResourceNamingNamespaced},
+				},
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with a changed resource naming scheme",
+			oldCluster: &GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ResourceNamingAnnotation: ResourceNamingLegacy},
+				},
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+				},
+			},
+			newCluster: &GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ResourceNamingAnnotation: ResourceNamingNamespaced},
+				},
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			warn, err := test.newCluster.ValidateUpdate(test.oldCluster)
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GCPIdentityKind is the kind of a cluster identity.
+type GCPIdentityKind string
+
+const (
+	// GCPClusterStaticIdentityKind is the kind of the identities backed by a credentials Secret.
+	GCPClusterStaticIdentityKind GCPIdentityKind = "GCPClusterStaticIdentity"
+	// GCPClusterImpersonationIdentityKind is the kind of the identities impersonating a service account.
+	GCPClusterImpersonationIdentityKind GCPIdentityKind = "GCPClusterImpersonationIdentity"
+)
+
+// GCPIdentityReference is a reference to a cluster identity.
+type GCPIdentityReference struct {
+	// Kind of the identity.
+	// +kubebuilder:validation:Enum=GCPClusterStaticIdentity;GCPClusterImpersonationIdentity
+	Kind GCPIdentityKind `json:"kind"`
+
+	// Name of the identity.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// AllowedNamespaces selects the namespaces of the clusters allowed to use an identity.
+// A namespace is allowed when it is listed or matches the selector.
+type AllowedNamespaces struct {
+	// NamespaceList is a list of namespaces allowed to use the identity.
+	// +optional
+	NamespaceList []string `json:"list,omitempty"`
+
+	// Selector is a label selector of the namespaces allowed to use the identity.
+	// An empty selector selects all the namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// GCPClusterIdentitySpec is the spec shared by the cluster identities.
+type GCPClusterIdentitySpec struct {
+	// AllowedNamespaces selects the namespaces of the clusters allowed to use the identity.
+	// If nil, no namespace is allowed.
+	// +optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// GCPClusterStaticIdentitySpec defines the desired state of GCPClusterStaticIdentity.
+type GCPClusterStaticIdentitySpec struct {
+	GCPClusterIdentitySpec `json:",inline"`
+
+	// SecretRef is a reference to the Secret that contains the credentials of the identity,
+	// under the "credentials" key. It should only be readable by the controller.
+	SecretRef ObjectReference `json:"secretRef"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpclusterstaticidentities,scope=Cluster,categories=cluster-api
+// +kubebuilder:storageversion
+
+// GCPClusterStaticIdentity is the Schema for the gcpclusterstaticidentities API.
+// It represents the credentials stored in a Secret, which the clusters of the allowed namespaces can use.
+type GCPClusterStaticIdentity struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GCPClusterStaticIdentitySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPClusterStaticIdentityList contains a list of GCPClusterStaticIdentity.
+type GCPClusterStaticIdentityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPClusterStaticIdentity `json:"items"`
+}
+
+// GCPClusterImpersonationIdentitySpec defines the desired state of GCPClusterImpersonationIdentity.
+type GCPClusterImpersonationIdentitySpec struct {
+	GCPClusterIdentitySpec      `json:",inline"`
+	ServiceAccountImpersonation `json:",inline"`
+
+	// SecretRef is an optional reference to the Secret that contains the credentials impersonating
+	// the service account, under the "credentials" key. If not supplied then the credentials of
+	// the controller will be used.
+	// +optional
+	SecretRef *ObjectReference `json:"secretRef,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpclusterimpersonationidentities,scope=Cluster,categories=cluster-api
+// +kubebuilder:storageversion
+
+// GCPClusterImpersonationIdentity is the Schema for the gcpclusterimpersonationidentities API.
+// It represents a service account, which the clusters of the allowed namespaces can impersonate.
+type GCPClusterImpersonationIdentity struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GCPClusterImpersonationIdentitySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPClusterImpersonationIdentityList contains a list of GCPClusterImpersonationIdentity.
+type GCPClusterImpersonationIdentityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPClusterImpersonationIdentity `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&GCPClusterStaticIdentity{}, &GCPClusterStaticIdentityList{},
+		&GCPClusterImpersonationIdentity{}, &GCPClusterImpersonationIdentityList{},
+	)
+}
@@ -22,6 +22,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -52,8 +53,13 @@ var _ webhook.Validator = &GCPClusterTemplate{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *GCPClusterTemplate) ValidateCreate() (admission.Warnings, error) {
 	gcpclustertemplatelog.Info("validate create", "name", r.Name)
+	spec := r.Spec.Template.Spec
+	allErrs := validateFailureDomains(spec.Region, spec.FailureDomains, spec.FailureDomainSpecs, field.NewPath("spec", "template", "spec"))
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
 
-	return nil, nil
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPClusterTemplate").GroupKind(), r.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
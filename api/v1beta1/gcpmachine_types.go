@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
@@ -160,6 +161,12 @@ type GCPMachineSpec struct {
 	// +optional
 	ProviderID *string `json:"providerID,omitempty"`
 
+	// ExistingInstance is the name, or the provider ID of the form gce://<project>/<zone>/<name>, of an existing
+	// GCE instance to adopt instead of creating a new one. The instance must be in the project and zone of the
+	// machine and in the network of the cluster. It is labelled as owned by the cluster and deleted with the machine.
+	// +optional
+	ExistingInstance *string `json:"existingInstance,omitempty"`
+
 	// ImageFamily is the full reference to a valid image family to be used for this machine.
 	// +optional
 	ImageFamily *string `json:"imageFamily,omitempty"`
@@ -252,6 +259,38 @@ type GCPMachineSpec struct {
 	// +kubebuilder:validation:Enum=Enabled;Disabled
 	// +optional
 	ConfidentialCompute *ConfidentialComputePolicy `json:"confidentialCompute,omitempty"`
+
+	// BootstrapCheck enables the detection of the bootstrap success of the instance: the machine is not ready
+	// until the instance reports that its bootstrap succeeded, and fails if it reports a failure or does not
+	// report in time. It is ignored for an existing instance.
+	// +optional
+	BootstrapCheck *BootstrapCheck `json:"bootstrapCheck,omitempty"`
+}
+
+// BootstrapCheckMethod is how the instance of a machine reports the result of its bootstrap.
+type BootstrapCheckMethod string
+
+const (
+	// BootstrapCheckMethodGuestAttributes reads the result from the capg/bootstrap guest attribute of the instance.
+	BootstrapCheckMethodGuestAttributes BootstrapCheckMethod = "GuestAttributes"
+	// BootstrapCheckMethodSerialConsole reads the result from the output of the serial console of the instance.
+	BootstrapCheckMethodSerialConsole BootstrapCheckMethod = "SerialConsole"
+)
+
+// BootstrapCheck describes the detection of the bootstrap success of the instance of a machine. The result is
+// reported by a startup script added to the instance, which waits for the end of the bootstrap.
+type BootstrapCheck struct {
+	// Method is how the instance reports the result of its bootstrap.
+	// Defaults to GuestAttributes.
+	// +kubebuilder:validation:Enum=GuestAttributes;SerialConsole
+	// +kubebuilder:default=GuestAttributes
+	// +optional
+	Method BootstrapCheckMethod `json:"method,omitempty"`
+
+	// Timeout is how long after the creation of the instance its bootstrap must succeed before the machine fails.
+	// Defaults to 20m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // MetadataItem defines a single piece of metadata associated with an instance.
@@ -275,6 +314,35 @@ type GCPMachineStatus struct {
 	// +optional
 	InstanceStatus *InstanceStatus `json:"instanceState,omitempty"`
 
+	// Zone is the zone the instance is placed in when the Machine has no failure domain.
+	// It is chosen once so that the instance stays in the same zone across reconciles.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// AdoptedInstance is the self link of the existing instance adopted by the machine, see spec.existingInstance.
+	// +optional
+	AdoptedInstance *string `json:"adoptedInstance,omitempty"`
+
+	// PendingOperations are the GCE operations started for the machine that are not done yet.
+	// +optional
+	PendingOperations []Operation `json:"pendingOperations,omitempty"`
+
+	// BootstrapSerialOffset is the byte position in the serial console output of the instance up to which the
+	// SerialConsole bootstrap check looked for the result of the bootstrap.
+	// +optional
+	BootstrapSerialOffset *int64 `json:"bootstrapSerialOffset,omitempty"`
+
+	// LabelKeys are the keys of the labels set by CAPG on the instance and its disks. Only these labels are removed
+	// when they are removed from the spec, the labels set by other means are left untouched.
+	// +optional
+	LabelKeys []string `json:"labelKeys,omitempty"`
+
+	// ResourceManagerTagValues are the resource names of the tag values bound by CAPG to the instance and its disks,
+	// e.g. tagValues/123. Only the bindings of these tag values are deleted when tags are removed from the spec,
+	// the bindings created by other means are left untouched.
+	// +optional
+	ResourceManagerTagValues []string `json:"resourceManagerTagValues,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -312,6 +380,10 @@ type GCPMachineStatus struct {
 	// controller's output.
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Conditions defines current service state of the GCPMachine.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -342,6 +414,16 @@ type GCPMachineList struct {
 	Items           []GCPMachine `json:"items"`
 }
 
+// GetConditions returns the observations of the operational state of the GCPMachine resource.
+func (r *GCPMachine) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPMachine to the predescribed clusterv1.Conditions.
+func (r *GCPMachine) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&GCPMachine{}, &GCPMachineList{})
 }
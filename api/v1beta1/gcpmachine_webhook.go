@@ -50,6 +50,14 @@ var _ webhook.Validator = &GCPMachine{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (m *GCPMachine) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", m.Name)
+	if err := validateExistingInstance(m.Spec); err != nil {
+		return nil, err
+	}
+
+	if err := validateBootstrapCheck(m.Spec, field.NewPath("spec")); err != nil {
+		return nil, err
+	}
+
 	return nil, validateConfidentialCompute(m.Spec)
 }
 
@@ -117,3 +125,43 @@ func validateConfidentialCompute(spec GCPMachineSpec) error {
 	}
 	return nil
 }
+
+// validateBootstrapCheck checks that a spec with a bootstrap check has no startup script of its own, which would
+// replace the one reporting the result of the bootstrap.
+func validateBootstrapCheck(spec GCPMachineSpec, fldPath *field.Path) error {
+	if spec.BootstrapCheck == nil {
+		return nil
+	}
+
+	for i, item := range spec.AdditionalMetadata {
+		if item.Key == "startup-script" {
+			return field.Forbidden(fldPath.Child("additionalMetadata").Index(i), "startup-script cannot be set with bootstrapCheck")
+		}
+	}
+
+	return nil
+}
+
+// validateExistingInstance checks that the existing instance of the spec is an instance name
+// or a provider ID of the form gce://<project>/<zone>/<name>.
+func validateExistingInstance(spec GCPMachineSpec) error {
+	if spec.ExistingInstance == nil {
+		return nil
+	}
+
+	existing := *spec.ExistingInstance
+	if existing == "" {
+		return field.Invalid(field.NewPath("spec", "existingInstance"), existing, "must not be empty")
+	}
+
+	if !strings.HasPrefix(existing, "gce://") {
+		return nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(existing, "gce://"), "/")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return field.Invalid(field.NewPath("spec", "existingInstance"), existing, "must be an instance name or a provider ID of the form gce://<project>/<zone>/<name>")
+	}
+
+	return nil
+}
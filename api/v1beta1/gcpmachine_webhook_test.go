@@ -27,11 +27,38 @@ func TestGCPMachine_ValidateCreate(t *testing.T) {
 	confidentialComputeEnabled := ConfidentialComputePolicyEnabled
 	onHostMaintenanceTerminate := HostMaintenancePolicyTerminate
 	onHostMaintenanceMigrate := HostMaintenancePolicyMigrate
+	existingInstanceName := "my-instance"
+	existingInstanceProviderID := "gce://my-proj/us-central1-a/my-instance"
+	existingInstanceInvalidProviderID := "gce://my-proj/my-instance"
+	startupScript := "#!/bin/bash"
+	enableOSLogin := "TRUE"
 	tests := []struct {
 		name string
 		*GCPMachine
 		wantErr bool
 	}{
+		{
+			name: "GCPMachine with a bootstrap check and a startup script - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:       "n2d-standard-4",
+					BootstrapCheck:     &BootstrapCheck{},
+					AdditionalMetadata: []MetadataItem{{Key: "startup-script", Value: &startupScript}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with a bootstrap check and other metadata - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:       "n2d-standard-4",
+					BootstrapCheck:     &BootstrapCheck{},
+					AdditionalMetadata: []MetadataItem{{Key: "enable-oslogin", Value: &enableOSLogin}},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "GCPMachined with OnHostMaintenance set to Terminate - valid",
 			GCPMachine: &GCPMachine{
@@ -85,6 +112,36 @@ func TestGCPMachine_ValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "GCPMachine with an existing instance name - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:     "n2d-standard-4",
+					ExistingInstance: &existingInstanceName,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with an existing instance provider ID - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:     "n2d-standard-4",
+					ExistingInstance: &existingInstanceProviderID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with an existing instance provider ID without zone - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:     "n2d-standard-4",
+					ExistingInstance: &existingInstanceInvalidProviderID,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -47,6 +47,15 @@ var _ webhook.Validator = &GCPMachineTemplate{}
 func (r *GCPMachineTemplate) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", r.Name)
 
+	if r.Spec.Template.Spec.ExistingInstance != nil {
+		// Every machine of the template would adopt the same instance.
+		return nil, field.Forbidden(field.NewPath("spec", "template", "spec", "existingInstance"), "cannot be set in a template")
+	}
+
+	if err := validateBootstrapCheck(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec")); err != nil {
+		return nil, err
+	}
+
 	return nil, validateConfidentialCompute(r.Spec.Template.Spec)
 }
 
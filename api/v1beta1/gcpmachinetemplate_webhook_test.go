@@ -27,11 +27,40 @@ func TestGCPMachineTemplate_ValidateCreate(t *testing.T) {
 	confidentialComputeEnabled := ConfidentialComputePolicyEnabled
 	onHostMaintenanceTerminate := HostMaintenancePolicyTerminate
 	onHostMaintenanceMigrate := HostMaintenancePolicyMigrate
+	existingInstance := "my-instance"
+	startupScript := "#!/bin/bash"
 	tests := []struct {
 		name     string
 		template *GCPMachineTemplate
 		wantErr  bool
 	}{
+		{
+			name: "GCPMachineTemplate with an existing instance - invalid",
+			template: &GCPMachineTemplate{
+				Spec: GCPMachineTemplateSpec{
+					Template: GCPMachineTemplateResource{
+						Spec: GCPMachineSpec{
+							InstanceType:     "n2d-standard-4",
+							ExistingInstance: &existingInstance,
+						}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachineTemplate with a bootstrap check and a startup script - invalid",
+			template: &GCPMachineTemplate{
+				Spec: GCPMachineTemplateSpec{
+					Template: GCPMachineTemplateResource{
+						Spec: GCPMachineSpec{
+							InstanceType:       "n2d-standard-4",
+							BootstrapCheck:     &BootstrapCheck{},
+							AdditionalMetadata: []MetadataItem{{Key: "startup-script", Value: &startupScript}},
+						}},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "GCPMachineTemplate with OnHostMaintenance set to Terminate - valid",
 			template: &GCPMachineTemplate{
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"reflect"
 	"strings"
@@ -32,7 +34,7 @@ func (in Labels) Equals(other Labels) bool {
 
 // HasOwned returns true if the tags contains a tag that marks the resource as owned by the cluster from the perspective of this management tooling.
 func (in Labels) HasOwned(cluster string) bool {
-	value, ok := in[ClusterTagKey(cluster)]
+	value, ok := in[SanitizeLabelKey(ClusterTagKey(cluster))]
 
 	return ok && ResourceLifecycle(value) == ResourceLifecycleOwned
 }
@@ -95,6 +97,11 @@ const (
 	// and in particular that the lifecycle is tied to the lifecycle of the cluster.
 	ResourceLifecycleOwned = ResourceLifecycle("owned")
 
+	// ResourceLifecycleShared is the value we use when tagging resources to indicate
+	// that the resource is used by the cluster but not managed by it, and in particular
+	// that it is not deleted with the cluster.
+	ResourceLifecycleShared = ResourceLifecycle("shared")
+
 	// NameGCPProviderPrefix is the tag prefix we use to differentiate
 	// cluster-api-provider-gcp owned components from other tooling that
 	// uses NameKubernetesClusterPrefix.
@@ -144,16 +151,69 @@ type BuildParams struct {
 }
 
 // Build builds tags including the cluster tag and returns them in map form.
+// The keys and values are sanitised to the GCE label rules, see SanitizeLabelKey and SanitizeLabelValue.
 func Build(params BuildParams) Labels {
 	tags := make(Labels)
 	for k, v := range params.Additional {
-		tags[strings.ToLower(k)] = strings.ToLower(v)
+		if key := SanitizeLabelKey(k); key != "" {
+			tags[key] = SanitizeLabelValue(v)
+		}
 	}
 
-	tags[ClusterTagKey(params.ClusterName)] = string(params.Lifecycle)
+	tags[SanitizeLabelKey(ClusterTagKey(params.ClusterName))] = string(params.Lifecycle)
 	if params.Role != nil {
-		tags[NameGCPClusterAPIRole] = strings.ToLower(*params.Role)
+		tags[NameGCPClusterAPIRole] = SanitizeLabelValue(*params.Role)
 	}
 
 	return tags
 }
+
+// maxLabelLength is the maximum length of the keys and values of GCE labels.
+const maxLabelLength = 63
+
+// SanitizeLabelKey returns the key converted to the GCE label rules: lowercase letters, digits, underscores
+// and dashes, starting with a letter and at most 63 characters long. Other characters are replaced by
+// underscores, and longer keys are truncated with a hash of the key as suffix so they stay unique.
+// An empty key stays empty.
+func SanitizeLabelKey(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	sanitized := sanitizeLabel(key)
+	if sanitized[0] < 'a' || sanitized[0] > 'z' {
+		sanitized = "x-" + sanitized
+	}
+
+	return truncateLabel(sanitized, key)
+}
+
+// SanitizeLabelValue returns the value converted to the GCE label rules: lowercase letters, digits, underscores
+// and dashes, at most 63 characters long. Other characters are replaced by underscores, and longer values are
+// truncated with a hash of the value as suffix so they stay unique.
+func SanitizeLabelValue(value string) string {
+	return truncateLabel(sanitizeLabel(value), value)
+}
+
+func sanitizeLabel(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+func truncateLabel(sanitized, original string) string {
+	if len(sanitized) <= maxLabelLength {
+		return sanitized
+	}
+
+	sum := sha256.Sum256([]byte(original))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	return sanitized[:maxLabelLength-len(suffix)-1] + "-" + suffix
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBuild(t *testing.T) {
+	g := NewWithT(t)
+	role := "Control-Plane"
+	longCluster := strings.Repeat("a", 60)
+
+	labels := Build(BuildParams{
+		ClusterName: longCluster,
+		Lifecycle:   ResourceLifecycleOwned,
+		Role:        &role,
+		Additional: Labels{
+			"Cost-Center": "Team.Platform",
+			"1st":         "value",
+			"":            "dropped",
+		},
+	})
+
+	g.Expect(labels).To(HaveKeyWithValue("cost-center", "team_platform"))
+	g.Expect(labels).To(HaveKeyWithValue("x-1st", "value"))
+	g.Expect(labels).To(HaveKeyWithValue(NameGCPClusterAPIRole, "control-plane"))
+	g.Expect(labels).To(HaveLen(4))
+	g.Expect(labels.HasOwned(longCluster)).To(BeTrue())
+	g.Expect(labels.HasOwned(strings.Repeat("a", 61))).To(BeFalse())
+	for k, v := range labels {
+		g.Expect(len(k)).To(BeNumerically("<=", maxLabelLength))
+		g.Expect(len(v)).To(BeNumerically("<=", maxLabelLength))
+	}
+}
+
+func TestSanitizeLabelValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "valid value",
+			value: "team_platform-1",
+			want:  "team_platform-1",
+		},
+		{
+			name:  "uppercase and invalid characters",
+			value: "Team Platform/Ops",
+			want:  "team_platform_ops",
+		},
+		{
+			name:  "empty value",
+			value: "",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := SanitizeLabelValue(tt.value)
+			g.Expect(got).To(Equal(tt.want))
+			g.Expect(len(got)).To(BeNumerically("<=", maxLabelLength))
+		})
+	}
+}
+
+func TestSanitizeLabelValueTruncatesUniquely(t *testing.T) {
+	g := NewWithT(t)
+	first := SanitizeLabelValue(strings.Repeat("b", 70))
+	second := SanitizeLabelValue(strings.Repeat("b", 71))
+
+	g.Expect(first).To(HaveLen(maxLabelLength))
+	g.Expect(first).To(HavePrefix(strings.Repeat("b", 54) + "-"))
+	g.Expect(second).To(HaveLen(maxLabelLength))
+	g.Expect(first).NotTo(Equal(second))
+}
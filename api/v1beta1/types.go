@@ -85,6 +85,66 @@ type Network struct {
 	// created for the API Server.
 	// +optional
 	APIServerForwardingRule *string `json:"apiServerForwardingRule,omitempty"`
+
+	// FirewallPolicy is the full reference to the network firewall policy
+	// created for the cluster.
+	// +optional
+	FirewallPolicy *string `json:"firewallPolicy,omitempty"`
+}
+
+const (
+	// MachineSeriesAttribute is the failure domain attribute listing the comma separated
+	// machine series, e.g. e2,n2, available in the zone.
+	MachineSeriesAttribute = "machineSeries"
+
+	// WeightAttribute is the failure domain attribute holding the relative weight of the zone
+	// when spreading machines without failure domain.
+	WeightAttribute = "weight"
+)
+
+// Operation is a GCE operation that has been started and has not been observed as done yet.
+type Operation struct {
+	// Name is the name of the operation.
+	Name string `json:"name"`
+
+	// Region is the region of a regional operation.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Zone is the zone of a zonal operation.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// OperationType is the type of the operation, e.g. insert or delete.
+	// +optional
+	OperationType string `json:"operationType,omitempty"`
+
+	// TargetLink is the URL of the resource the operation applies to.
+	// +optional
+	TargetLink string `json:"targetLink,omitempty"`
+}
+
+// FailureDomainSpec defines a zone of the cluster region that machines can be placed in.
+type FailureDomainSpec struct {
+	// Zone is the name of the zone, e.g. us-central1-a. It must belong to the cluster region.
+	Zone string `json:"zone"`
+
+	// ControlPlane determines if the zone is suitable for control plane machines.
+	// Defaults to true.
+	// +optional
+	ControlPlane *bool `json:"controlPlane,omitempty"`
+
+	// Weight is the relative share of the machines without failure domain placed in the zone,
+	// e.g. a zone with weight 2 receives twice as many machines as a zone with weight 1.
+	// A zone with weight 0 only receives machines that explicitly select it. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Attributes is a free form map of attributes reported for the failure domain,
+	// in addition to the zone capabilities discovered by the controller.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
 // NetworkSpec encapsulates all things related to a GCP network.
@@ -112,6 +172,111 @@ type NetworkSpec struct {
 	// Allow for configuration of load balancer backend (useful for changing apiserver port)
 	// +optional
 	LoadBalancerBackendPort *int32 `json:"loadBalancerBackendPort,omitempty"`
+
+	// Routes configures the VPC routes managed for the cluster.
+	// +optional
+	Routes *RoutesSpec `json:"routes,omitempty"`
+
+	// FirewallPolicy, when set, makes the cluster use a global network firewall policy associated
+	// with the cluster network instead of VPC firewall rules keyed on network tags.
+	// +optional
+	FirewallPolicy *FirewallPolicySpec `json:"firewallPolicy,omitempty"`
+
+	// Router is the name or the self link of an existing Cloud Router of the network, in the region of the
+	// cluster, to use instead of creating a Cloud NAT router. It is shared: CAPG never updates nor deletes it.
+	// +optional
+	Router *string `json:"router,omitempty"`
+}
+
+// LoadBalancerSpec references existing components of the control plane load balancer by name or self link.
+// The components created by CAPG are owned by the cluster and deleted with it, while the referenced ones are
+// shared: CAPG labels them as shared when they support labels and never deletes them.
+type LoadBalancerSpec struct {
+	// HealthCheck is the health check of the backend service created by CAPG.
+	// +optional
+	HealthCheck *string `json:"healthCheck,omitempty"`
+
+	// BackendService is the backend service of the control plane. The instance groups of the control plane are
+	// added to its backends, and removed from them when the cluster is deleted.
+	// +optional
+	BackendService *string `json:"backendService,omitempty"`
+
+	// Address is the global address of the control plane endpoint.
+	// +optional
+	Address *string `json:"address,omitempty"`
+
+	// ForwardingRule is the global forwarding rule of the control plane endpoint. Its target TCP proxy must
+	// route to the backend service of the cluster. Its IP address is the control plane endpoint when no
+	// Address is referenced.
+	// +optional
+	ForwardingRule *string `json:"forwardingRule,omitempty"`
+}
+
+// FirewallPolicySpec configures the global network firewall policy of a cluster.
+// The default health-check and intra-cluster rules of the policy target secure tags,
+// which must be bound to the instances, e.g. through ResourceManagerTags.
+type FirewallPolicySpec struct {
+	// ControlPlaneTag is the secure tag bound to the control plane instances.
+	// The tag key must have the GCE_FIREWALL purpose.
+	ControlPlaneTag ResourceManagerTag `json:"controlPlaneTag"`
+
+	// NodeTag is the secure tag bound to the worker instances.
+	// The tag key must have the GCE_FIREWALL purpose.
+	NodeTag ResourceManagerTag `json:"nodeTag"`
+}
+
+// RoutesSpec configures the VPC routes managed for a cluster.
+type RoutesSpec struct {
+	// PodCIDRRoutes, when set to true, creates a route for every Node of the workload cluster
+	// that sends traffic for the Node's spec.podCIDR to the Node's instance. This is required
+	// by CNIs running in native routing mode without an overlay, e.g. Calico or Cilium.
+	// +optional
+	PodCIDRRoutes bool `json:"podCIDRRoutes,omitempty"`
+
+	// StaticRoutes is a list of additional routes to create in the cluster network.
+	// +optional
+	StaticRoutes []StaticRouteSpec `json:"staticRoutes,omitempty"`
+}
+
+// StaticRouteSpec configures a user-declared GCP route.
+// Exactly one of NextHopInstance, NextHopIP or NextHopGateway must be set.
+type StaticRouteSpec struct {
+	// Name defines a unique identifier to reference this resource. The GCP route is named after the cluster
+	// and this name, so that the routes of the clusters of a project do not collide.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// DestRange is the destination range of outgoing packets that this route applies to.
+	// Both IPv4 and IPv6 are supported.
+	DestRange string `json:"destRange"`
+
+	// Priority is the priority of this route, used to break ties in cases where there
+	// is more than one matching route of equal prefix length. Defaults to 1000.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Priority *int64 `json:"priority,omitempty"`
+
+	// Tags is a list of network tags to which this route applies. If empty, the route
+	// applies to all instances in the network.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// NextHopInstance is the URL, or partial URL, of an instance that should handle
+	// matching packets, e.g. zones/us-central1-a/instances/my-instance.
+	// +optional
+	NextHopInstance *string `json:"nextHopInstance,omitempty"`
+
+	// NextHopIP is the network IP address of an instance that should handle matching packets.
+	// +optional
+	NextHopIP *string `json:"nextHopIP,omitempty"`
+
+	// NextHopGateway is the URL, or partial URL, of a gateway that should handle matching
+	// packets. Only the default internet gateway is supported, e.g.
+	// global/gateways/default-internet-gateway.
+	// +optional
+	NextHopGateway *string `json:"nextHopGateway,omitempty"`
 }
 
 // SubnetSpec configures an GCP Subnet.
@@ -267,3 +432,17 @@ type ObjectReference struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 }
+
+// ServiceAccountImpersonation is a service account impersonated with the credentials of a cluster.
+type ServiceAccountImpersonation struct {
+	// TargetServiceAccount is the email of the impersonated service account. The credentials must be
+	// granted the Service Account Token Creator role on it, or on the first delegate if any.
+	// +kubebuilder:validation:Required
+	TargetServiceAccount string `json:"targetServiceAccount"`
+
+	// Delegates is the chain of service accounts impersonated in sequence to get the access tokens of the
+	// target service account. Each service account must be granted the Service Account Token Creator role
+	// on the next one, the last one on the target service account.
+	// +optional
+	Delegates []string `json:"delegates,omitempty"`
+}
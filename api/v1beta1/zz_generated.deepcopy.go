@@ -23,11 +23,37 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
+	*out = *in
+	if in.NamespaceList != nil {
+		in, out := &in.NamespaceList, &out.NamespaceList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
+func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AttachedDiskSpec) DeepCopyInto(out *AttachedDiskSpec) {
 	*out = *in
@@ -53,6 +79,26 @@ func (in *AttachedDiskSpec) DeepCopy() *AttachedDiskSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapCheck) DeepCopyInto(out *BootstrapCheck) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapCheck.
+func (in *BootstrapCheck) DeepCopy() *BootstrapCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
@@ -80,6 +126,38 @@ func (in *BuildParams) DeepCopy() *BuildParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomainSpec) DeepCopyInto(out *FailureDomainSpec) {
+	*out = *in
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomainSpec.
+func (in *FailureDomainSpec) DeepCopy() *FailureDomainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filter) DeepCopyInto(out *Filter) {
 	*out = *in
@@ -100,6 +178,23 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallPolicySpec) DeepCopyInto(out *FirewallPolicySpec) {
+	*out = *in
+	out.ControlPlaneTag = in.ControlPlaneTag
+	out.NodeTag = in.NodeTag
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallPolicySpec.
+func (in *FirewallPolicySpec) DeepCopy() *FirewallPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPCluster) DeepCopyInto(out *GCPCluster) {
 	*out = *in
@@ -127,6 +222,106 @@ func (in *GCPCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterIdentitySpec) DeepCopyInto(out *GCPClusterIdentitySpec) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterIdentitySpec.
+func (in *GCPClusterIdentitySpec) DeepCopy() *GCPClusterIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterImpersonationIdentity) DeepCopyInto(out *GCPClusterImpersonationIdentity) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterImpersonationIdentity.
+func (in *GCPClusterImpersonationIdentity) DeepCopy() *GCPClusterImpersonationIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterImpersonationIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterImpersonationIdentity) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterImpersonationIdentityList) DeepCopyInto(out *GCPClusterImpersonationIdentityList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPClusterImpersonationIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterImpersonationIdentityList.
+func (in *GCPClusterImpersonationIdentityList) DeepCopy() *GCPClusterImpersonationIdentityList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterImpersonationIdentityList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterImpersonationIdentityList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterImpersonationIdentitySpec) DeepCopyInto(out *GCPClusterImpersonationIdentitySpec) {
+	*out = *in
+	in.GCPClusterIdentitySpec.DeepCopyInto(&out.GCPClusterIdentitySpec)
+	in.ServiceAccountImpersonation.DeepCopyInto(&out.ServiceAccountImpersonation)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterImpersonationIdentitySpec.
+func (in *GCPClusterImpersonationIdentitySpec) DeepCopy() *GCPClusterImpersonationIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterImpersonationIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPClusterList) DeepCopyInto(out *GCPClusterList) {
 	*out = *in
@@ -164,11 +359,23 @@ func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
 	*out = *in
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 	in.Network.DeepCopyInto(&out.Network)
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(LoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailureDomains != nil {
 		in, out := &in.FailureDomains, &out.FailureDomains
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailureDomainSpecs != nil {
+		in, out := &in.FailureDomainSpecs, &out.FailureDomainSpecs
+		*out = make([]FailureDomainSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AdditionalLabels != nil {
 		in, out := &in.AdditionalLabels, &out.AdditionalLabels
 		*out = make(Labels, len(*in))
@@ -186,6 +393,16 @@ func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
 		*out = new(ObjectReference)
 		**out = **in
 	}
+	if in.ServiceAccountImpersonation != nil {
+		in, out := &in.ServiceAccountImpersonation, &out.ServiceAccountImpersonation
+		*out = new(ServiceAccountImpersonation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(GCPIdentityReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterSpec.
@@ -198,6 +415,81 @@ func (in *GCPClusterSpec) DeepCopy() *GCPClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterStaticIdentity) DeepCopyInto(out *GCPClusterStaticIdentity) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStaticIdentity.
+func (in *GCPClusterStaticIdentity) DeepCopy() *GCPClusterStaticIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterStaticIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterStaticIdentity) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterStaticIdentityList) DeepCopyInto(out *GCPClusterStaticIdentityList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPClusterStaticIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStaticIdentityList.
+func (in *GCPClusterStaticIdentityList) DeepCopy() *GCPClusterStaticIdentityList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterStaticIdentityList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterStaticIdentityList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterStaticIdentitySpec) DeepCopyInto(out *GCPClusterStaticIdentitySpec) {
+	*out = *in
+	in.GCPClusterIdentitySpec.DeepCopyInto(&out.GCPClusterIdentitySpec)
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStaticIdentitySpec.
+func (in *GCPClusterStaticIdentitySpec) DeepCopy() *GCPClusterStaticIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterStaticIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPClusterStatus) DeepCopyInto(out *GCPClusterStatus) {
 	*out = *in
@@ -209,6 +501,28 @@ func (in *GCPClusterStatus) DeepCopyInto(out *GCPClusterStatus) {
 		}
 	}
 	in.Network.DeepCopyInto(&out.Network)
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]Operation, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceManagerTagValues != nil {
+		in, out := &in.ResourceManagerTagValues, &out.ResourceManagerTagValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStatus.
@@ -312,6 +626,21 @@ func (in *GCPClusterTemplateSpec) DeepCopy() *GCPClusterTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPIdentityReference) DeepCopyInto(out *GCPIdentityReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPIdentityReference.
+func (in *GCPIdentityReference) DeepCopy() *GCPIdentityReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPIdentityReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPMachine) DeepCopyInto(out *GCPMachine) {
 	*out = *in
@@ -384,6 +713,11 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ExistingInstance != nil {
+		in, out := &in.ExistingInstance, &out.ExistingInstance
+		*out = new(string)
+		**out = **in
+	}
 	if in.ImageFamily != nil {
 		in, out := &in.ImageFamily, &out.ImageFamily
 		*out = new(string)
@@ -460,6 +794,11 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 		*out = new(ConfidentialComputePolicy)
 		**out = **in
 	}
+	if in.BootstrapCheck != nil {
+		in, out := &in.BootstrapCheck, &out.BootstrapCheck
+		*out = new(BootstrapCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineSpec.
@@ -485,6 +824,36 @@ func (in *GCPMachineStatus) DeepCopyInto(out *GCPMachineStatus) {
 		*out = new(InstanceStatus)
 		**out = **in
 	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdoptedInstance != nil {
+		in, out := &in.AdoptedInstance, &out.AdoptedInstance
+		*out = new(string)
+		**out = **in
+	}
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]Operation, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootstrapSerialOffset != nil {
+		in, out := &in.BootstrapSerialOffset, &out.BootstrapSerialOffset
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceManagerTagValues != nil {
+		in, out := &in.ResourceManagerTagValues, &out.ResourceManagerTagValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -495,6 +864,13 @@ func (in *GCPMachineStatus) DeepCopyInto(out *GCPMachineStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineStatus.
@@ -634,6 +1010,41 @@ func (in Labels) DeepCopy() Labels {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+	*out = *in
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackendService != nil {
+		in, out := &in.BackendService, &out.BackendService
+		*out = new(string)
+		**out = **in
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+	if in.ForwardingRule != nil {
+		in, out := &in.ForwardingRule, &out.ForwardingRule
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
+func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataItem) DeepCopyInto(out *MetadataItem) {
 	*out = *in
@@ -706,6 +1117,11 @@ func (in *Network) DeepCopyInto(out *Network) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FirewallPolicy != nil {
+		in, out := &in.FirewallPolicy, &out.FirewallPolicy
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
@@ -743,6 +1159,21 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = new(RoutesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FirewallPolicy != nil {
+		in, out := &in.FirewallPolicy, &out.FirewallPolicy
+		*out = new(FirewallPolicySpec)
+		**out = **in
+	}
+	if in.Router != nil {
+		in, out := &in.Router, &out.Router
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -770,6 +1201,21 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Operation) DeepCopyInto(out *Operation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operation.
+func (in *Operation) DeepCopy() *Operation {
+	if in == nil {
+		return nil
+	}
+	out := new(Operation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceManagerTag) DeepCopyInto(out *ResourceManagerTag) {
 	*out = *in
@@ -825,6 +1271,28 @@ func (in ResourceManagerTagsMap) DeepCopy() ResourceManagerTagsMap {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutesSpec) DeepCopyInto(out *RoutesSpec) {
+	*out = *in
+	if in.StaticRoutes != nil {
+		in, out := &in.StaticRoutes, &out.StaticRoutes
+		*out = make([]StaticRouteSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutesSpec.
+func (in *RoutesSpec) DeepCopy() *RoutesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	*out = *in
@@ -845,6 +1313,66 @@ func (in *ServiceAccount) DeepCopy() *ServiceAccount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountImpersonation) DeepCopyInto(out *ServiceAccountImpersonation) {
+	*out = *in
+	if in.Delegates != nil {
+		in, out := &in.Delegates, &out.Delegates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountImpersonation.
+func (in *ServiceAccountImpersonation) DeepCopy() *ServiceAccountImpersonation {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountImpersonation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticRouteSpec) DeepCopyInto(out *StaticRouteSpec) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextHopInstance != nil {
+		in, out := &in.NextHopInstance, &out.NextHopInstance
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextHopIP != nil {
+		in, out := &in.NextHopIP, &out.NextHopIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextHopGateway != nil {
+		in, out := &in.NextHopGateway, &out.NextHopGateway
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticRouteSpec.
+func (in *StaticRouteSpec) DeepCopy() *StaticRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
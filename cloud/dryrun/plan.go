@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Action is the kind of change of a resource.
+type Action string
+
+const (
+	// ActionCreate creates a resource.
+	ActionCreate = Action("create")
+
+	// ActionUpdate updates a resource, with a PUT or PATCH call or a custom method such as setLabels.
+	ActionUpdate = Action("update")
+
+	// ActionDelete deletes a resource.
+	ActionDelete = Action("delete")
+)
+
+// FieldDiff is the change of a field of a resource. The values are JSON encoded, the current value is
+// empty for the fields of a created resource.
+type FieldDiff struct {
+	Field   string `json:"field"`
+	Current string `json:"current,omitempty"`
+	Desired string `json:"desired,omitempty"`
+}
+
+// Change is a change of a resource, identified by its path, e.g. projects/my-project/global/networks/default.
+type Change struct {
+	Action   Action      `json:"action"`
+	Resource string      `json:"resource"`
+	Method   string      `json:"method,omitempty"`
+	Fields   []FieldDiff `json:"fields,omitempty"`
+}
+
+// Plan records the changes that a reconcile would make, in the order they would be made.
+type Plan struct {
+	mu      sync.Mutex
+	changes []Change
+}
+
+// NewPlan returns an empty plan.
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// Add records a change.
+func (p *Plan) Add(change Change) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changes = append(p.changes, change)
+}
+
+// Changes returns the recorded changes.
+func (p *Plan) Changes() []Change {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Change{}, p.changes...)
+}
+
+// YAML returns the changes of the plan formatted for users, along with the error which stopped the
+// reconcile before all the changes could be planned, if any.
+func (p *Plan) YAML(reconcileErr error) ([]byte, error) {
+	doc := struct {
+		Changes []Change `json:"changes"`
+		Error   string   `json:"error,omitempty"`
+	}{
+		Changes: p.Changes(),
+	}
+	if reconcileErr != nil {
+		doc.Error = reconcileErr.Error()
+	}
+
+	return yaml.Marshal(doc)
+}
+
+// diff returns the changes of the fields of the desired object from the current one, nil for a created
+// object. Only the fields set in the desired object are compared, nested objects are compared field by field.
+func diff(current, desired map[string]any) []FieldDiff {
+	currentFields := map[string]string{}
+	flatten("", current, currentFields)
+	desiredFields := map[string]string{}
+	flatten("", desired, desiredFields)
+
+	diffs := []FieldDiff{}
+	for field, value := range desiredFields {
+		if currentFields[field] != value {
+			diffs = append(diffs, FieldDiff{Field: field, Current: currentFields[field], Desired: value})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// flatten sets the JSON encoded values of the leaf fields of the object by their dotted path, e.g. labels.env
+// or networkInterfaces[0].network.
+func flatten(prefix string, value any, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, child, fields)
+		}
+	case []any:
+		for i, child := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), child, fields)
+		}
+	case nil:
+	default:
+		encoded, _ := json.Marshal(v)
+		fields[prefix] = string(encoded)
+	}
+}
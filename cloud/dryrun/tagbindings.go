@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+// tagBindings lists the tag bindings with the wrapped ones, and records their creation and deletion in a plan.
+// The resource manager clients use gRPC, their calls cannot be recorded by the Transport.
+type tagBindings struct {
+	cloud.TagBindings
+	plan *Plan
+}
+
+// NewTagBindings returns tag bindings recording their creation and deletion in the plan.
+func NewTagBindings(bindings cloud.TagBindings, plan *Plan) cloud.TagBindings {
+	return &tagBindings{TagBindings: bindings, plan: plan}
+}
+
+func (b *tagBindings) Create(_ context.Context, parent, tagValue string) error {
+	encodedParent, _ := json.Marshal(parent)
+	encodedTagValue, _ := json.Marshal(tagValue)
+	b.plan.Add(Change{
+		Action:   ActionCreate,
+		Resource: "tagBindings",
+		Fields: []FieldDiff{
+			{Field: "parent", Desired: string(encodedParent)},
+			{Field: "tagValue", Desired: string(encodedTagValue)},
+		},
+	})
+	return nil
+}
+
+func (b *tagBindings) Delete(_ context.Context, name string) error {
+	b.plan.Add(Change{Action: ActionDelete, Resource: name})
+	return nil
+}
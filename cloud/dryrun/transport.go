@@ -0,0 +1,273 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// operationPrefix prefixes the names of the operations returned for the recorded calls.
+const operationPrefix = "dry-run-"
+
+// customMethodVerbs are the verbs of the custom methods of the compute API, e.g. setLabels or addInstances,
+// which are called on a resource rather than a collection.
+var customMethodVerbs = []string{"add", "attach", "detach", "remove", "resize", "set", "start", "stop"}
+
+// Transport serves the read calls of the GCP REST APIs with the wrapped transport and records the mutating
+// calls in a plan instead of sending them. The recorded calls return an operation which is already done.
+// The resources created, updated or deleted by the recorded calls are kept in memory, so the later calls of
+// the reconcile see them as if the calls had been applied.
+type Transport struct {
+	base http.RoundTripper
+	plan *Plan
+
+	mu      sync.Mutex
+	objects map[string]map[string]any
+	deleted map[string]bool
+	ops     int
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// NewTransport returns a Transport recording the mutating calls in the plan.
+func NewTransport(base http.RoundTripper, plan *Plan) *Transport {
+	return &Transport{
+		base:    base,
+		plan:    plan,
+		objects: map[string]map[string]any{},
+		deleted: map[string]bool{},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	prefix, resource := splitPath(req.URL.Path)
+	if scope, name, ok := strings.Cut(resource, "/operations/"+operationPrefix); ok {
+		// Get or wait for an operation returned for a recorded call.
+		name, _, _ = strings.Cut(name, "/")
+		return t.operation(req, prefix, scope, operationPrefix+name, "")
+	}
+
+	if req.Method == http.MethodGet {
+		return t.get(req, resource)
+	}
+
+	var body map[string]any
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &body); err != nil {
+				return nil, fmt.Errorf("decoding the body of %s %s: %w", req.Method, req.URL.Path, err)
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	target := resource
+	switch name, hasName := body["name"].(string); {
+	case req.Method == http.MethodDelete:
+		t.plan.Add(Change{Action: ActionDelete, Resource: resource})
+		t.deleted[resource] = true
+		delete(t.objects, resource)
+	case req.Method == http.MethodPost && isCustomMethod(resource):
+		target = path.Dir(resource)
+		current, err := t.current(req, prefix, target)
+		if err != nil {
+			return nil, err
+		}
+		t.plan.Add(Change{Action: ActionUpdate, Resource: target, Method: path.Base(resource), Fields: diff(current, body)})
+	case req.Method == http.MethodPost && hasName:
+		target = resource + "/" + name
+		t.plan.Add(Change{Action: ActionCreate, Resource: target, Fields: diff(nil, body)})
+		body["selfLink"] = link(req, prefix, target)
+		t.objects[target] = body
+		delete(t.deleted, target)
+	default:
+		current, err := t.current(req, prefix, resource)
+		if err != nil {
+			return nil, err
+		}
+		t.plan.Add(Change{Action: ActionUpdate, Resource: resource, Fields: diff(current, body)})
+		if current == nil {
+			current = map[string]any{}
+		}
+		for k, v := range body {
+			current[k] = v
+		}
+		t.objects[resource] = current
+	}
+
+	t.ops++
+	return t.operation(req, prefix, operationScope(target), fmt.Sprintf("%s%d", operationPrefix, t.ops), target)
+}
+
+// get returns the resource from memory if it was created, updated or deleted by a recorded call, otherwise
+// from the API.
+func (t *Transport) get(req *http.Request, resource string) (*http.Response, error) {
+	t.mu.Lock()
+	obj, ok := t.objects[resource]
+	deleted := t.deleted[resource]
+	t.mu.Unlock()
+
+	switch {
+	case ok:
+		return response(req, http.StatusOK, obj)
+	case deleted:
+		return notFound(req, resource)
+	default:
+		return t.base.RoundTrip(req)
+	}
+}
+
+// current returns the current state of the resource, nil if it does not exist.
+func (t *Transport) current(req *http.Request, prefix, resource string) (map[string]any, error) {
+	if obj, ok := t.objects[resource]; ok {
+		return obj, nil
+	}
+	if t.deleted[resource] {
+		return nil, nil
+	}
+
+	getReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, link(req, prefix, resource), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(getReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("getting %s: %s", resource, resp.Status)
+	}
+
+	var obj map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// operation returns a done operation of the scope, e.g. projects/my-project/zones/us-central1-a.
+func (t *Transport) operation(req *http.Request, prefix, scope, name, target string) (*http.Response, error) {
+	op := map[string]any{
+		"kind":     "compute#operation",
+		"name":     name,
+		"status":   "DONE",
+		"progress": 100,
+		"selfLink": link(req, prefix, scope+"/operations/"+name),
+		"done":     true,
+	}
+	if target != "" {
+		op["targetLink"] = link(req, prefix, target)
+	}
+	if segments := strings.Split(scope, "/"); len(segments) == 4 {
+		op[strings.TrimSuffix(segments[2], "s")] = link(req, prefix, scope)
+	}
+
+	return response(req, http.StatusOK, op)
+}
+
+// splitPath splits the path of a call into the prefix of the API, e.g. /compute/v1/, and the path of the
+// resource, e.g. projects/my-project/global/networks/default.
+func splitPath(p string) (string, string) {
+	i := strings.Index(p, "projects/")
+	if i < 0 {
+		return "", p
+	}
+
+	return p[:i], p[i:]
+}
+
+// operationScope returns the scope of the operations on the resource: its zone, its region or global.
+func operationScope(resource string) string {
+	segments := strings.Split(resource, "/")
+	if len(segments) >= 4 && (segments[2] == "zones" || segments[2] == "regions") {
+		return strings.Join(segments[:4], "/")
+	}
+	if len(segments) >= 2 {
+		return strings.Join(segments[:2], "/") + "/global"
+	}
+
+	return resource
+}
+
+// isCustomMethod reports whether the path is a custom method of a resource, i.e. a verb or a verb followed by
+// an upper case letter, which tells addInstances from the addresses collection.
+func isCustomMethod(resource string) bool {
+	method := path.Base(resource)
+	for _, verb := range customMethodVerbs {
+		rest, ok := strings.CutPrefix(method, verb)
+		if ok && (rest == "" || unicode.IsUpper(rune(rest[0]))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func link(req *http.Request, prefix, resource string) string {
+	return fmt.Sprintf("%s://%s%s%s", req.URL.Scheme, req.URL.Host, prefix, resource)
+}
+
+func response(req *http.Request, status int, obj any) (*http.Response, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+func notFound(req *http.Request, resource string) (*http.Response, error) {
+	return response(req, http.StatusNotFound, map[string]any{
+		"error": map[string]any{
+			"code":    http.StatusNotFound,
+			"message": fmt.Sprintf("The resource '%s' was not found", resource),
+		},
+	})
+}
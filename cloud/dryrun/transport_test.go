@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+)
+
+// newService returns a compute service recording its mutating calls in the plan, on top of a fake API serving
+// an existing firewall and an existing instance.
+func newService(t *testing.T, plan *Plan) *compute.Service {
+	t.Helper()
+	existing := map[string]any{
+		"/compute/v1/projects/my-proj/global/firewalls/my-firewall":        map[string]any{"name": "my-firewall", "priority": 1000, "sourceRanges": []string{"10.0.0.0/8"}},
+		"/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-vm": map[string]any{"name": "my-vm", "labels": map[string]string{"env": "dev"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("%s %s reached the API", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		obj, ok := existing[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			obj = map[string]any{"error": map[string]any{"code": http.StatusNotFound, "message": "not found"}}
+		}
+		_ = json.NewEncoder(w).Encode(obj)
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := compute.NewService(context.TODO(),
+		option.WithEndpoint(server.URL+"/compute/v1/"),
+		option.WithHTTPClient(&http.Client{Transport: NewTransport(http.DefaultTransport, plan)}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return service
+}
+
+func TestTransport(t *testing.T) {
+	ctx := context.TODO()
+	plan := NewPlan()
+	service := newService(t, plan)
+	gce := cloud.NewGCE(&cloud.Service{GA: service, ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"}, RateLimiter: &cloud.NopRateLimiter{}})
+
+	// The cloud wrapper waits for the operations of its calls.
+	if err := gce.Networks().Insert(ctx, meta.GlobalKey("my-network"), &compute.Network{Name: "my-network", AutoCreateSubnetworks: true}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	network, err := gce.Networks().Get(ctx, meta.GlobalKey("my-network"))
+	if err != nil || network.SelfLink == "" {
+		t.Fatalf("Get() = %+v, %v, want the planned network", network, err)
+	}
+
+	if _, err := service.GlobalAddresses.Insert("my-proj", &compute.Address{Name: "my-address"}).Do(); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+
+	op, err := service.Firewalls.Patch("my-proj", "my-firewall", &compute.Firewall{Priority: 900}).Do()
+	if err != nil || op.Status != "DONE" {
+		t.Fatalf("Patch() = %+v, %v, want a done operation", op, err)
+	}
+
+	if _, err := service.Instances.SetLabels("my-proj", "us-central1-a", "my-vm", &compute.InstancesSetLabelsRequest{
+		Labels: map[string]string{"env": "prod"},
+	}).Do(); err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+
+	if _, err := service.Instances.Delete("my-proj", "us-central1-a", "my-vm").Do(); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := service.Instances.Get("my-proj", "us-central1-a", "my-vm").Do(); !gcperrors.IsNotFound(err) {
+		t.Fatalf("Get() error = %v, want the planned deletion", err)
+	}
+
+	want := []Change{
+		{Action: ActionCreate, Resource: "projects/my-proj/global/networks/my-network", Fields: []FieldDiff{
+			{Field: "autoCreateSubnetworks", Desired: "true"},
+			{Field: "name", Desired: `"my-network"`},
+		}},
+		{Action: ActionCreate, Resource: "projects/my-proj/global/addresses/my-address", Fields: []FieldDiff{
+			{Field: "name", Desired: `"my-address"`},
+		}},
+		{Action: ActionUpdate, Resource: "projects/my-proj/global/firewalls/my-firewall", Fields: []FieldDiff{
+			{Field: "priority", Current: "1000", Desired: "900"},
+		}},
+		{Action: ActionUpdate, Resource: "projects/my-proj/zones/us-central1-a/instances/my-vm", Method: "setLabels", Fields: []FieldDiff{
+			{Field: "labels.env", Current: `"dev"`, Desired: `"prod"`},
+		}},
+		{Action: ActionDelete, Resource: "projects/my-proj/zones/us-central1-a/instances/my-vm"},
+	}
+	if diff := cmp.Diff(want, plan.Changes()); diff != "" {
+		t.Errorf("Changes() mismatch (-want +got):\n%s", diff)
+	}
+}
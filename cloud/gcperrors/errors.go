@@ -18,20 +18,235 @@ limitations under the License.
 package gcperrors
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/googleapis/gax-go/v2/apierror"
+	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// IsNotFound reports whether err is a Google API error
-// with http.StatusNotFround.
-func IsNotFound(err error) bool {
+// Category is a class of errors returned by the GCP APIs which are handled alike.
+// Categories are used as the reasons of the conditions reporting the errors.
+type Category string
+
+const (
+	// Unknown is the category of the errors which are not classified.
+	Unknown Category = "Unknown"
+	// NotFound is the category of the errors returned when a resource does not exist.
+	NotFound Category = "NotFound"
+	// AlreadyExists is the category of the errors returned when creating a resource which exists.
+	AlreadyExists Category = "AlreadyExists"
+	// PermissionDenied is the category of the errors returned when the credentials are not allowed to perform a call.
+	PermissionDenied Category = "PermissionDenied"
+	// QuotaExceeded is the category of the errors returned when a quota or a rate limit is exceeded.
+	QuotaExceeded Category = "QuotaExceeded"
+	// ZoneResourceExhausted is the category of the errors returned when a zone is out of the requested resources.
+	ZoneResourceExhausted Category = "ZoneResourceExhausted"
+	// Conflict is the category of the errors returned when a resource is not in the state required by a call,
+	// e.g. when it is used by another resource or when another operation is running on it.
+	Conflict Category = "Conflict"
+	// InvalidArgument is the category of the errors returned when a call is invalid.
+	InvalidArgument Category = "InvalidArgument"
+)
+
+// IsTerminal reports whether the errors of the category cannot be fixed by retrying the call as is.
+func (c Category) IsTerminal() bool {
+	return c == InvalidArgument
+}
+
+// RetryAfter returns how long to wait before retrying a call which failed with an error of the category.
+// It returns 0 for the errors which are retried with the default backoff.
+func (c Category) RetryAfter() time.Duration {
+	switch c {
+	case AlreadyExists:
+		return 5 * time.Second
+	case Conflict:
+		return 15 * time.Second
+	case NotFound:
+		return 30 * time.Second
+	case QuotaExceeded:
+		return time.Minute
+	case ZoneResourceExhausted:
+		return 2 * time.Minute
+	case PermissionDenied:
+		return 5 * time.Minute
+	default:
+		return 0
+	}
+}
+
+// OperationError is the error of a GCE operation which failed.
+type OperationError struct {
+	Name          string
+	OperationType string
+	TargetLink    string
+	Errors        []*compute.OperationErrorErrors
+}
+
+// Error implements error.
+func (e *OperationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, oe := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", oe.Code, oe.Message))
+	}
+
+	return fmt.Sprintf("operation %s %s on %s failed: %s", e.Name, e.OperationType, e.TargetLink, strings.Join(messages, ", "))
+}
+
+// Classify returns the category of err. It handles the errors of the REST clients (*googleapi.Error),
+// of the gRPC clients (*apierror.APIError and gRPC status errors) and of the GCE operations (*OperationError),
+// wrapped or not.
+func Classify(err error) Category {
 	if err == nil {
-		return false
+		return Unknown
+	}
+
+	var operationErr *OperationError
+	if errors.As(err, &operationErr) {
+		for _, oe := range operationErr.Errors {
+			if c := classifyReason(oe.Code); c != Unknown {
+				return c
+			}
+		}
+
+		return Unknown
+	}
+
+	var googleErr *googleapi.Error
+	if errors.As(err, &googleErr) {
+		return classifyGoogleAPIError(googleErr)
+	}
+
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		if c := classifyReason(apiErr.Reason()); c != Unknown {
+			return c
+		}
+
+		if s := apiErr.GRPCStatus(); s != nil {
+			return classifyStatus(s)
+		}
+
+		return classifyHTTPCode(apiErr.HTTPCode())
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return classifyStatus(grpcErr.GRPCStatus())
+	}
+
+	return Unknown
+}
+
+func classifyGoogleAPIError(err *googleapi.Error) Category {
+	for _, e := range err.Errors {
+		if c := classifyReason(e.Reason); c != Unknown {
+			return c
+		}
+	}
+
+	if strings.Contains(err.Message, "ZONE_RESOURCE_POOL_EXHAUSTED") {
+		return ZoneResourceExhausted
 	}
-	ae, ok := err.(*googleapi.Error)
 
-	return ok && ae.Code == http.StatusNotFound
+	return classifyHTTPCode(err.Code)
+}
+
+// classifyReason classifies the reasons of the Google API errors, e.g. "quotaExceeded",
+// and the codes of the GCE operation errors, e.g. "QUOTA_EXCEEDED".
+func classifyReason(reason string) Category {
+	switch strings.ToUpper(strings.ReplaceAll(reason, "_", "")) {
+	case "NOTFOUND", "RESOURCENOTFOUND":
+		return NotFound
+	case "ALREADYEXISTS", "RESOURCEALREADYEXISTS", "DUPLICATE":
+		return AlreadyExists
+	case "FORBIDDEN", "INSUFFICIENTPERMISSIONS", "IAMPERMISSIONDENIED", "PERMISSIONDENIED", "ACCESSNOTCONFIGURED", "SERVICEDISABLED":
+		return PermissionDenied
+	case "RATELIMITEXCEEDED", "USERRATELIMITEXCEEDED", "QUOTAEXCEEDED", "RATELIMITEXCEEDEDWITHDETAILS":
+		return QuotaExceeded
+	case "ZONERESOURCEPOOLEXHAUSTED", "ZONERESOURCEPOOLEXHAUSTEDWITHDETAILS", "RESOURCEPOOLEXHAUSTED", "STOCKOUT":
+		return ZoneResourceExhausted
+	case "RESOURCEINUSEBYANOTHERRESOURCE", "RESOURCENOTREADY", "CONDITIONNOTMET", "CONFLICT", "OPERATIONINPROGRESS":
+		return Conflict
+	case "INVALID", "INVALIDFIELDVALUE", "INVALIDPARAMETER", "INVALIDARGUMENT", "REQUIRED", "UNSUPPORTEDOPERATION":
+		return InvalidArgument
+	default:
+		return Unknown
+	}
+}
+
+func classifyHTTPCode(code int) Category {
+	switch code {
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return AlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return PermissionDenied
+	case http.StatusTooManyRequests:
+		return QuotaExceeded
+	case http.StatusPreconditionFailed:
+		return Conflict
+	default:
+		return Unknown
+	}
+}
+
+func classifyStatus(s *status.Status) Category {
+	switch s.Code() {
+	case codes.NotFound:
+		return NotFound
+	case codes.AlreadyExists:
+		return AlreadyExists
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return PermissionDenied
+	case codes.ResourceExhausted:
+		if strings.Contains(s.Message(), "ZONE_RESOURCE_POOL_EXHAUSTED") {
+			return ZoneResourceExhausted
+		}
+
+		return QuotaExceeded
+	case codes.FailedPrecondition, codes.Aborted:
+		return Conflict
+	case codes.InvalidArgument, codes.OutOfRange:
+		return InvalidArgument
+	default:
+		return Unknown
+	}
+}
+
+// invalidFieldPattern matches the field of the Google API errors returned for an invalid value,
+// e.g. "Invalid value for field 'resource.machineType': ...".
+var invalidFieldPattern = regexp.MustCompile(`Invalid value for field '([^']+)'`)
+
+// InvalidField returns the field, e.g. resource.machineType, reported by a Google API error returned
+// because a call has an invalid field value. It returns false for the other errors, including the
+// errors of GCE operations.
+func InvalidField(err error) (string, bool) {
+	var googleErr *googleapi.Error
+	if !errors.As(err, &googleErr) || classifyGoogleAPIError(googleErr) != InvalidArgument {
+		return "", false
+	}
+
+	match := invalidFieldPattern.FindStringSubmatch(googleErr.Message)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// IsNotFound reports whether err is a Google API error
+// returned because a resource does not exist.
+func IsNotFound(err error) bool {
+	return Classify(err) == NotFound
 }
 
 // IgnoreNotFound ignore Google API not found error and return nil.
@@ -43,3 +258,39 @@ func IgnoreNotFound(err error) error {
 
 	return err
 }
+
+// IsAlreadyExists reports whether err is a Google API error
+// returned because a resource already exists.
+func IsAlreadyExists(err error) bool {
+	return Classify(err) == AlreadyExists
+}
+
+// IsPermissionDenied reports whether err is a Google API error
+// returned because the credentials are not allowed to perform a call.
+func IsPermissionDenied(err error) bool {
+	return Classify(err) == PermissionDenied
+}
+
+// IsRateLimited reports whether err is a Google API error
+// returned because a rate limit or a quota was exceeded.
+func IsRateLimited(err error) bool {
+	return Classify(err) == QuotaExceeded
+}
+
+// IsZoneResourceExhausted reports whether err is a Google API error
+// returned because a zone is out of the requested resources.
+func IsZoneResourceExhausted(err error) bool {
+	return Classify(err) == ZoneResourceExhausted
+}
+
+// IsConflict reports whether err is a Google API error returned because
+// a resource is not in the state required by a call.
+func IsConflict(err error) bool {
+	return Classify(err) == Conflict
+}
+
+// IsInvalidArgument reports whether err is a Google API error
+// returned because a call is invalid.
+func IsInvalidArgument(err error) bool {
+	return Classify(err) == InvalidArgument
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcperrors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassify(t *testing.T) {
+	grpcAPIError, ok := apierror.FromError(status.Error(codes.FailedPrecondition, "cluster is running an operation"))
+	if !ok {
+		t.Fatal("apierror.FromError() failed to wrap a gRPC status error")
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want Category
+	}{
+		{
+			name: "nil",
+			want: Unknown,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("connection reset"),
+			want: Unknown,
+		},
+		{
+			name: "google API not found",
+			err:  &googleapi.Error{Code: http.StatusNotFound},
+			want: NotFound,
+		},
+		{
+			name: "wrapped google API not found",
+			err:  errors.Wrap(&googleapi.Error{Code: http.StatusNotFound}, "getting network"),
+			want: NotFound,
+		},
+		{
+			name: "google API already exists",
+			err:  &googleapi.Error{Code: http.StatusConflict, Errors: []googleapi.ErrorItem{{Reason: "alreadyExists"}}},
+			want: AlreadyExists,
+		},
+		{
+			name: "google API resource in use",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "resourceInUseByAnotherResource"}}},
+			want: Conflict,
+		},
+		{
+			name: "google API rate limited",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}},
+			want: QuotaExceeded,
+		},
+		{
+			name: "google API permission denied",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}},
+			want: PermissionDenied,
+		},
+		{
+			name: "google API invalid argument",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "invalid"}}},
+			want: InvalidArgument,
+		},
+		{
+			name: "google API bad request without an invalid argument reason",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "badRequest"}}},
+			want: Unknown,
+		},
+		{
+			name: "gRPC not found",
+			err:  status.Error(codes.NotFound, "cluster not found"),
+			want: NotFound,
+		},
+		{
+			name: "gRPC quota exceeded",
+			err:  status.Error(codes.ResourceExhausted, "insufficient regional quota"),
+			want: QuotaExceeded,
+		},
+		{
+			name: "gRPC stockout",
+			err:  status.Error(codes.ResourceExhausted, "ZONE_RESOURCE_POOL_EXHAUSTED: no more n2 machines"),
+			want: ZoneResourceExhausted,
+		},
+		{
+			name: "API error failed precondition",
+			err:  grpcAPIError,
+			want: Conflict,
+		},
+		{
+			name: "operation stockout",
+			err: &OperationError{
+				Name:   "operation-1",
+				Errors: []*compute.OperationErrorErrors{{Code: "ZONE_RESOURCE_POOL_EXHAUSTED_WITH_DETAILS", Message: "no more n2 machines"}},
+			},
+			want: ZoneResourceExhausted,
+		},
+		{
+			name: "operation invalid field",
+			err: &OperationError{
+				Name:   "operation-1",
+				Errors: []*compute.OperationErrorErrors{{Code: "INVALID_FIELD_VALUE", Message: "invalid machine type"}},
+			},
+			want: InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Errorf("Classify() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidField(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantField string
+		wantOK    bool
+	}{
+		{
+			name: "invalid machine type",
+			err: errors.Wrap(&googleapi.Error{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid value for field 'resource.machineType': 'zones/us-central1-a/machineTypes/n9-standard-2'. Machine type with name 'n9-standard-2' does not exist in zone 'us-central1-a'.",
+				Errors:  []googleapi.ErrorItem{{Reason: "invalid"}},
+			}, "creating instance"),
+			wantField: "resource.machineType",
+			wantOK:    true,
+		},
+		{
+			name: "invalid argument without field",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Message: "Bad request", Errors: []googleapi.ErrorItem{{Reason: "invalid"}}},
+		},
+		{
+			name: "resource in use",
+			err: &googleapi.Error{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid value for field 'resource.disks': the disk is used by another instance",
+				Errors:  []googleapi.ErrorItem{{Reason: "resourceInUseByAnotherResource"}},
+			},
+		},
+		{
+			name: "operation invalid field",
+			err: &OperationError{
+				Name:   "operation-1",
+				Errors: []*compute.OperationErrorErrors{{Code: "INVALID_FIELD_VALUE", Message: "Invalid value for field 'resource.machineType'"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, ok := InvalidField(tt.err)
+			if field != tt.wantField || ok != tt.wantOK {
+				t.Errorf("InvalidField() = %q, %v, want %q, %v", field, ok, tt.wantField, tt.wantOK)
+			}
+		})
+	}
+}
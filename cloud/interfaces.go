@@ -21,7 +21,10 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	rmpb "cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -31,6 +34,9 @@ import (
 // Cloud alias for cloud.Cloud interface.
 type Cloud = cloud.Cloud
 
+// RateLimiter alias for cloud.RateLimiter interface.
+type RateLimiter = cloud.RateLimiter
+
 // Reconciler is a generic interface used by components offering a type of service.
 type Reconciler interface {
 	Reconcile(ctx context.Context) error
@@ -43,6 +49,12 @@ type ReconcilerWithResult interface {
 	Delete(ctx context.Context) (ctrl.Result, error)
 }
 
+// Orphaner is implemented by the services which can leave their resources behind instead of deleting them.
+// Orphan removes the CAPG ownership of the resources and returns the ones left behind.
+type Orphaner interface {
+	Orphan(ctx context.Context) ([]string, error)
+}
+
 // Client is an interface which can get cloud client.
 type Client interface {
 	Cloud() Cloud
@@ -61,6 +73,41 @@ type ClusterGetter interface {
 	FailureDomains() clusterv1.FailureDomains
 	ControlPlaneEndpoint() clusterv1.APIEndpoint
 	ResourceManagerTags() infrav1.ResourceManagerTags
+	LabelSetter() LabelSetter
+	InstanceConsole() InstanceConsole
+	TagValues() TagValues
+	TagBindings(ctx context.Context, location string) (TagBindings, error)
+	ResourceName(parts ...string) string
+	IsOrphanOnDelete() bool
+}
+
+// LabelSetter sets the labels of the compute resources, since the Cloud clients of most labelled resources
+// lack the setLabels calls.
+type LabelSetter interface {
+	SetGlobalAddressLabels(ctx context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error
+	SetGlobalForwardingRuleLabels(ctx context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error
+	SetInstanceLabels(ctx context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error
+	SetDiskLabels(ctx context.Context, key *meta.Key, req *compute.ZoneSetLabelsRequest) error
+}
+
+// InstanceConsole reads what the instances report from the inside, which the Cloud clients lack: their guest
+// attributes and the output of their serial console.
+type InstanceConsole interface {
+	GetGuestAttributes(ctx context.Context, key *meta.Key, queryPath string) (*compute.GuestAttributes, error)
+	GetSerialPortOutput(ctx context.Context, key *meta.Key, start int64) (*compute.SerialPortOutput, error)
+}
+
+// TagValues looks up the tag values of the resource-manager tags with the credentials of the cluster.
+type TagValues interface {
+	TagValueName(ctx context.Context, tag infrav1.ResourceManagerTag) (string, error)
+	ResourceManagerTagsMap(ctx context.Context, tags infrav1.ResourceManagerTags) infrav1.ResourceManagerTagsMap
+}
+
+// TagBindings manages the tag bindings of resources.
+type TagBindings interface {
+	List(ctx context.Context, parent string) ([]*rmpb.TagBinding, error)
+	Create(ctx context.Context, parent, tagValue string) error
+	Delete(ctx context.Context, name string) error
 }
 
 // ClusterSetter is an interface which can set cluster information.
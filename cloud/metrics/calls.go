@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capg_gcp_api_requests_total",
+		Help: "Number of GCP API calls by service, operation and result code.",
+	}, []string{"service", "operation", "code"})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "capg_gcp_api_request_duration_seconds",
+		Help:    "Latency of the GCP API calls by service, operation and result code.",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"service", "operation", "code"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRequestDuration)
+}
+
+// ObserveCall records a GCP API call and its latency.
+func ObserveCall(service, operation, code string, duration time.Duration) {
+	apiRequests.WithLabelValues(service, operation, code).Inc()
+	apiRequestDuration.WithLabelValues(service, operation, code).Observe(duration.Seconds())
+}
+
+// CallObserver implements cloud.CallObserver to record the GCP API calls of the cloud wrapper.
+// The latency of a call includes the time spent waiting for the rate limiter.
+type CallObserver struct {
+	started sync.Map
+}
+
+var _ cloud.CallObserver = &CallObserver{}
+
+// Start records the start of the call.
+func (o *CallObserver) Start(_ context.Context, key *cloud.RateLimitKey) {
+	o.started.Store(key, time.Now())
+}
+
+// End records the result and the latency of the call.
+func (o *CallObserver) End(_ context.Context, key *cloud.RateLimitKey, err error) {
+	code := HTTPCode(err)
+	apiRequests.WithLabelValues(key.Service, key.Operation, code).Inc()
+	if start, ok := o.started.LoadAndDelete(key); ok {
+		apiRequestDuration.WithLabelValues(key.Service, key.Operation, code).Observe(time.Since(start.(time.Time)).Seconds())
+	}
+}
+
+// HTTPCode returns the HTTP status code of the result of a REST call.
+func HTTPCode(err error) string {
+	if err == nil {
+		return strconv.Itoa(http.StatusOK)
+	}
+
+	var ae *googleapi.Error
+	if errors.As(err, &ae) {
+		return strconv.Itoa(ae.Code)
+	}
+
+	return "error"
+}
+
+// UnaryClientInterceptor records the calls of the gRPC clients of the GCP APIs.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	// Methods are formatted as /google.container.v1.ClusterManager/GetCluster.
+	service, operation := path.Split(method)
+	ObserveCall(path.Base(service), operation, status.Code(err).String(), time.Since(start))
+	return err
+}
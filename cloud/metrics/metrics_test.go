@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+func TestCallObserver(t *testing.T) {
+	o := &CallObserver{}
+	key := &cloud.RateLimitKey{Service: "Networks", Operation: "Get"}
+
+	o.Start(context.TODO(), key)
+	o.End(context.TODO(), key, &googleapi.Error{Code: http.StatusNotFound})
+
+	if got := testutil.ToFloat64(apiRequests.WithLabelValues("Networks", "Get", "404")); got != 1 {
+		t.Errorf("requests = %v, want 1", got)
+	}
+
+	if got := testutil.CollectAndCount(apiRequestDuration); got != 1 {
+		t.Errorf("request durations = %v, want 1", got)
+	}
+
+	if _, ok := o.started.Load(key); ok {
+		t.Errorf("started call was not forgotten")
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.NotFound, "cluster not found")
+	}
+
+	_ = UnaryClientInterceptor(context.TODO(), "/google.container.v1.ClusterManager/GetCluster", nil, nil, nil, invoker)
+
+	if got := testutil.ToFloat64(apiRequests.WithLabelValues("google.container.v1.ClusterManager", "GetCluster", "NotFound")); got != 1 {
+		t.Errorf("requests = %v, want 1", got)
+	}
+}
+
+func TestRecordInstanceStatus(t *testing.T) {
+	machine := &infrav1.GCPMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default", Finalizers: []string{infrav1.MachineFinalizer}},
+	}
+
+	provisioning, running := infrav1.InstanceStatusProvisioning, infrav1.InstanceStatusRunning
+	RecordInstanceStatus(machine, infrav1.MachineFinalizer, &provisioning)
+	RecordInstanceStatus(machine, infrav1.MachineFinalizer, &running)
+	if got := testutil.CollectAndCount(instanceStatus); got != 1 {
+		t.Fatalf("instance statuses = %v, want only the current one", got)
+	}
+
+	if got := testutil.ToFloat64(instanceStatus.WithLabelValues("default", "my-machine", "RUNNING")); got != 1 {
+		t.Errorf("RUNNING instance status = %v, want 1", got)
+	}
+
+	now := metav1.Now()
+	machine.DeletionTimestamp = &now
+	machine.Finalizers = nil
+	RecordInstanceStatus(machine, infrav1.MachineFinalizer, &running)
+	if got := testutil.CollectAndCount(instanceStatus); got != 0 {
+		t.Errorf("instance statuses = %v, want none once the machine is deleted", got)
+	}
+}
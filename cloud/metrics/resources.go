@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	ready = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capg_cluster_ready",
+		Help: "Whether a GCPCluster, GCPManagedCluster or GCPManagedControlPlane is ready (1) or not (0).",
+	}, []string{"kind", "namespace", "name"})
+
+	instanceStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capg_gcpmachine_instance_status",
+		Help: "Status of the instance of a GCPMachine, set to 1 for the current status.",
+	}, []string{"namespace", "name", "status"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(ready, instanceStatus)
+}
+
+// RecordReady records the readiness of a cluster object, or forgets it once it is deleted and its finalizer removed.
+func RecordReady(kind string, obj client.Object, finalizer string, isReady bool) {
+	if isDeleted(obj, finalizer) {
+		ready.DeleteLabelValues(kind, obj.GetNamespace(), obj.GetName())
+		return
+	}
+
+	value := 0.0
+	if isReady {
+		value = 1
+	}
+
+	ready.WithLabelValues(kind, obj.GetNamespace(), obj.GetName()).Set(value)
+}
+
+// RecordInstanceStatus records the instance status of a GCPMachine, or forgets it once it is deleted and its finalizer removed.
+func RecordInstanceStatus(obj client.Object, finalizer string, status *infrav1.InstanceStatus) {
+	instanceStatus.DeletePartialMatch(prometheus.Labels{"namespace": obj.GetNamespace(), "name": obj.GetName()})
+	if isDeleted(obj, finalizer) || status == nil {
+		return
+	}
+
+	instanceStatus.WithLabelValues(obj.GetNamespace(), obj.GetName(), string(*status)).Set(1)
+}
+
+func isDeleted(obj client.Object, finalizer string) bool {
+	return !obj.GetDeletionTimestamp().IsZero() && !controllerutil.ContainsFinalizer(obj, finalizer)
+}
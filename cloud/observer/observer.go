@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package observer
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
+)
+
+// calls records the metrics and the spans of the GCP API calls.
+var calls cloud.CallObserver = observers{&metrics.CallObserver{}, &tracing.CallObserver{}}
+
+// observers notifies several observers of the calls.
+type observers []cloud.CallObserver
+
+func (o observers) Start(ctx context.Context, key *cloud.RateLimitKey) {
+	for _, obs := range o {
+		obs.Start(ctx, key)
+	}
+}
+
+func (o observers) End(ctx context.Context, key *cloud.RateLimitKey, err error) {
+	for _, obs := range o {
+		obs.End(ctx, key, err)
+	}
+}
+
+// WithContext returns a context whose GCP API calls made through the cloud wrapper are observed.
+func WithContext(ctx context.Context) context.Context {
+	return cloud.WithCallObserver(ctx, calls)
+}
+
+// Call performs a GCP API call made outside of the cloud wrapper the same way the wrapper does: the call is observed,
+// waits for the rate limiter, and its result is reported to the rate limiter.
+func Call(ctx context.Context, rateLimiter cloud.RateLimiter, key *cloud.RateLimitKey, call func() error) error {
+	calls.Start(ctx, key)
+	if err := rateLimiter.Accept(ctx, key); err != nil {
+		calls.End(ctx, key, err)
+		return err
+	}
+
+	err := call()
+	calls.End(ctx, key, err)
+	rateLimiter.Observe(ctx, err, key)
+	return err
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// nonBlockingCloud overrides the mutating calls of the clients used by the cluster and machine
+// reconcilers so that they return a PendingError instead of waiting for the GCE operation.
+// Read calls and the other clients are served by the wrapped cloud.
+type nonBlockingCloud struct {
+	cloud.Cloud
+	service     *compute.Service
+	project     string
+	rateLimiter cloud.RateLimiter
+}
+
+// NewCloud returns a cloud.Cloud whose networks, subnetworks, firewalls, routes, load balancer
+// and instance clients start operations without waiting for them to complete.
+func NewCloud(c cloud.Cloud, service *compute.Service, project string, rateLimiter cloud.RateLimiter) cloud.Cloud {
+	return &nonBlockingCloud{Cloud: c, service: service, project: project, rateLimiter: rateLimiter}
+}
+
+func (c *nonBlockingCloud) do(ctx context.Context, service, operation string, call func(...googleapi.CallOption) (*compute.Operation, error)) error {
+	return Do(ctx, c.rateLimiter, &cloud.RateLimitKey{ProjectID: c.project, Operation: operation, Version: meta.VersionGA, Service: service}, call)
+}
+
+func (c *nonBlockingCloud) Networks() cloud.Networks {
+	return &networks{Networks: c.Cloud.Networks(), c: c}
+}
+
+func (c *nonBlockingCloud) Routers() cloud.Routers {
+	return &routers{Routers: c.Cloud.Routers(), c: c}
+}
+
+func (c *nonBlockingCloud) Subnetworks() cloud.Subnetworks {
+	return &subnetworks{Subnetworks: c.Cloud.Subnetworks(), c: c}
+}
+
+func (c *nonBlockingCloud) Firewalls() cloud.Firewalls {
+	return &firewalls{Firewalls: c.Cloud.Firewalls(), c: c}
+}
+
+func (c *nonBlockingCloud) Routes() cloud.Routes {
+	return &routes{Routes: c.Cloud.Routes(), c: c}
+}
+
+func (c *nonBlockingCloud) GlobalAddresses() cloud.GlobalAddresses {
+	return &globalAddresses{GlobalAddresses: c.Cloud.GlobalAddresses(), c: c}
+}
+
+func (c *nonBlockingCloud) BackendServices() cloud.BackendServices {
+	return &backendServices{BackendServices: c.Cloud.BackendServices(), c: c}
+}
+
+func (c *nonBlockingCloud) GlobalForwardingRules() cloud.GlobalForwardingRules {
+	return &globalForwardingRules{GlobalForwardingRules: c.Cloud.GlobalForwardingRules(), c: c}
+}
+
+func (c *nonBlockingCloud) HealthChecks() cloud.HealthChecks {
+	return &healthChecks{HealthChecks: c.Cloud.HealthChecks(), c: c}
+}
+
+func (c *nonBlockingCloud) InstanceGroups() cloud.InstanceGroups {
+	return &instanceGroups{InstanceGroups: c.Cloud.InstanceGroups(), c: c}
+}
+
+func (c *nonBlockingCloud) TargetTcpProxies() cloud.TargetTcpProxies { //nolint:revive,stylecheck // Name of the cloud.Cloud method.
+	return &targetTCPProxies{TargetTcpProxies: c.Cloud.TargetTcpProxies(), c: c}
+}
+
+func (c *nonBlockingCloud) Instances() cloud.Instances {
+	return &instances{Instances: c.Cloud.Instances(), c: c}
+}
+
+type networks struct {
+	cloud.Networks
+	c *nonBlockingCloud
+}
+
+func (n *networks) Insert(ctx context.Context, key *meta.Key, obj *compute.Network) error {
+	obj.Name = key.Name
+	return n.c.do(ctx, "Networks", "Insert", n.c.service.Networks.Insert(n.c.project, obj).Context(ctx).Do)
+}
+
+func (n *networks) Delete(ctx context.Context, key *meta.Key) error {
+	return n.c.do(ctx, "Networks", "Delete", n.c.service.Networks.Delete(n.c.project, key.Name).Context(ctx).Do)
+}
+
+type routers struct {
+	cloud.Routers
+	c *nonBlockingCloud
+}
+
+func (r *routers) Insert(ctx context.Context, key *meta.Key, obj *compute.Router) error {
+	obj.Name = key.Name
+	return r.c.do(ctx, "Routers", "Insert", r.c.service.Routers.Insert(r.c.project, key.Region, obj).Context(ctx).Do)
+}
+
+func (r *routers) Delete(ctx context.Context, key *meta.Key) error {
+	return r.c.do(ctx, "Routers", "Delete", r.c.service.Routers.Delete(r.c.project, key.Region, key.Name).Context(ctx).Do)
+}
+
+type subnetworks struct {
+	cloud.Subnetworks
+	c *nonBlockingCloud
+}
+
+func (s *subnetworks) Insert(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) error {
+	obj.Name = key.Name
+	return s.c.do(ctx, "Subnetworks", "Insert", s.c.service.Subnetworks.Insert(s.c.project, key.Region, obj).Context(ctx).Do)
+}
+
+func (s *subnetworks) Delete(ctx context.Context, key *meta.Key) error {
+	return s.c.do(ctx, "Subnetworks", "Delete", s.c.service.Subnetworks.Delete(s.c.project, key.Region, key.Name).Context(ctx).Do)
+}
+
+type firewalls struct {
+	cloud.Firewalls
+	c *nonBlockingCloud
+}
+
+func (f *firewalls) Insert(ctx context.Context, key *meta.Key, obj *compute.Firewall) error {
+	obj.Name = key.Name
+	return f.c.do(ctx, "Firewalls", "Insert", f.c.service.Firewalls.Insert(f.c.project, obj).Context(ctx).Do)
+}
+
+func (f *firewalls) Update(ctx context.Context, key *meta.Key, obj *compute.Firewall) error {
+	return f.c.do(ctx, "Firewalls", "Update", f.c.service.Firewalls.Update(f.c.project, key.Name, obj).Context(ctx).Do)
+}
+
+func (f *firewalls) Delete(ctx context.Context, key *meta.Key) error {
+	return f.c.do(ctx, "Firewalls", "Delete", f.c.service.Firewalls.Delete(f.c.project, key.Name).Context(ctx).Do)
+}
+
+type routes struct {
+	cloud.Routes
+	c *nonBlockingCloud
+}
+
+func (r *routes) Insert(ctx context.Context, key *meta.Key, obj *compute.Route) error {
+	obj.Name = key.Name
+	return r.c.do(ctx, "Routes", "Insert", r.c.service.Routes.Insert(r.c.project, obj).Context(ctx).Do)
+}
+
+func (r *routes) Delete(ctx context.Context, key *meta.Key) error {
+	return r.c.do(ctx, "Routes", "Delete", r.c.service.Routes.Delete(r.c.project, key.Name).Context(ctx).Do)
+}
+
+type globalAddresses struct {
+	cloud.GlobalAddresses
+	c *nonBlockingCloud
+}
+
+func (a *globalAddresses) Insert(ctx context.Context, key *meta.Key, obj *compute.Address) error {
+	obj.Name = key.Name
+	return a.c.do(ctx, "GlobalAddresses", "Insert", a.c.service.GlobalAddresses.Insert(a.c.project, obj).Context(ctx).Do)
+}
+
+func (a *globalAddresses) Delete(ctx context.Context, key *meta.Key) error {
+	return a.c.do(ctx, "GlobalAddresses", "Delete", a.c.service.GlobalAddresses.Delete(a.c.project, key.Name).Context(ctx).Do)
+}
+
+type backendServices struct {
+	cloud.BackendServices
+	c *nonBlockingCloud
+}
+
+func (b *backendServices) Insert(ctx context.Context, key *meta.Key, obj *compute.BackendService) error {
+	obj.Name = key.Name
+	return b.c.do(ctx, "BackendServices", "Insert", b.c.service.BackendServices.Insert(b.c.project, obj).Context(ctx).Do)
+}
+
+func (b *backendServices) Update(ctx context.Context, key *meta.Key, obj *compute.BackendService) error {
+	return b.c.do(ctx, "BackendServices", "Update", b.c.service.BackendServices.Update(b.c.project, key.Name, obj).Context(ctx).Do)
+}
+
+func (b *backendServices) Delete(ctx context.Context, key *meta.Key) error {
+	return b.c.do(ctx, "BackendServices", "Delete", b.c.service.BackendServices.Delete(b.c.project, key.Name).Context(ctx).Do)
+}
+
+type globalForwardingRules struct {
+	cloud.GlobalForwardingRules
+	c *nonBlockingCloud
+}
+
+func (f *globalForwardingRules) Insert(ctx context.Context, key *meta.Key, obj *compute.ForwardingRule) error {
+	obj.Name = key.Name
+	return f.c.do(ctx, "GlobalForwardingRules", "Insert", f.c.service.GlobalForwardingRules.Insert(f.c.project, obj).Context(ctx).Do)
+}
+
+func (f *globalForwardingRules) Delete(ctx context.Context, key *meta.Key) error {
+	return f.c.do(ctx, "GlobalForwardingRules", "Delete", f.c.service.GlobalForwardingRules.Delete(f.c.project, key.Name).Context(ctx).Do)
+}
+
+type healthChecks struct {
+	cloud.HealthChecks
+	c *nonBlockingCloud
+}
+
+func (h *healthChecks) Insert(ctx context.Context, key *meta.Key, obj *compute.HealthCheck) error {
+	obj.Name = key.Name
+	return h.c.do(ctx, "HealthChecks", "Insert", h.c.service.HealthChecks.Insert(h.c.project, obj).Context(ctx).Do)
+}
+
+func (h *healthChecks) Delete(ctx context.Context, key *meta.Key) error {
+	return h.c.do(ctx, "HealthChecks", "Delete", h.c.service.HealthChecks.Delete(h.c.project, key.Name).Context(ctx).Do)
+}
+
+type instanceGroups struct {
+	cloud.InstanceGroups
+	c *nonBlockingCloud
+}
+
+func (g *instanceGroups) Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceGroup) error {
+	obj.Name = key.Name
+	return g.c.do(ctx, "InstanceGroups", "Insert", g.c.service.InstanceGroups.Insert(g.c.project, key.Zone, obj).Context(ctx).Do)
+}
+
+func (g *instanceGroups) Delete(ctx context.Context, key *meta.Key) error {
+	return g.c.do(ctx, "InstanceGroups", "Delete", g.c.service.InstanceGroups.Delete(g.c.project, key.Zone, key.Name).Context(ctx).Do)
+}
+
+func (g *instanceGroups) AddInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsAddInstancesRequest) error {
+	return g.c.do(ctx, "InstanceGroups", "AddInstances", g.c.service.InstanceGroups.AddInstances(g.c.project, key.Zone, key.Name, req).Context(ctx).Do)
+}
+
+func (g *instanceGroups) RemoveInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsRemoveInstancesRequest) error {
+	return g.c.do(ctx, "InstanceGroups", "RemoveInstances", g.c.service.InstanceGroups.RemoveInstances(g.c.project, key.Zone, key.Name, req).Context(ctx).Do)
+}
+
+type targetTCPProxies struct {
+	cloud.TargetTcpProxies
+	c *nonBlockingCloud
+}
+
+func (t *targetTCPProxies) Insert(ctx context.Context, key *meta.Key, obj *compute.TargetTcpProxy) error {
+	obj.Name = key.Name
+	return t.c.do(ctx, "TargetTcpProxies", "Insert", t.c.service.TargetTcpProxies.Insert(t.c.project, obj).Context(ctx).Do)
+}
+
+func (t *targetTCPProxies) Delete(ctx context.Context, key *meta.Key) error {
+	return t.c.do(ctx, "TargetTcpProxies", "Delete", t.c.service.TargetTcpProxies.Delete(t.c.project, key.Name).Context(ctx).Do)
+}
+
+type instances struct {
+	cloud.Instances
+	c *nonBlockingCloud
+}
+
+func (i *instances) Insert(ctx context.Context, key *meta.Key, obj *compute.Instance) error {
+	obj.Name = key.Name
+	return i.c.do(ctx, "Instances", "Insert", i.c.service.Instances.Insert(i.c.project, key.Zone, obj).Context(ctx).Do)
+}
+
+func (i *instances) Delete(ctx context.Context, key *meta.Key) error {
+	return i.c.do(ctx, "Instances", "Delete", i.c.service.Instances.Delete(i.c.project, key.Zone, key.Name).Context(ctx).Do)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// LabelSetter sets the labels of the compute resources without waiting for the GCE operations,
+// returning a PendingError while they are running.
+type LabelSetter struct {
+	service     *compute.Service
+	project     string
+	rateLimiter cloud.RateLimiter
+}
+
+// NewLabelSetter returns a LabelSetter of the compute resources of the project.
+func NewLabelSetter(service *compute.Service, project string, rateLimiter cloud.RateLimiter) *LabelSetter {
+	return &LabelSetter{service: service, project: project, rateLimiter: rateLimiter}
+}
+
+func (l *LabelSetter) do(ctx context.Context, service string, call func(...googleapi.CallOption) (*compute.Operation, error)) error {
+	return Do(ctx, l.rateLimiter, &cloud.RateLimitKey{ProjectID: l.project, Operation: "SetLabels", Version: meta.VersionGA, Service: service}, call)
+}
+
+// SetGlobalAddressLabels sets the labels of a global address.
+func (l *LabelSetter) SetGlobalAddressLabels(ctx context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error {
+	return l.do(ctx, "GlobalAddresses", l.service.GlobalAddresses.SetLabels(l.project, key.Name, req).Context(ctx).Do)
+}
+
+// SetGlobalForwardingRuleLabels sets the labels of a global forwarding rule.
+func (l *LabelSetter) SetGlobalForwardingRuleLabels(ctx context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error {
+	return l.do(ctx, "GlobalForwardingRules", l.service.GlobalForwardingRules.SetLabels(l.project, key.Name, req).Context(ctx).Do)
+}
+
+// SetInstanceLabels sets the labels of an instance.
+func (l *LabelSetter) SetInstanceLabels(ctx context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error {
+	return l.do(ctx, "Instances", l.service.Instances.SetLabels(l.project, key.Zone, key.Name, req).Context(ctx).Do)
+}
+
+// SetDiskLabels sets the labels of a zonal disk.
+func (l *LabelSetter) SetDiskLabels(ctx context.Context, key *meta.Key, req *compute.ZoneSetLabelsRequest) error {
+	return l.do(ctx, "Disks", l.service.Disks.SetLabels(l.project, key.Zone, key.Name, req).Context(ctx).Do)
+}
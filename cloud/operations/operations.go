@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const statusDone = "DONE"
+
+// PendingError is returned by the non-blocking compute clients when an operation
+// has been started and is not done yet.
+type PendingError struct {
+	Operation infrav1.Operation
+}
+
+// Error implements error.
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("operation %s %s is pending on %s", e.Operation.Name, e.Operation.OperationType, e.Operation.TargetLink)
+}
+
+// IsPending returns the operation of a PendingError wrapped in err, if any.
+func IsPending(err error) (*infrav1.Operation, bool) {
+	var pending *PendingError
+	if errors.As(err, &pending) {
+		return &pending.Operation, true
+	}
+
+	return nil, false
+}
+
+// Track appends the operation of a PendingError wrapped in err to the pending operations,
+// and reports whether err was a PendingError.
+func Track(pending *[]infrav1.Operation, err error) bool {
+	op, ok := IsPending(err)
+	if !ok {
+		return false
+	}
+
+	for _, p := range *pending {
+		if p.Name == op.Name {
+			return true
+		}
+	}
+
+	*pending = append(*pending, *op)
+	return true
+}
+
+// Do waits for the rate limiter, starts an operation and checks it.
+func Do(ctx context.Context, rateLimiter cloud.RateLimiter, key *cloud.RateLimitKey, call func(...googleapi.CallOption) (*compute.Operation, error)) error {
+	var op *compute.Operation
+	err := observer.Call(ctx, rateLimiter, key, func() (err error) {
+		op, err = call()
+		return err
+	})
+	return Check(op, err)
+}
+
+// Check returns nil if the operation is done, the operation error if it failed,
+// or a PendingError if it is still running.
+func Check(op *compute.Operation, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if op.Status != statusDone {
+		return &PendingError{Operation: fromCompute(op)}
+	}
+
+	return operationError(op)
+}
+
+// Poll refreshes the pending operations and removes the ones that are done.
+// It reports whether some operations are still running, and returns the errors of the failed ones.
+func Poll(ctx context.Context, service *compute.Service, rateLimiter cloud.RateLimiter, project string, pending *[]infrav1.Operation) (bool, error) {
+	log := log.FromContext(ctx)
+	remaining := make([]infrav1.Operation, 0, len(*pending))
+	var errs []error
+	for _, p := range *pending {
+		op, err := get(ctx, service, rateLimiter, project, p)
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				// Operations are garbage collected some time after they completed.
+				log.V(2).Info("Pending operation not found, forgetting it", "name", p.Name)
+				continue
+			}
+
+			return true, err
+		}
+
+		if op.Status != statusDone {
+			remaining = append(remaining, p)
+			continue
+		}
+
+		log.V(2).Info("Operation done", "name", p.Name, "type", p.OperationType, "target", p.TargetLink)
+		if err := operationError(op); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	*pending = remaining
+	return len(remaining) > 0, errors.Join(errs...)
+}
+
+func get(ctx context.Context, service *compute.Service, rateLimiter cloud.RateLimiter, project string, p infrav1.Operation) (*compute.Operation, error) {
+	key := &cloud.RateLimitKey{ProjectID: project, Operation: "Get", Version: meta.VersionGA, Service: "Operations"}
+	var op *compute.Operation
+	err := observer.Call(ctx, rateLimiter, key, func() (err error) {
+		switch {
+		case p.Zone != "":
+			op, err = service.ZoneOperations.Get(project, p.Zone, p.Name).Context(ctx).Do()
+		case p.Region != "":
+			op, err = service.RegionOperations.Get(project, p.Region, p.Name).Context(ctx).Do()
+		default:
+			op, err = service.GlobalOperations.Get(project, p.Name).Context(ctx).Do()
+		}
+		return err
+	})
+	return op, err
+}
+
+func operationError(op *compute.Operation) error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+
+	return &gcperrors.OperationError{
+		Name:          op.Name,
+		OperationType: op.OperationType,
+		TargetLink:    op.TargetLink,
+		Errors:        op.Error.Errors,
+	}
+}
+
+func fromCompute(op *compute.Operation) infrav1.Operation {
+	return infrav1.Operation{
+		Name:          op.Name,
+		Region:        lastSegment(op.Region),
+		Zone:          lastSegment(op.Zone),
+		OperationType: op.OperationType,
+		TargetLink:    op.TargetLink,
+	}
+}
+
+func lastSegment(link string) string {
+	return link[strings.LastIndex(link, "/")+1:]
+}
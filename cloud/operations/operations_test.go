@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          *compute.Operation
+		err         error
+		wantPending *infrav1.Operation
+		wantErr     bool
+	}{
+		{
+			name:    "call error is returned",
+			err:     errors.New("quota exceeded"),
+			wantErr: true,
+		},
+		{
+			name: "running operation is pending",
+			op: &compute.Operation{
+				Name:          "operation-1",
+				Status:        "RUNNING",
+				OperationType: "insert",
+				Zone:          "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a",
+				TargetLink:    "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-machine",
+			},
+			wantPending: &infrav1.Operation{
+				Name:          "operation-1",
+				Zone:          "us-central1-a",
+				OperationType: "insert",
+				TargetLink:    "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-machine",
+			},
+			wantErr: true,
+		},
+		{
+			name: "done operation succeeds",
+			op:   &compute.Operation{Name: "operation-1", Status: "DONE"},
+		},
+		{
+			name: "failed operation returns its errors",
+			op: &compute.Operation{
+				Name:   "operation-1",
+				Status: "DONE",
+				Error: &compute.OperationError{
+					Errors: []*compute.OperationErrorErrors{{Code: "RESOURCE_IN_USE_BY_ANOTHER_RESOURCE", Message: "in use"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.op, tt.err)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			pending, ok := IsPending(err)
+			if ok != (tt.wantPending != nil) {
+				t.Fatalf("IsPending() = %v, want %v", ok, tt.wantPending != nil)
+			}
+
+			if tt.wantPending != nil && *pending != *tt.wantPending {
+				t.Errorf("IsPending() = %+v, want %+v", *pending, *tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestTrack(t *testing.T) {
+	pending := []infrav1.Operation{{Name: "operation-1"}}
+
+	if Track(&pending, errors.New("quota exceeded")) {
+		t.Errorf("Track() = true for an error that is not pending")
+	}
+
+	if !Track(&pending, errors.Wrap(&PendingError{Operation: infrav1.Operation{Name: "operation-1"}}, "creating network")) {
+		t.Errorf("Track() = false for a wrapped pending error")
+	}
+
+	if !Track(&pending, &PendingError{Operation: infrav1.Operation{Name: "operation-2"}}) {
+		t.Errorf("Track() = false for a pending error")
+	}
+
+	if len(pending) != 2 {
+		t.Errorf("Track() pending operations = %+v, want operation-1 and operation-2", pending)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	operations := map[string]*compute.Operation{
+		"/projects/my-proj/global/operations/running":           {Name: "running", Status: "RUNNING"},
+		"/projects/my-proj/zones/us-central1-a/operations/done": {Name: "done", Status: "DONE"},
+		"/projects/my-proj/regions/us-central1/operations/failed": {
+			Name:   "failed",
+			Status: "DONE",
+			Error: &compute.OperationError{
+				Errors: []*compute.OperationErrorErrors{{Code: "ZONE_RESOURCE_POOL_EXHAUSTED", Message: "no more n2 machines"}},
+			},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, ok := operations[strings.TrimPrefix(r.URL.Path, "/compute/v1")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(op)
+	}))
+	defer server.Close()
+
+	service, err := compute.NewService(context.TODO(), option.WithEndpoint(server.URL+"/compute/v1/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := []infrav1.Operation{
+		{Name: "running"},
+		{Name: "done", Zone: "us-central1-a"},
+		{Name: "failed", Region: "us-central1"},
+		{Name: "collected"},
+	}
+	running, err := Poll(context.TODO(), service, &cloud.NopRateLimiter{}, "my-proj", &pending)
+	if err == nil || !strings.Contains(err.Error(), "ZONE_RESOURCE_POOL_EXHAUSTED") {
+		t.Errorf("Poll() error = %v, want the failed operation error", err)
+	}
+
+	if category := gcperrors.Classify(err); category != gcperrors.ZoneResourceExhausted {
+		t.Errorf("Classify(Poll() error) = %s, want %s", category, gcperrors.ZoneResourceExhausted)
+	}
+
+	if !running {
+		t.Errorf("Poll() running = false, want true")
+	}
+
+	if len(pending) != 1 || pending[0].Name != "running" {
+		t.Errorf("Poll() pending operations = %+v, want only the running operation", pending)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"strings"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/util/resourceurl"
 )
@@ -47,6 +48,19 @@ func NewFromResourceURL(url string) (ProviderID, error) {
 	return New(resourceURL.Project, resourceURL.Location, resourceURL.Name)
 }
 
+// Parse parses a provider id of the form gce://project/location/name.
+func Parse(providerID string) (ProviderID, error) {
+	if !strings.HasPrefix(providerID, Prefix) {
+		return nil, fmt.Errorf("provider id %q is missing prefix %s", providerID, Prefix)
+	}
+	parts := strings.Split(strings.TrimPrefix(providerID, Prefix), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("provider id %q must be in the form %sproject/location/name", providerID, Prefix)
+	}
+
+	return New(parts[0], parts[1], parts[2])
+}
+
 // New creates a new provider id.
 func New(project, location, name string) (ProviderID, error) {
 	if project == "" {
@@ -114,3 +114,49 @@ func TestProviderID_NewFromResourceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderID_Parse(t *testing.T) {
+	RegisterTestingT(t)
+
+	testCases := []struct {
+		testname           string
+		providerID         string
+		expectedProviderID string
+		expectError        bool
+	}{
+		{
+			testname:    "missing prefix, should fail",
+			providerID:  "proj1/eu-west4/vm1",
+			expectError: true,
+		},
+		{
+			testname:    "missing name, should fail",
+			providerID:  "gce://proj1/eu-west4",
+			expectError: true,
+		},
+		{
+			testname:    "empty project, should fail",
+			providerID:  "gce:///eu-west4/vm1",
+			expectError: true,
+		},
+		{
+			testname:           "valid provider id, should pass",
+			providerID:         "gce://proj1/eu-west4/vm1",
+			expectError:        false,
+			expectedProviderID: "gce://proj1/eu-west4/vm1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testname, func(t *testing.T) {
+			providerID, err := providerid.Parse(tc.providerID)
+
+			if tc.expectError {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(providerID.String()).To(Equal(tc.expectedProviderID))
+			}
+		})
+	}
+}
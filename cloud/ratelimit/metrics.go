@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsSubsystem = "capg_gcp_api_rate_limit"
+
+var (
+	qps = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "qps",
+		Help:      "Current rate of the GCP API rate limit buckets, lowered while GCP rate limits the calls.",
+	}, []string{"project", "bucket"})
+
+	backOffs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "backoffs_total",
+		Help:      "Number of times a GCP API rate limit bucket backed off after a rate limit or quota error.",
+	}, []string{"project", "bucket"})
+
+	waitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "wait_seconds",
+		Help:      "Time GCP API calls waited for their rate limit bucket.",
+		Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30},
+	}, []string{"project", "bucket"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(qps, backOffs, waitSeconds)
+}
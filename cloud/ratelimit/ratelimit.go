@@ -0,0 +1,231 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+)
+
+const (
+	// DefaultQPS is the default rate of the GCP API calls of a project.
+	DefaultQPS = 20
+	// DefaultBurst is the default burst of the GCP API calls of a project.
+	DefaultBurst = 40
+
+	// defaultBucket is the name of the bucket shared by the calls without a limit of their own.
+	defaultBucket = "*"
+
+	// minimumFactor is how far below its configured rate a bucket can back off.
+	minimumFactor = 32
+	// recoveryFactor is the fraction of the configured rate a bucket recovers after each successful call.
+	recoveryFactor = 20
+)
+
+// Limit is the rate and burst of a rate limiter.
+type Limit struct {
+	QPS   float64
+	Burst int
+}
+
+// Validate returns an error if the rate or the burst of the limit is not positive, which would block the calls forever.
+func (l Limit) Validate() error {
+	if math.IsNaN(l.QPS) || math.IsInf(l.QPS, 0) || l.QPS <= 0 {
+		return fmt.Errorf("invalid rate %v: must be a positive number", l.QPS)
+	}
+
+	if l.Burst <= 0 {
+		return fmt.Errorf("invalid burst %d: must be a positive integer", l.Burst)
+	}
+
+	return nil
+}
+
+// Config configures the rate limiters of the projects.
+type Config struct {
+	// Default is the limit shared by the calls of a project without a limit of their own.
+	Default Limit
+	// Overrides are the limits of the calls of a service, e.g. "Instances",
+	// or of an operation of a service, e.g. "Instances.Insert".
+	Overrides map[string]Limit
+}
+
+// DefaultConfig returns the configuration used when Configure is not called.
+func DefaultConfig() Config {
+	return Config{
+		Default: Limit{QPS: DefaultQPS, Burst: DefaultBurst},
+		Overrides: map[string]Limit{
+			"Operations.Get": {QPS: 5, Burst: 5},
+		},
+	}
+}
+
+var (
+	mu       sync.Mutex
+	config   = DefaultConfig()
+	limiters = map[string]*Limiter{}
+)
+
+// Configure sets the configuration of the rate limiters. It must be called before ForProject.
+func Configure(c Config) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	config = c
+	limiters = map[string]*Limiter{}
+}
+
+// ForProject returns the rate limiter shared by all the clients of the given project.
+func ForProject(project string) *Limiter {
+	mu.Lock()
+	defer mu.Unlock()
+
+	l, ok := limiters[project]
+	if !ok {
+		l = &Limiter{project: project, config: config, buckets: map[string]*bucket{}}
+		limiters[project] = l
+	}
+
+	return l
+}
+
+// ParseLimits parses limits formatted as "qps" or "qps:burst", e.g. "5:10".
+// The burst defaults to the rounded up rate.
+func ParseLimits(values map[string]string) (map[string]Limit, error) {
+	limits := make(map[string]Limit, len(values))
+	for name, value := range values {
+		qpsValue, burstValue, hasBurst := strings.Cut(value, ":")
+		limitQPS, err := strconv.ParseFloat(qpsValue, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate %q for %s: must be a positive number", qpsValue, name)
+		}
+
+		limit := Limit{QPS: limitQPS, Burst: int(math.Ceil(limitQPS))}
+		if hasBurst {
+			limit.Burst, err = strconv.Atoi(burstValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid burst %q for %s: must be a positive integer", burstValue, name)
+			}
+		}
+
+		if err := limit.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		limits[name] = limit
+	}
+
+	return limits, nil
+}
+
+// Limiter implements cloud.RateLimiter. The calls of a project are limited by the bucket of their
+// operation or service when one is configured, by the default bucket of the project otherwise.
+// The rate of a bucket is lowered when its calls are rate limited by GCP, and recovers as they succeed.
+type Limiter struct {
+	project string
+	config  Config
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+var _ cloud.RateLimiter = &Limiter{}
+
+// Accept blocks until the call can be performed.
+func (l *Limiter) Accept(ctx context.Context, key *cloud.RateLimitKey) error {
+	b := l.bucket(key)
+	start := time.Now()
+	if err := b.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	waitSeconds.WithLabelValues(l.project, b.name).Observe(time.Since(start).Seconds())
+	return nil
+}
+
+// Observe adapts the rate of the bucket of the call to its result.
+func (l *Limiter) Observe(_ context.Context, err error, key *cloud.RateLimitKey) {
+	b := l.bucket(key)
+	switch {
+	case gcperrors.IsRateLimited(err):
+		b.backOff()
+		backOffs.WithLabelValues(l.project, b.name).Inc()
+	case err == nil:
+		b.recover()
+	default:
+		return
+	}
+
+	qps.WithLabelValues(l.project, b.name).Set(float64(b.limiter.Limit()))
+}
+
+func (l *Limiter) bucket(key *cloud.RateLimitKey) *bucket {
+	name, limit := defaultBucket, l.config.Default
+	if override, ok := l.config.Overrides[key.Service+"."+key.Operation]; ok {
+		name, limit = key.Service+"."+key.Operation, override
+	} else if override, ok := l.config.Overrides[key.Service]; ok {
+		name, limit = key.Service, override
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[name]
+	if !ok {
+		b = &bucket{name: name, limit: limit, limiter: rate.NewLimiter(rate.Limit(limit.QPS), limit.Burst)}
+		l.buckets[name] = b
+		qps.WithLabelValues(l.project, name).Set(limit.QPS)
+	}
+
+	return b
+}
+
+// bucket is a token bucket whose rate can be lowered down to a fraction of its configured rate.
+type bucket struct {
+	name    string
+	limit   Limit
+	limiter *rate.Limiter
+
+	mu sync.Mutex
+}
+
+func (b *bucket) backOff() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current := float64(b.limiter.Limit())
+	b.limiter.SetLimit(rate.Limit(math.Max(current/2, b.limit.QPS/minimumFactor)))
+}
+
+func (b *bucket) recover() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current := float64(b.limiter.Limit())
+	if current < b.limit.QPS {
+		b.limiter.SetLimit(rate.Limit(math.Min(current+b.limit.QPS/recoveryFactor, b.limit.QPS)))
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/googleapi"
+)
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    map[string]Limit
+		wantErr bool
+	}{
+		{
+			name:   "rate and burst",
+			values: map[string]string{"Instances.Insert": "5:10", "Operations": "2.5"},
+			want:   map[string]Limit{"Instances.Insert": {QPS: 5, Burst: 10}, "Operations": {QPS: 2.5, Burst: 3}},
+		},
+		{
+			name:    "invalid rate",
+			values:  map[string]string{"Instances": "fast"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid burst",
+			values:  map[string]string{"Instances": "5:0"},
+			wantErr: true,
+		},
+		{
+			name:    "negative rate",
+			values:  map[string]string{"Instances": "-1:5"},
+			wantErr: true,
+		},
+		{
+			name:    "not a number rate",
+			values:  map[string]string{"Instances": "NaN"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimits(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLimits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterBuckets(t *testing.T) {
+	Configure(Config{
+		Default: Limit{QPS: 20, Burst: 40},
+		Overrides: map[string]Limit{
+			"Instances":        {QPS: 10, Burst: 10},
+			"Instances.Insert": {QPS: 2, Burst: 2},
+		},
+	})
+	defer Configure(DefaultConfig())
+
+	l := ForProject("my-proj")
+	if ForProject("my-proj") != l {
+		t.Errorf("ForProject() returned a different limiter for the same project")
+	}
+
+	tests := []struct {
+		key  *cloud.RateLimitKey
+		want string
+	}{
+		{key: &cloud.RateLimitKey{Service: "Instances", Operation: "Insert"}, want: "Instances.Insert"},
+		{key: &cloud.RateLimitKey{Service: "Instances", Operation: "Get"}, want: "Instances"},
+		{key: &cloud.RateLimitKey{Service: "Networks", Operation: "Get"}, want: defaultBucket},
+	}
+	for _, tt := range tests {
+		if got := l.bucket(tt.key).name; got != tt.want {
+			t.Errorf("bucket(%s.%s) = %s, want %s", tt.key.Service, tt.key.Operation, got, tt.want)
+		}
+	}
+}
+
+func TestLimiterBackOff(t *testing.T) {
+	Configure(Config{Default: Limit{QPS: 64, Burst: 64}})
+	defer Configure(DefaultConfig())
+
+	l := ForProject("my-proj")
+	key := &cloud.RateLimitKey{Service: "Instances", Operation: "Insert"}
+	rateLimited := &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}
+
+	l.Observe(context.TODO(), rateLimited, key)
+	if got := float64(l.bucket(key).limiter.Limit()); got != 32 {
+		t.Errorf("rate after a rate limited call = %v, want 32", got)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.Observe(context.TODO(), &googleapi.Error{Code: http.StatusTooManyRequests}, key)
+	}
+	if got := float64(l.bucket(key).limiter.Limit()); got != 2 {
+		t.Errorf("rate after many rate limited calls = %v, want the minimum 2", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		l.Observe(context.TODO(), nil, key)
+	}
+	if got := float64(l.bucket(key).limiter.Limit()); got != 64 {
+		t.Errorf("rate after many successful calls = %v, want the configured 64", got)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+// staleClientGracePeriod is how long a client replaced in the cache stays open, so the reconciles using it can finish.
+var staleClientGracePeriod = time.Minute
+
+// idleClientTimeout is how long a client stays cached without being used, e.g. after its cluster or the Secret
+// of its credentials was deleted.
+var idleClientTimeout = time.Hour
+
+// clients caches the GCP clients of the scopes, so the reconciles of the clusters sharing credentials reuse
+// their gRPC connections and HTTP transports instead of creating new ones every time.
+var clients = newClientCache()
+
+// clientCache caches clients by kind and credentials source.
+// A client is replaced when the content of the Secret of its credentials changes, and closed once it is idle.
+type clientCache struct {
+	mu      sync.Mutex
+	entries map[string]*clientCacheEntry
+}
+
+type clientCacheEntry struct {
+	// hash is the hash of the credentials the client was created with.
+	hash string
+	// ready is closed once the client is created, or failed to be.
+	ready    chan struct{}
+	client   any
+	err      error
+	lastUsed time.Time
+}
+
+func newClientCache() *clientCache {
+	return &clientCache{entries: map[string]*clientCacheEntry{}}
+}
+
+// get returns the client cached under the key if it was created with the credentials of the hash, otherwise
+// it creates a new client which replaces the cached one. The client is created without holding the lock of the
+// cache, the concurrent calls for the same key wait for it while the calls for the other keys are not blocked.
+func (c *clientCache) get(key, hash string, newClient func() (any, error)) (any, error) {
+	c.mu.Lock()
+	now := time.Now()
+	c.evictIdle(now)
+
+	entry, ok := c.entries[key]
+	if ok && entry.hash == hash {
+		entry.lastUsed = now
+		c.mu.Unlock()
+
+		<-entry.ready
+		return entry.client, entry.err
+	}
+
+	stale := entry
+	entry = &clientCacheEntry{hash: hash, ready: make(chan struct{}), lastUsed: now}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.client, entry.err = newClient()
+	close(entry.ready)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry.err != nil {
+		// The next call retries creating the client, the previous one is kept until then.
+		if c.entries[key] == entry {
+			if stale != nil {
+				c.entries[key] = stale
+			} else {
+				delete(c.entries, key)
+			}
+		}
+
+		return nil, entry.err
+	}
+
+	if stale != nil {
+		closeClientAfter(stale.client, staleClientGracePeriod)
+	}
+
+	return entry.client, nil
+}
+
+// evictIdle closes and drops the clients which were not used for idleClientTimeout.
+func (c *clientCache) evictIdle(now time.Time) {
+	for key, entry := range c.entries {
+		if now.Sub(entry.lastUsed) < idleClientTimeout {
+			continue
+		}
+
+		select {
+		case <-entry.ready:
+			delete(c.entries, key)
+			closeClientAfter(entry.client, staleClientGracePeriod)
+		default:
+			// The client is still being created.
+		}
+	}
+}
+
+// closeClientAfter closes the client once the duration has elapsed, if it can be closed.
+func closeClientAfter(client any, d time.Duration) {
+	closer, ok := client.(interface{ Close() error })
+	if !ok {
+		return
+	}
+
+	time.AfterFunc(d, func() {
+		_ = closer.Close()
+	})
+}
+
+// cachedClient returns the client of the kind cached for the credentials source, creating it with newClient
+// if it is missing or was created with a previous content of the Secret of the credentials.
+func cachedClient[T any](kind string, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, rawData []byte, newClient func() (T, error)) (T, error) {
+	client, err := clients.get(clientCacheKey(kind, credentialsRef, impersonation), credentialsHash(rawData), func() (any, error) {
+		return newClient()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return client.(T), nil
+}
+
+// clientCacheKey returns the key identifying the clients of the kind created with the credentials source.
+func clientCacheKey(kind string, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation) string {
+	return fmt.Sprintf("%s|%s", kind, credentialsSourceKey(credentialsRef, impersonation))
+}
+
+// credentialsSourceKey returns the key identifying the credentials source, "default" for the credentials of the
+// controller.
+func credentialsSourceKey(credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation) string {
+	source := "default"
+	if credentialsRef != nil {
+		source = fmt.Sprintf("%s/%s", credentialsRef.Namespace, credentialsRef.Name)
+	}
+
+	if impersonation != nil {
+		source = fmt.Sprintf("%s>%s", source, strings.Join(append(append([]string{}, impersonation.Delegates...), impersonation.TargetServiceAccount), ">"))
+	}
+
+	return source
+}
+
+// credentialsHash returns the hash of the content of the Secret of the credentials.
+func credentialsHash(rawData []byte) string {
+	if rawData == nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(rawData)
+	return hex.EncodeToString(sum[:])
+}
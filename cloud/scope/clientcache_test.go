@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+type fakeClient struct {
+	closed atomic.Bool
+}
+
+func (c *fakeClient) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func TestClientCache(t *testing.T) {
+	defaultGracePeriod := staleClientGracePeriod
+	staleClientGracePeriod = 0
+	defer func() { staleClientGracePeriod = defaultGracePeriod }()
+
+	cache := newClientCache()
+	created := 0
+	newClient := func() (any, error) {
+		created++
+		return &fakeClient{}, nil
+	}
+
+	first, err := cache.get("compute|default", "hash", newClient)
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+
+	got, _ := cache.get("compute|default", "hash", newClient)
+	if got != first || created != 1 {
+		t.Errorf("get() created a client for the same credentials")
+	}
+
+	if _, err := cache.get("compute|default", "rotated", func() (any, error) { return nil, errors.New("invalid credentials") }); err == nil {
+		t.Errorf("get() did not return the error creating the client")
+	}
+
+	got, _ = cache.get("compute|default", "rotated", newClient)
+	if got == first || created != 2 {
+		t.Errorf("get() reused the client of the previous credentials")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !first.(*fakeClient).closed.Load() {
+		if time.Now().After(deadline) {
+			t.Fatalf("the client of the previous credentials was not closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClientCacheConcurrentKeys(t *testing.T) {
+	cache := newClientCache()
+	building := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, err := cache.get("compute|slow", "hash", func() (any, error) {
+			close(building)
+			<-release
+			return &fakeClient{}, nil
+		})
+		done <- err
+	}()
+	<-building
+
+	other := make(chan error)
+	go func() {
+		_, err := cache.get("compute|other", "hash", func() (any, error) { return &fakeClient{}, nil })
+		other <- err
+	}()
+
+	select {
+	case err := <-other:
+		if err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("get() of another key was blocked while a client was created")
+	}
+
+	waiter := make(chan any)
+	go func() {
+		client, _ := cache.get("compute|slow", "hash", func() (any, error) {
+			t.Error("get() created a client while one was being created for the same key")
+			return nil, nil
+		})
+		waiter <- client
+	}()
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if client := <-waiter; client == nil {
+		t.Errorf("get() did not return the client created by the concurrent call")
+	}
+}
+
+func TestClientCacheEvictsIdleClients(t *testing.T) {
+	defaultGracePeriod, defaultIdleTimeout := staleClientGracePeriod, idleClientTimeout
+	staleClientGracePeriod, idleClientTimeout = 0, time.Hour
+	defer func() { staleClientGracePeriod, idleClientTimeout = defaultGracePeriod, defaultIdleTimeout }()
+
+	cache := newClientCache()
+	idle, _ := cache.get("compute|deleted", "hash", func() (any, error) { return &fakeClient{}, nil })
+	cache.entries["compute|deleted"].lastUsed = time.Now().Add(-2 * time.Hour)
+
+	if _, err := cache.get("compute|default", "hash", func() (any, error) { return &fakeClient{}, nil }); err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+
+	if _, ok := cache.entries["compute|deleted"]; ok {
+		t.Errorf("get() kept the idle client")
+	}
+	if _, ok := cache.entries["compute|default"]; !ok {
+		t.Errorf("get() did not cache the client in use")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !idle.(*fakeClient).closed.Load() {
+		if time.Now().After(deadline) {
+			t.Fatalf("the idle client was not closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClientCacheKey(t *testing.T) {
+	credentialsRef := &infrav1.ObjectReference{Namespace: "default", Name: "credentials"}
+	impersonation := &infrav1.ServiceAccountImpersonation{
+		TargetServiceAccount: "capg@my-proj.iam.gserviceaccount.com",
+		Delegates:            []string{"broker@my-proj.iam.gserviceaccount.com"},
+	}
+
+	tests := []struct {
+		name           string
+		credentialsRef *infrav1.ObjectReference
+		impersonation  *infrav1.ServiceAccountImpersonation
+		want           string
+	}{
+		{
+			name: "controller credentials",
+			want: "compute|default",
+		},
+		{
+			name:           "credentials secret",
+			credentialsRef: credentialsRef,
+			want:           "compute|default/credentials",
+		},
+		{
+			name:           "impersonation",
+			credentialsRef: credentialsRef,
+			impersonation:  impersonation,
+			want:           "compute|default/credentials>broker@my-proj.iam.gserviceaccount.com>capg@my-proj.iam.gserviceaccount.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientCacheKey("compute", tt.credentialsRef, tt.impersonation); got != tt.want {
+				t.Errorf("clientCacheKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
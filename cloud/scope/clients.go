@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	computerest "cloud.google.com/go/compute/apiv1"
@@ -29,9 +30,16 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/option"
+	"google.golang.org/api/serviceusage/v1"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
 	"k8s.io/client-go/pkg/version"
-	"k8s.io/client-go/util/flowcontrol"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/dryrun"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/ratelimit"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -40,123 +48,308 @@ type GCPServices struct {
 	Compute *compute.Service
 }
 
-// GCPRateLimiter implements cloud.RateLimiter.
-type GCPRateLimiter struct{}
+// GCPRateLimiter implements cloud.RateLimiter on top of the rate limiter shared by the clients of a project.
+type GCPRateLimiter struct {
+	*ratelimit.Limiter
+}
 
 // Accept blocks until the operation can be performed.
 func (rl *GCPRateLimiter) Accept(ctx context.Context, key *cloud.RateLimitKey) error {
 	if key.Operation == "Get" && key.Service == "Operations" {
-		// Wait a minimum amount of time regardless of rate limiter.
+		// Operations are polled until they are done, wait a minimum amount of time between polls regardless of rate limiter.
 		rl := &cloud.MinimumRateLimiter{
-			// Convert flowcontrol.RateLimiter into cloud.RateLimiter
-			RateLimiter: &cloud.AcceptRateLimiter{
-				Acceptor: flowcontrol.NewTokenBucketRateLimiter(5, 5), // 5
-			},
-			Minimum: time.Second,
+			RateLimiter: rl.Limiter,
+			Minimum:     time.Second,
 		}
 
 		return rl.Accept(ctx, key)
 	}
-	return nil
-}
 
-// Observe does nothing.
-func (rl *GCPRateLimiter) Observe(context.Context, error, *cloud.RateLimitKey) {
-	// noop
+	return rl.Limiter.Accept(ctx, key)
 }
 
 func newCloud(project string, service GCPServices) cloud.Cloud {
 	return cloud.NewGCE(&cloud.Service{
 		GA:            service.Compute,
 		ProjectRouter: &cloud.SingleProjectRouter{ID: project},
-		RateLimiter:   &GCPRateLimiter{},
+		RateLimiter:   &GCPRateLimiter{Limiter: ratelimit.ForProject(project)},
 	})
 }
 
-func defaultClientOptions(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) ([]option.ClientOption, error) {
+// getCredentialsData returns the content of the Secret referenced by credentialsRef, or nil if there is none.
+func getCredentialsData(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) ([]byte, error) {
+	if credentialsRef == nil {
+		return nil, nil
+	}
+
+	rawData, err := getCredentialDataFromRef(ctx, credentialsRef, crClient)
+	if err != nil {
+		return nil, fmt.Errorf("getting gcp credentials from reference %s: %w", credentialsRef, err)
+	}
+
+	return rawData, nil
+}
+
+// defaultClientOptions returns the default options of the GCP clients, authenticated with the credentials data,
+// or the credentials of the controller if nil, and the impersonation. The clients are cached across reconciles,
+// so their token sources are not bound to the context of a reconcile.
+func defaultClientOptions(rawData []byte, impersonation *infrav1.ServiceAccountImpersonation) ([]option.ClientOption, error) {
 	opts := []option.ClientOption{
 		option.WithUserAgent(fmt.Sprintf("gcp.cluster.x-k8s.io/%s", version.Get())),
 	}
 
-	if credentialsRef != nil {
-		rawData, err := getCredentialDataFromRef(ctx, credentialsRef, crClient)
+	var credentialsOpts []option.ClientOption
+	if rawData != nil {
+		credentialsOpts = append(credentialsOpts, option.WithCredentialsJSON(rawData))
+	}
+
+	if impersonation != nil {
+		tokenSource, err := impersonatedTokenSource(context.Background(), impersonation, credentialsOpts...)
 		if err != nil {
-			return nil, fmt.Errorf("getting gcp credentials from reference %s: %w", credentialsRef, err)
+			return nil, err
 		}
-		opts = append(opts, option.WithCredentialsJSON(rawData))
+		credentialsOpts = []option.ClientOption{option.WithTokenSource(tokenSource)}
+	}
+
+	return append(opts, credentialsOpts...), nil
+}
+
+// grpcClientOptions returns the default options of the GCP gRPC clients, which record and trace their calls.
+func grpcClientOptions(rawData []byte, impersonation *infrav1.ServiceAccountImpersonation) ([]option.ClientOption, error) {
+	opts, err := defaultClientOptions(rawData, impersonation)
+	if err != nil {
+		return nil, err
 	}
 
-	return opts, nil
+	return append(opts, option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(metrics.UnaryClientInterceptor, tracing.UnaryClientInterceptor))), nil
 }
 
-func newComputeService(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*compute.Service, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newComputeService(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*compute.Service, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		return nil, err
 	}
 
-	computeSvc, err := compute.NewService(ctx, opts...)
+	return cachedClient("compute", credentialsRef, impersonation, rawData, func() (*compute.Service, error) {
+		opts, err := defaultClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		computeSvc, err := compute.NewService(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating new compute service instance: %w", err)
+		}
+
+		return computeSvc, nil
+	})
+}
+
+// newDryRunComputeService returns a compute service which records its mutating calls in the plan instead of
+// sending them.
+func newDryRunComputeService(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client, plan *dryrun.Plan) (*compute.Service, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, fmt.Errorf("creating new compute service instance: %w", err)
+		return nil, err
+	}
+
+	// The authenticated HTTP client is cached, the service is not since its in-memory resources belong to a reconcile.
+	httpClient, err := cachedClient("compute-http", credentialsRef, impersonation, rawData, func() (*http.Client, error) {
+		opts, err := defaultClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		opts = append(opts, option.WithScopes(compute.ComputeScope))
+		httpClient, _, err := htransport.NewClient(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating new compute http client: %w", err)
+		}
+
+		return httpClient, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	computeSvc, err := compute.NewService(ctx, option.WithHTTPClient(&http.Client{Transport: dryrun.NewTransport(httpClient.Transport, plan)}))
+	if err != nil {
+		return nil, fmt.Errorf("creating new dry-run compute service instance: %w", err)
 	}
 
 	return computeSvc, nil
 }
 
-func newClusterManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*container.ClusterManagerClient, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newClusterManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*container.ClusterManagerClient, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		return nil, err
 	}
 
-	managedClusterClient, err := container.NewClusterManagerClient(ctx, opts...)
+	return cachedClient("clustermanager", credentialsRef, impersonation, rawData, func() (*container.ClusterManagerClient, error) {
+		opts, err := grpcClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		managedClusterClient, err := container.NewClusterManagerClient(context.Background(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp cluster manager client: %v", err)
+		}
+
+		return managedClusterClient, nil
+	})
+}
+
+func newIamCredentialsClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*credentials.IamCredentialsClient, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedClient("iamcredentials", credentialsRef, impersonation, rawData, func() (*credentials.IamCredentialsClient, error) {
+		opts, err := grpcClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		credentialsClient, err := credentials.NewIamCredentialsClient(context.Background(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp ciam credentials client: %v", err)
+		}
+
+		return credentialsClient, nil
+	})
+}
+
+func newInstanceGroupManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*computerest.InstanceGroupManagersClient, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, errors.Errorf("failed to create gcp cluster manager client: %v", err)
+		return nil, err
 	}
 
-	return managedClusterClient, nil
+	return cachedClient("instancegroupmanagers", credentialsRef, impersonation, rawData, func() (*computerest.InstanceGroupManagersClient, error) {
+		opts, err := defaultClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		instanceGroupManagersClient, err := computerest.NewInstanceGroupManagersRESTClient(context.Background(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp instance group managers rest client: %v", err)
+		}
+
+		return instanceGroupManagersClient, nil
+	})
 }
 
-func newIamCredentialsClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*credentials.IamCredentialsClient, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newTagBindingsClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client, location string) (*resourcemanager.TagBindingsClient, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		return nil, err
 	}
 
-	credentialsClient, err := credentials.NewIamCredentialsClient(ctx, opts...)
+	return cachedClient("tagbindings/"+location, credentialsRef, impersonation, rawData, func() (*resourcemanager.TagBindingsClient, error) {
+		opts, err := grpcClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+		// The tag bindings of regional and zonal resources are managed by the endpoints of their location.
+		if location != "global" {
+			endpoint := fmt.Sprintf("%s-cloudresourcemanager.googleapis.com:443", location)
+			opts = append(opts, option.WithEndpoint(endpoint))
+		}
+
+		client, err := resourcemanager.NewTagBindingsClient(context.Background(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp tag binding client: %v", err)
+		}
+
+		return client, nil
+	})
+}
+
+// newTagValues returns the lookup of the tag values with the credentials source, whose client is created on first use.
+func newTagValues(source *credentialsSource, crClient client.Client) *shared.TagValues {
+	return shared.NewTagValues(credentialsSourceKey(source.credentialsRef, source.impersonation), func(ctx context.Context) (*resourcemanager.TagValuesClient, error) {
+		return newTagValuesClient(ctx, source.credentialsRef, source.impersonation, crClient)
+	})
+}
+
+func newTagValuesClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*resourcemanager.TagValuesClient, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, errors.Errorf("failed to create gcp ciam credentials client: %v", err)
+		return nil, err
 	}
 
-	return credentialsClient, nil
+	return cachedClient("tagvalues", credentialsRef, impersonation, rawData, func() (*resourcemanager.TagValuesClient, error) {
+		opts, err := grpcClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		client, err := resourcemanager.NewTagValuesClient(context.Background(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp tag values client: %v", err)
+		}
+
+		return client, nil
+	})
 }
 
-func newInstanceGroupManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*computerest.InstanceGroupManagersClient, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newServiceUsageService(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*serviceusage.Service, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		return nil, err
 	}
 
-	instanceGroupManagersClient, err := computerest.NewInstanceGroupManagersRESTClient(ctx, opts...)
+	return cachedClient("serviceusage", credentialsRef, impersonation, rawData, func() (*serviceusage.Service, error) {
+		opts, err := defaultClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		serviceUsageSvc, err := serviceusage.NewService(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating new service usage service instance: %w", err)
+		}
+
+		return serviceUsageSvc, nil
+	})
+}
+
+func newProjectsClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*resourcemanager.ProjectsClient, error) {
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
 	if err != nil {
-		return nil, errors.Errorf("failed to create gcp instance group managers rest client: %v", err)
+		return nil, err
 	}
 
-	return instanceGroupManagersClient, nil
+	return cachedClient("projects", credentialsRef, impersonation, rawData, func() (*resourcemanager.ProjectsClient, error) {
+		opts, err := grpcClientOptions(rawData, impersonation)
+		if err != nil {
+			return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		}
+
+		projectsClient, err := resourcemanager.NewProjectsClient(context.Background(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp projects client: %v", err)
+		}
+
+		return projectsClient, nil
+	})
 }
 
-func newTagBindingsClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, location string) (*resourcemanager.TagBindingsClient, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
-	endpoint := fmt.Sprintf("%s-cloudresourcemanager.googleapis.com:443", location)
-	opts = append(opts, option.WithEndpoint(endpoint))
+// newProjectChecker returns the checker of the state of the project, using the cached clients of the credentials.
+func newProjectChecker(ctx context.Context, project string, computeSvc *compute.Service, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (shared.ProjectChecker, error) {
+	serviceUsageSvc, err := newServiceUsageService(ctx, credentialsRef, impersonation, crClient)
 	if err != nil {
-		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+		return nil, err
 	}
 
-	client, err := resourcemanager.NewTagBindingsClient(ctx, opts...)
+	projectsClient, err := newProjectsClient(ctx, credentialsRef, impersonation, crClient)
 	if err != nil {
-		return nil, errors.Errorf("failed to create gcp tag binding client: %v", err)
+		return nil, err
 	}
 
-	return client, nil
+	return shared.NewProjectChecker(project, computeSvc, serviceUsageSvc, projectsClient), nil
 }
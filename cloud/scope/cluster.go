@@ -20,14 +20,29 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	gcecloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/dryrun"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/operations"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/ratelimit"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/hash"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -38,6 +53,11 @@ type ClusterScopeParams struct {
 	Client     client.Client
 	Cluster    *clusterv1.Cluster
 	GCPCluster *infrav1.GCPCluster
+	// Tracker provides the cached clients of the workload clusters.
+	Tracker *remote.ClusterCacheTracker
+	// DryRun records the changes of the GCP resources in a plan instead of applying them,
+	// and does not patch the GCPCluster.
+	DryRun bool
 }
 
 // NewClusterScope creates a new Scope from the supplied parameters.
@@ -50,8 +70,24 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		return nil, errors.New("failed to generate new scope from nil GCPCluster")
 	}
 
+	source, err := getCredentialsSource(ctx, params.GCPCluster.Namespace, params.GCPCluster.Spec.CredentialsRef, params.GCPCluster.Spec.ServiceAccountImpersonation, params.GCPCluster.Spec.IdentityRef, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("getting gcp credentials source: %w", err)
+	}
+
+	var plan *dryrun.Plan
+	if params.DryRun {
+		plan = dryrun.NewPlan()
+		computeSvc, err := newDryRunComputeService(ctx, source.credentialsRef, source.impersonation, params.Client, plan)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp dry-run compute client: %v", err)
+		}
+
+		params.GCPServices.Compute = computeSvc
+	}
+
 	if params.GCPServices.Compute == nil {
-		computeSvc, err := newComputeService(ctx, params.GCPCluster.Spec.CredentialsRef, params.Client)
+		computeSvc, err := newComputeService(ctx, source.credentialsRef, source.impersonation, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp compute client: %v", err)
 		}
@@ -69,7 +105,10 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		Cluster:     params.Cluster,
 		GCPCluster:  params.GCPCluster,
 		GCPServices: params.GCPServices,
+		credentials: source,
 		patchHelper: helper,
+		plan:        plan,
+		tracker:     params.Tracker,
 	}, nil
 }
 
@@ -77,6 +116,9 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 type ClusterScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	credentials *credentialsSource
+	plan        *dryrun.Plan
+	tracker     *remote.ClusterCacheTracker
 
 	Cluster    *clusterv1.Cluster
 	GCPCluster *infrav1.GCPCluster
@@ -87,7 +129,27 @@ type ClusterScope struct {
 
 // Cloud returns initialized cloud.
 func (s *ClusterScope) Cloud() cloud.Cloud {
-	return newCloud(s.Project(), s.GCPServices)
+	return operations.NewCloud(newCloud(s.Project(), s.GCPServices), s.Compute, s.Project(), s.RateLimiter())
+}
+
+// RateLimiter returns the rate limiter of the calls to the compute API made outside of the cloud wrapper.
+func (s *ClusterScope) RateLimiter() cloud.RateLimiter {
+	return ratelimit.ForProject(s.Project())
+}
+
+// LabelSetter returns the setter of the labels of the compute resources.
+func (s *ClusterScope) LabelSetter() cloud.LabelSetter {
+	return operations.NewLabelSetter(s.Compute, s.Project(), s.RateLimiter())
+}
+
+// InstanceConsole returns the reader of the guest attributes and serial console of the instances.
+func (s *ClusterScope) InstanceConsole() cloud.InstanceConsole {
+	return newInstanceConsole(s.Compute, s.Project(), s.RateLimiter())
+}
+
+// TagValues returns the lookup of the tag values of the resource-manager tags with the credentials of the cluster.
+func (s *ClusterScope) TagValues() cloud.TagValues {
+	return newTagValues(s.credentials, s.client)
 }
 
 // Project returns the current project name.
@@ -115,11 +177,32 @@ func (s *ClusterScope) NetworkName() string {
 	return pointer.StringDeref(s.GCPCluster.Spec.Network.Name, "default")
 }
 
+// ExistingRouter returns the name or the self link of the existing router of the network used by the cluster,
+// empty if CAPG creates the Cloud NAT router.
+func (s *ClusterScope) ExistingRouter() string {
+	return pointer.StringDeref(s.GCPCluster.Spec.Network.Router, "")
+}
+
+// LoadBalancer returns the existing components of the control plane load balancer used by the cluster.
+func (s *ClusterScope) LoadBalancer() *infrav1.LoadBalancerSpec {
+	if s.GCPCluster.Spec.LoadBalancer == nil {
+		return &infrav1.LoadBalancerSpec{}
+	}
+
+	return s.GCPCluster.Spec.LoadBalancer
+}
+
 // NetworkLink returns the partial URL for the network.
 func (s *ClusterScope) NetworkLink() string {
 	return fmt.Sprintf("projects/%s/global/networks/%s", s.Project(), s.NetworkName())
 }
 
+// ExternalResourceGCEnabled returns false if the cluster opted out of the garbage collection
+// of the resources created by its cloud controller manager and CSI driver.
+func (s *ClusterScope) ExternalResourceGCEnabled() bool {
+	return s.GCPCluster.Annotations[infrav1.ExternalResourceGCAnnotation] != "false"
+}
+
 // Network returns the cluster network object.
 func (s *ClusterScope) Network() *infrav1.Network {
 	return &s.GCPCluster.Status.Network
@@ -139,6 +222,64 @@ func (s *ClusterScope) ResourceManagerTags() infrav1.ResourceManagerTags {
 	return s.GCPCluster.Spec.ResourceManagerTags.DeepCopy()
 }
 
+// LabelKeys returns the keys of the labels set by CAPG on the resources of the control plane load balancer.
+func (s *ClusterScope) LabelKeys() []string {
+	return s.GCPCluster.Status.LabelKeys
+}
+
+// SetLabelKeys sets the keys of the labels set by CAPG on the resources of the control plane load balancer.
+func (s *ClusterScope) SetLabelKeys(keys []string) {
+	s.GCPCluster.Status.LabelKeys = keys
+}
+
+// ResourceManagerTagValues returns the tag values bound by CAPG to the resources created for the cluster.
+func (s *ClusterScope) ResourceManagerTagValues() []string {
+	return s.GCPCluster.Status.ResourceManagerTagValues
+}
+
+// SetResourceManagerTagValues sets the tag values bound by CAPG to the resources created for the cluster.
+func (s *ClusterScope) SetResourceManagerTagValues(tagValues []string) {
+	s.GCPCluster.Status.ResourceManagerTagValues = tagValues
+}
+
+// ResourceName returns the name of a GCE resource of the cluster made of the parts, see resourceName.
+func (s *ClusterScope) ResourceName(parts ...string) string {
+	return resourceName(s.Namespace(), namespacedResourceNames(s.GCPCluster), parts...)
+}
+
+// IsOrphanOnDelete reports whether the GCP resources of the cluster are left behind when it is deleted.
+func (s *ClusterScope) IsOrphanOnDelete() bool {
+	return reconciler.IsOrphanOnDelete(s.GCPCluster)
+}
+
+// DefaultResourceNaming records the naming scheme of the GCE resources of the cluster when it is first reconciled.
+// It must be called before the finalizer is added.
+func (s *ClusterScope) DefaultResourceNaming() {
+	defaultResourceNaming(s.GCPCluster, infrav1.ClusterFinalizer)
+}
+
+// Labels returns the labels of the GCE resources of the cluster with the role: the ownership labels and the
+// additional labels of the cluster.
+func (s *ClusterScope) Labels(role string) infrav1.Labels {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ResourceName(s.Name()),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Role:        pointer.String(role),
+		Additional:  s.AdditionalLabels(),
+	})
+}
+
+// SharedLabels returns the labels of the existing GCE resources used by the cluster with the role: the shared
+// ownership labels and the additional labels of the cluster.
+func (s *ClusterScope) SharedLabels(role string) infrav1.Labels {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ResourceName(s.Name()),
+		Lifecycle:   infrav1.ResourceLifecycleShared,
+		Role:        pointer.String(role),
+		Additional:  s.AdditionalLabels(),
+	})
+}
+
 // ControlPlaneEndpoint returns the cluster control-plane endpoint.
 func (s *ClusterScope) ControlPlaneEndpoint() clusterv1.APIEndpoint {
 	endpoint := s.GCPCluster.Spec.ControlPlaneEndpoint
@@ -154,6 +295,52 @@ func (s *ClusterScope) FailureDomains() clusterv1.FailureDomains {
 	return s.GCPCluster.Status.FailureDomains
 }
 
+// FailureDomainSpecs returns the failure domains selected in the spec, from FailureDomainSpecs or the
+// zones listed in FailureDomains. It is empty when all the zones of the region are used.
+func (s *ClusterScope) FailureDomainSpecs() []infrav1.FailureDomainSpec {
+	if len(s.GCPCluster.Spec.FailureDomainSpecs) > 0 {
+		return s.GCPCluster.Spec.FailureDomainSpecs
+	}
+
+	specs := make([]infrav1.FailureDomainSpec, 0, len(s.GCPCluster.Spec.FailureDomains))
+	for _, zone := range s.GCPCluster.Spec.FailureDomains {
+		specs = append(specs, infrav1.FailureDomainSpec{Zone: zone})
+	}
+
+	return specs
+}
+
+// PendingOperations returns the GCE operations started for the cluster that are not done yet.
+func (s *ClusterScope) PendingOperations() *[]infrav1.Operation {
+	return &s.GCPCluster.Status.PendingOperations
+}
+
+// PollOperations refreshes the given pending operations and removes the ones that are done.
+// It reports whether some operations are still running.
+func (s *ClusterScope) PollOperations(ctx context.Context, pending *[]infrav1.Operation) (bool, error) {
+	return operations.Poll(ctx, s.Compute, s.RateLimiter(), s.Project(), pending)
+}
+
+// ZoneMachineSeries returns the sorted machine series, e.g. n2, available in the given zone.
+// It lists all the machine types of the zone, so callers should reuse the result.
+func (s *ClusterScope) ZoneMachineSeries(ctx context.Context, zone string) ([]string, error) {
+	key := &gcecloud.RateLimitKey{ProjectID: s.Project(), Operation: "List", Version: meta.VersionGA, Service: "MachineTypes"}
+	series := sets.New[string]()
+	err := observer.Call(ctx, s.RateLimiter(), key, func() error {
+		return s.Compute.MachineTypes.List(s.Project(), zone).Pages(ctx, func(page *compute.MachineTypeList) error {
+			for _, machineType := range page.Items {
+				series.Insert(strings.SplitN(machineType.Name, "-", 2)[0])
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sets.List(series), nil
+}
+
 // ANCHOR_END: ClusterGetter
 
 // ANCHOR: ClusterSetter
@@ -182,7 +369,7 @@ func (s *ClusterScope) NetworkSpec() *compute.Network {
 	createSubnet := pointer.BoolDeref(s.GCPCluster.Spec.Network.AutoCreateSubnetworks, true)
 	network := &compute.Network{
 		Name:                  s.NetworkName(),
-		Description:           infrav1.ClusterTagKey(s.Name()),
+		Description:           infrav1.ClusterTagKey(s.ResourceName(s.Name())),
 		AutoCreateSubnetworks: createSubnet,
 		ForceSendFields:       []string{"AutoCreateSubnetworks"},
 	}
@@ -194,10 +381,10 @@ func (s *ClusterScope) NetworkSpec() *compute.Network {
 func (s *ClusterScope) NatRouterSpec() *compute.Router {
 	networkSpec := s.NetworkSpec()
 	return &compute.Router{
-		Name: fmt.Sprintf("%s-%s", networkSpec.Name, "router"),
+		Name: s.ResourceName(networkSpec.Name, "router"),
 		Nats: []*compute.RouterNat{
 			{
-				Name:                          fmt.Sprintf("%s-%s", networkSpec.Name, "nat"),
+				Name:                          s.ResourceName(networkSpec.Name, "nat"),
 				NatIpAllocateOption:           "AUTO_ONLY",
 				SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
 			},
@@ -222,7 +409,7 @@ func (s *ClusterScope) SubnetSpecs() []*compute.Subnetwork {
 			PrivateIpGoogleAccess: pointer.BoolDeref(subnetwork.PrivateGoogleAccess, false),
 			IpCidrRange:           subnetwork.CidrBlock,
 			SecondaryIpRanges:     secondaryIPRanges,
-			Description:           pointer.StringDeref(subnetwork.Description, infrav1.ClusterTagKey(s.Name())),
+			Description:           pointer.StringDeref(subnetwork.Description, infrav1.ClusterTagKey(s.ResourceName(s.Name()))),
 			Network:               s.NetworkLink(),
 			Purpose:               pointer.StringDeref(subnetwork.Purpose, "PRIVATE_RFC_1918"),
 			Role:                  "ACTIVE",
@@ -232,13 +419,88 @@ func (s *ClusterScope) SubnetSpecs() []*compute.Subnetwork {
 	return subnets
 }
 
+// ANCHOR: ClusterRouteSpec
+
+// RouteSpecs returns google compute route spec for the user-declared static routes.
+func (s *ClusterScope) RouteSpecs() []*compute.Route {
+	routes := []*compute.Route{}
+	if s.GCPCluster.Spec.Network.Routes == nil {
+		return routes
+	}
+
+	for _, route := range s.GCPCluster.Spec.Network.Routes.StaticRoutes {
+		routes = append(routes, &compute.Route{
+			Name:            s.ResourceName(s.Name(), route.Name),
+			Network:         s.NetworkLink(),
+			DestRange:       route.DestRange,
+			Description:     infrav1.ClusterTagKey(s.ResourceName(s.Name())),
+			Priority:        pointer.Int64Deref(route.Priority, 1000),
+			Tags:            route.Tags,
+			NextHopInstance: pointer.StringDeref(route.NextHopInstance, ""),
+			NextHopIp:       pointer.StringDeref(route.NextHopIP, ""),
+			NextHopGateway:  pointer.StringDeref(route.NextHopGateway, ""),
+			ForceSendFields: []string{"Priority"},
+		})
+	}
+
+	return routes
+}
+
+// PodCIDRRoutesEnabled returns true if a route should be managed for the pod CIDR of every workload cluster Node.
+func (s *ClusterScope) PodCIDRRoutesEnabled() bool {
+	return s.GCPCluster.Spec.Network.Routes != nil && s.GCPCluster.Spec.Network.Routes.PodCIDRRoutes
+}
+
+// podCIDRRouteHashLength is the length of the hash identifying the pod CIDR routes of the Nodes.
+const podCIDRRouteHashLength = 16
+
+// PodCIDRRoutePrefix returns the name prefix shared by all the pod CIDR routes of the cluster.
+func (s *ClusterScope) PodCIDRRoutePrefix() string {
+	return resourceNameWithin(maxResourceNameLength-podCIDRRouteHashLength-1, s.Namespace(), namespacedResourceNames(s.GCPCluster), s.Name(), "podcidr") + "-"
+}
+
+// PodCIDRRouteSpec returns google compute route spec sending the given pod CIDR of a Node to its instance.
+func (s *ClusterScope) PodCIDRRouteSpec(nodeName, podCIDR, instance string) *compute.Route {
+	// Node names and CIDRs do not fit into a route name, use a stable hash of both instead.
+	suffix, _ := hash.Base36TruncatedHash(nodeName+"/"+podCIDR, podCIDRRouteHashLength)
+	return &compute.Route{
+		Name:            s.PodCIDRRoutePrefix() + suffix,
+		Network:         s.NetworkLink(),
+		DestRange:       podCIDR,
+		Description:     infrav1.ClusterTagKey(s.ResourceName(s.Name())),
+		Priority:        1000,
+		NextHopInstance: instance,
+	}
+}
+
+// WorkloadNodes returns the Nodes of the workload cluster, read from the cache of the tracker.
+func (s *ClusterScope) WorkloadNodes(ctx context.Context) ([]corev1.Node, error) {
+	if s.tracker == nil {
+		return nil, errors.New("no workload cluster tracker")
+	}
+
+	workloadClient, err := s.tracker.GetClient(ctx, client.ObjectKeyFromObject(s.Cluster))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workload cluster client")
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := workloadClient.List(ctx, nodes); err != nil {
+		return nil, errors.Wrap(err, "failed to list workload cluster nodes")
+	}
+
+	return nodes.Items, nil
+}
+
+// ANCHOR_END: ClusterRouteSpec
+
 // ANCHOR: ClusterFirewallSpec
 
 // FirewallRulesSpec returns google compute firewall spec.
 func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 	firewallRules := []*compute.Firewall{
 		{
-			Name:    fmt.Sprintf("allow-%s-healthchecks", s.Name()),
+			Name:    s.ResourceName("allow", s.Name(), "healthchecks"),
 			Network: s.NetworkLink(),
 			Allowed: []*compute.FirewallAllowed{
 				{
@@ -254,11 +516,11 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 				"130.211.0.0/22",
 			},
 			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
+				s.ResourceName(s.Name(), "control-plane"),
 			},
 		},
 		{
-			Name:    fmt.Sprintf("allow-%s-cluster", s.Name()),
+			Name:    s.ResourceName("allow", s.Name(), "cluster"),
 			Network: s.NetworkLink(),
 			Allowed: []*compute.FirewallAllowed{
 				{
@@ -267,12 +529,12 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 			},
 			Direction: "INGRESS",
 			SourceTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
+				s.ResourceName(s.Name(), "control-plane"),
+				s.ResourceName(s.Name(), "node"),
 			},
 			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
+				s.ResourceName(s.Name(), "control-plane"),
+				s.ResourceName(s.Name(), "node"),
 			},
 		},
 	}
@@ -280,6 +542,83 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 	return firewallRules
 }
 
+// FirewallPolicy returns the network firewall policy configuration of the cluster,
+// nil if the cluster uses VPC firewall rules.
+func (s *ClusterScope) FirewallPolicy() *infrav1.FirewallPolicySpec {
+	return s.GCPCluster.Spec.Network.FirewallPolicy
+}
+
+// FirewallPolicySpec returns google compute network firewall policy spec.
+func (s *ClusterScope) FirewallPolicySpec() *compute.FirewallPolicy {
+	return &compute.FirewallPolicy{
+		Name:        s.ResourceName(s.Name(), "firewall-policy"),
+		Description: infrav1.ClusterTagKey(s.ResourceName(s.Name())),
+	}
+}
+
+// FirewallPolicyAssociationSpec returns google compute network firewall policy association spec.
+func (s *ClusterScope) FirewallPolicyAssociationSpec() *compute.FirewallPolicyAssociation {
+	return &compute.FirewallPolicyAssociation{
+		Name:             s.ResourceName(s.Name(), s.NetworkName()),
+		AttachmentTarget: s.NetworkLink(),
+	}
+}
+
+// FirewallPolicyRulesSpec returns google compute network firewall policy rules spec
+// targeting the given control plane and node secure tag values.
+func (s *ClusterScope) FirewallPolicyRulesSpec(controlPlaneTag, nodeTag string) []*compute.FirewallPolicyRule {
+	clusterTags := []*compute.FirewallPolicyRuleSecureTag{
+		{Name: controlPlaneTag},
+		{Name: nodeTag},
+	}
+
+	return []*compute.FirewallPolicyRule{
+		{
+			RuleName:  s.ResourceName("allow", s.Name(), "healthchecks"),
+			Priority:  1000,
+			Direction: "INGRESS",
+			Action:    "allow",
+			Match: &compute.FirewallPolicyRuleMatcher{
+				Layer4Configs: []*compute.FirewallPolicyRuleMatcherLayer4Config{
+					{
+						IpProtocol: "tcp",
+						Ports: []string{
+							strconv.FormatInt(6443, 10),
+						},
+					},
+				},
+				SrcIpRanges: []string{
+					"35.191.0.0/16",
+					"130.211.0.0/22",
+				},
+			},
+			TargetSecureTags: []*compute.FirewallPolicyRuleSecureTag{
+				{Name: controlPlaneTag},
+			},
+		},
+		{
+			RuleName:  s.ResourceName("allow", s.Name(), "cluster"),
+			Priority:  1001,
+			Direction: "INGRESS",
+			Action:    "allow",
+			Match: &compute.FirewallPolicyRuleMatcher{
+				Layer4Configs: []*compute.FirewallPolicyRuleMatcherLayer4Config{
+					{
+						IpProtocol: "all",
+					},
+				},
+				SrcSecureTags: clusterTags,
+			},
+			TargetSecureTags: clusterTags,
+		},
+	}
+}
+
+// ComputeService returns the google compute service used by the scope.
+func (s *ClusterScope) ComputeService() *compute.Service {
+	return s.Compute
+}
+
 // ANCHOR_END: ClusterFirewallSpec
 
 // ANCHOR: ClusterControlPlaneSpec
@@ -287,16 +626,18 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 // AddressSpec returns google compute address spec.
 func (s *ClusterScope) AddressSpec() *compute.Address {
 	return &compute.Address{
-		Name:        fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+		Name:        s.ResourceName(s.Name(), infrav1.APIServerRoleTagValue),
 		AddressType: "EXTERNAL",
 		IpVersion:   "IPV4",
+		Labels:      s.Labels(infrav1.APIServerRoleTagValue),
 	}
 }
 
 // BackendServiceSpec returns google compute backend-service spec.
 func (s *ClusterScope) BackendServiceSpec() *compute.BackendService {
 	return &compute.BackendService{
-		Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+		Name:                s.ResourceName(s.Name(), infrav1.APIServerRoleTagValue),
+		Description:         infrav1.ClusterTagKey(s.ResourceName(s.Name())),
 		LoadBalancingScheme: "EXTERNAL",
 		PortName:            "apiserver",
 		Protocol:            "TCP",
@@ -312,18 +653,20 @@ func (s *ClusterScope) ForwardingRuleSpec() *compute.ForwardingRule {
 	}
 	portRange := fmt.Sprintf("%d-%d", port, port)
 	return &compute.ForwardingRule{
-		Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+		Name:                s.ResourceName(s.Name(), infrav1.APIServerRoleTagValue),
 		IPProtocol:          "TCP",
 		LoadBalancingScheme: "EXTERNAL",
 		PortRange:           portRange,
+		Labels:              s.Labels(infrav1.APIServerRoleTagValue),
 	}
 }
 
 // HealthCheckSpec returns google compute health-check spec.
 func (s *ClusterScope) HealthCheckSpec() *compute.HealthCheck {
 	return &compute.HealthCheck{
-		Name: fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
-		Type: "HTTPS",
+		Name:        s.ResourceName(s.Name(), infrav1.APIServerRoleTagValue),
+		Description: infrav1.ClusterTagKey(s.ResourceName(s.Name())),
+		Type:        "HTTPS",
 		HttpsHealthCheck: &compute.HTTPSHealthCheck{
 			Port:              6443,
 			PortSpecification: "USE_FIXED_PORT",
@@ -340,7 +683,8 @@ func (s *ClusterScope) HealthCheckSpec() *compute.HealthCheck {
 func (s *ClusterScope) InstanceGroupSpec(zone string) *compute.InstanceGroup {
 	port := pointer.Int32Deref(s.GCPCluster.Spec.Network.LoadBalancerBackendPort, 6443)
 	return &compute.InstanceGroup{
-		Name: fmt.Sprintf("%s-%s-%s", s.Name(), infrav1.APIServerRoleTagValue, zone),
+		Name:        s.ResourceName(s.Name(), infrav1.APIServerRoleTagValue, zone),
+		Description: infrav1.ClusterTagKey(s.ResourceName(s.Name())),
 		NamedPorts: []*compute.NamedPort{
 			{
 				Name: "apiserver",
@@ -353,15 +697,54 @@ func (s *ClusterScope) InstanceGroupSpec(zone string) *compute.InstanceGroup {
 // TargetTCPProxySpec returns google compute target-tcp-proxy spec.
 func (s *ClusterScope) TargetTCPProxySpec() *compute.TargetTcpProxy {
 	return &compute.TargetTcpProxy{
-		Name:        fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+		Name:        s.ResourceName(s.Name(), infrav1.APIServerRoleTagValue),
+		Description: infrav1.ClusterTagKey(s.ResourceName(s.Name())),
 		ProxyHeader: "NONE",
 	}
 }
 
 // ANCHOR_END: ClusterControlPlaneSpec
 
-// PatchObject persists the cluster configuration and status.
+// TagBindingsClient returns the client of the tag bindings of the resources in the location, a zone, a region or global.
+func (s *ClusterScope) TagBindingsClient(ctx context.Context, location string) (*resourcemanager.TagBindingsClient, error) {
+	return newTagBindingsClient(ctx, s.credentials.credentialsRef, s.credentials.impersonation, s.client, location)
+}
+
+// TagBindings returns the tag bindings of the resources in the location, a zone, a region or global.
+func (s *ClusterScope) TagBindings(ctx context.Context, location string) (cloud.TagBindings, error) {
+	client, err := s.TagBindingsClient(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.plan != nil {
+		return dryrun.NewTagBindings(shared.NewTagBindings(client), s.plan), nil
+	}
+
+	return shared.NewTagBindings(client), nil
+}
+
+// ConditionSetter returns the GCPCluster, which holds the conditions of the cluster.
+func (s *ClusterScope) ConditionSetter() conditions.Setter {
+	return s.GCPCluster
+}
+
+// Plan returns the plan recording the changes of the GCP resources, nil if the scope is not a dry run.
+func (s *ClusterScope) Plan() *dryrun.Plan {
+	return s.plan
+}
+
+// ValidateCredentials checks that the credentials of the cluster can authenticate with GCP.
+func (s *ClusterScope) ValidateCredentials(ctx context.Context) error {
+	return validateCredentials(ctx, s.credentials, s.client)
+}
+
+// PatchObject persists the cluster configuration and status, unless the scope is a dry run.
 func (s *ClusterScope) PatchObject() error {
+	if s.plan != nil {
+		return nil
+	}
+
 	return s.patchHelper.Patch(context.TODO(), s.GCPCluster)
 }
 
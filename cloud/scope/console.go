@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
+)
+
+// instanceConsole implements cloud.InstanceConsole with the compute service, sharing the rate limiter
+// of the project.
+type instanceConsole struct {
+	service     *compute.Service
+	project     string
+	rateLimiter cloud.RateLimiter
+}
+
+func newInstanceConsole(service *compute.Service, project string, rateLimiter cloud.RateLimiter) *instanceConsole {
+	return &instanceConsole{service: service, project: project, rateLimiter: rateLimiter}
+}
+
+func (c *instanceConsole) key(operation string) *cloud.RateLimitKey {
+	return &cloud.RateLimitKey{ProjectID: c.project, Operation: operation, Version: meta.VersionGA, Service: "Instances"}
+}
+
+// GetGuestAttributes returns the guest attributes of an instance under the query path, e.g. namespace/key.
+func (c *instanceConsole) GetGuestAttributes(ctx context.Context, key *meta.Key, queryPath string) (*compute.GuestAttributes, error) {
+	var attributes *compute.GuestAttributes
+	err := observer.Call(ctx, c.rateLimiter, c.key("GetGuestAttributes"), func() (err error) {
+		attributes, err = c.service.Instances.GetGuestAttributes(c.project, key.Zone, key.Name).QueryPath(queryPath).Context(ctx).Do()
+		return err
+	})
+	return attributes, err
+}
+
+// GetSerialPortOutput returns the output of the first serial port of an instance from the start offset.
+func (c *instanceConsole) GetSerialPortOutput(ctx context.Context, key *meta.Key, start int64) (*compute.SerialPortOutput, error) {
+	var output *compute.SerialPortOutput
+	err := observer.Call(ctx, c.rateLimiter, c.key("GetSerialPortOutput"), func() (err error) {
+		output, err = c.service.Instances.GetSerialPortOutput(c.project, key.Zone, key.Name).Start(start).Context(ctx).Do()
+		return err
+	})
+	return output, err
+}
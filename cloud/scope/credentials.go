@@ -20,9 +20,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
 
+	"cloud.google.com/go/compute/metadata"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
@@ -33,30 +42,137 @@ const (
 	// ConfigFileEnvVar is the name of the environment variable
 	// that contains the path to the credentials file.
 	ConfigFileEnvVar = "GOOGLE_APPLICATION_CREDENTIALS"
+
+	// cloudPlatformScope is the OAuth scope requested for the access tokens of the credentials.
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+	// ServiceAccountCredential is the type of the service account key credentials.
+	ServiceAccountCredential = "service_account"
+	// ExternalAccountCredential is the type of the workload identity federation credentials.
+	ExternalAccountCredential = "external_account"
+	// MetadataCredential is the type of the credentials of the metadata server, e.g. the
+	// service account of a GCE instance or of a GKE workload identity.
+	MetadataCredential = "metadata"
 )
 
+// tokenInfoURL is the endpoint describing the identity of an access token.
+var tokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// tokenInfoClient is the HTTP client of the calls to tokenInfoURL.
+var tokenInfoClient = &http.Client{Timeout: 30 * time.Second}
+
+// impersonationURLRegexp matches the service account impersonation URL of external_account credentials,
+// e.g. https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/sa@my-proj.iam.gserviceaccount.com:generateAccessToken.
+var impersonationURLRegexp = regexp.MustCompile(`/serviceAccounts/([^/:]+):generateAccessToken$`)
+
 // Credential is a struct to hold GCP credential data.
 type Credential struct {
 	Type        string `json:"type"`
 	ProjectID   string `json:"project_id"`
 	ClientEmail string `json:"client_email"`
 	ClientID    string `json:"client_id"`
+	// ServiceAccountImpersonationURL is the URL external_account credentials use to impersonate a service account.
+	ServiceAccountImpersonationURL string `json:"service_account_impersonation_url"`
+
+	// TokenSource is the source of the access tokens of the credential.
+	TokenSource oauth2.TokenSource `json:"-"`
+	// Impersonated is true when TokenSource returns the access tokens of the impersonated ClientEmail.
+	Impersonated bool `json:"-"`
 }
 
-func getCredentials(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*Credential, error) {
-	var credentialData []byte
-	var err error
+func getCredentials(ctx context.Context, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, crClient client.Client) (*Credential, error) {
+	var credential *Credential
+	rawData, err := getCredentialsData(ctx, credentialsRef, crClient)
+	if err == nil {
+		if rawData != nil {
+			credential, err = getCredentialFromData(ctx, rawData)
+		} else {
+			credential, err = getCredentialUsingADC(ctx)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting credential data: %w", err)
+	}
+
+	if impersonation != nil {
+		credential.TokenSource, err = impersonatedTokenSource(ctx, impersonation, option.WithTokenSource(credential.TokenSource))
+		if err != nil {
+			return nil, err
+		}
+		credential.ClientEmail = impersonation.TargetServiceAccount
+		credential.Impersonated = true
+	}
+
+	if credential.ClientEmail == "" {
+		// The email is cached with the clients of the credentials, so it is only resolved again when they change.
+		credential.ClientEmail, err = cachedClient("serviceaccountemail", credentialsRef, impersonation, rawData, func() (string, error) {
+			return resolveServiceAccountEmail(ctx, credential)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("resolving service account of credentials: %w", err)
+		}
+	}
+
+	return credential, nil
+}
+
+// validateCredentials checks that the credentials of the source can get an access token. The token sources are
+// cached by the content of the credentials and reuse their tokens until they expire, so the credentials are
+// only authenticated again when they change or their token is refreshed.
+func validateCredentials(ctx context.Context, source *credentialsSource, crClient client.Client) error {
+	rawData, err := getCredentialsData(ctx, source.credentialsRef, crClient)
+	if err != nil {
+		return err
+	}
 
-	if credentialsRef != nil {
-		credentialData, err = getCredentialDataFromRef(ctx, credentialsRef, crClient)
+	tokenSource, err := cachedClient("tokensource", source.credentialsRef, source.impersonation, rawData, func() (oauth2.TokenSource, error) {
+		return newTokenSource(rawData, source.impersonation)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := tokenSource.Token(); err != nil {
+		return fmt.Errorf("getting access token: %w", err)
+	}
+
+	return nil
+}
+
+// newTokenSource returns the source of the access tokens of the credentials data, or of the credentials of the
+// controller if nil, and the impersonation. The token source is not bound to the context of a reconcile.
+func newTokenSource(rawData []byte, impersonation *infrav1.ServiceAccountImpersonation) (oauth2.TokenSource, error) {
+	var creds *google.Credentials
+	var err error
+	if rawData != nil {
+		creds, err = google.CredentialsFromJSON(context.Background(), rawData, cloudPlatformScope)
 	} else {
-		credentialData, err = getCredentialDataUsingADC()
+		creds, err = google.FindDefaultCredentials(context.Background(), cloudPlatformScope)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("getting credential data: %w", err)
+		return nil, fmt.Errorf("loading credentials: %w", err)
+	}
+
+	if impersonation == nil {
+		return creds.TokenSource, nil
+	}
+
+	return impersonatedTokenSource(context.Background(), impersonation, option.WithTokenSource(creds.TokenSource))
+}
+
+func getCredentialFromData(ctx context.Context, rawData []byte) (*Credential, error) {
+	credential, err := parseCredential(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := google.CredentialsFromJSON(ctx, rawData, cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("loading credentials: %w", err)
 	}
+	credential.TokenSource = creds.TokenSource
 
-	return parseCredential(credentialData)
+	return credential, nil
 }
 
 func getCredentialDataFromRef(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) ([]byte, error) {
@@ -78,17 +194,28 @@ func getCredentialDataFromRef(ctx context.Context, credentialsRef *infrav1.Objec
 	return rawData, nil
 }
 
-func getCredentialDataUsingADC() ([]byte, error) {
-	credsPath := os.Getenv(ConfigFileEnvVar)
-	if credsPath == "" {
-		return nil, fmt.Errorf("no ADC environment variable found for credentials (expect %s)", ConfigFileEnvVar)
+// getCredentialUsingADC returns the Application Default Credentials: the file referenced by
+// the ConfigFileEnvVar environment variable, the gcloud credentials or the metadata server.
+func getCredentialUsingADC(ctx context.Context) (*Credential, error) {
+	creds, err := google.FindDefaultCredentials(ctx, cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("finding default credentials: %w", err)
 	}
 
-	byteValue, err := os.ReadFile(credsPath) //nolint:gosec // We need to read a file here
-	if err != nil {
-		return nil, fmt.Errorf("reading credentials from file %s: %w", credsPath, err)
+	credential := &Credential{Type: MetadataCredential}
+	if len(creds.JSON) > 0 {
+		credential, err = parseCredential(creds.JSON)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if credential.ProjectID == "" {
+		credential.ProjectID = creds.ProjectID
 	}
-	return byteValue, nil
+	credential.TokenSource = creds.TokenSource
+
+	return credential, nil
 }
 
 func parseCredential(rawData []byte) (*Credential, error) {
@@ -99,3 +226,86 @@ func parseCredential(rawData []byte) (*Credential, error) {
 	}
 	return &credential, nil
 }
+
+// impersonatedTokenSource returns a source of access tokens of the target service account of impersonation,
+// impersonated with the credentials of the given client options.
+func impersonatedTokenSource(ctx context.Context, impersonation *infrav1.ServiceAccountImpersonation, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+	tokenSource, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: impersonation.TargetServiceAccount,
+		Delegates:       impersonation.Delegates,
+		Scopes:          []string{cloudPlatformScope},
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("impersonating service account %s: %w", impersonation.TargetServiceAccount, err)
+	}
+
+	return tokenSource, nil
+}
+
+// resolveServiceAccountEmail returns the email of the service account whose identity is used by external_account
+// and metadata credentials, or an empty string if they do not act as a service account, e.g. federated identities
+// granted access to the resources directly, or for the other types of credentials.
+func resolveServiceAccountEmail(ctx context.Context, credential *Credential) (string, error) {
+	if credential.Type != ExternalAccountCredential && credential.Type != MetadataCredential {
+		return "", nil
+	}
+
+	if credential.ServiceAccountImpersonationURL != "" {
+		match := impersonationURLRegexp.FindStringSubmatch(credential.ServiceAccountImpersonationURL)
+		if match == nil {
+			return "", errors.Errorf("unexpected service account impersonation URL %q", credential.ServiceAccountImpersonationURL)
+		}
+
+		return url.PathUnescape(match[1])
+	}
+
+	if credential.Type == MetadataCredential && metadata.OnGCE() {
+		email, err := metadata.Email("default")
+		if err != nil {
+			return "", fmt.Errorf("getting service account from metadata server: %w", err)
+		}
+
+		return email, nil
+	}
+
+	if credential.TokenSource == nil {
+		return "", nil
+	}
+
+	return tokenEmail(ctx, credential.TokenSource)
+}
+
+// tokenEmail returns the email of the identity of the access tokens of a token source, if any.
+func tokenEmail(ctx context.Context, tokenSource oauth2.TokenSource) (string, error) {
+	token, err := tokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("getting access token: %w", err)
+	}
+
+	// The token is sent in the body, so it does not end up in the logs of the URLs of proxies.
+	body := url.Values{"access_token": {token.AccessToken}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenInfoURL, strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := tokenInfoClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("getting access token info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("getting access token info: unexpected status %s", resp.Status)
+	}
+
+	var info struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("decoding access token info: %w", err)
+	}
+
+	return info.Email, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestResolveServiceAccountEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Query().Has("access_token") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.PostFormValue("access_token") == "federated-token" {
+			_, _ = w.Write([]byte(`{"azp": "federated"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"email": "capg@my-proj.iam.gserviceaccount.com"}`))
+	}))
+	defer server.Close()
+
+	defaultTokenInfoURL := tokenInfoURL
+	tokenInfoURL = server.URL
+	defer func() { tokenInfoURL = defaultTokenInfoURL }()
+
+	tests := []struct {
+		name       string
+		credential *Credential
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "external account impersonating a service account",
+			credential: &Credential{
+				Type:                           ExternalAccountCredential,
+				ServiceAccountImpersonationURL: "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/capg@my-proj.iam.gserviceaccount.com:generateAccessToken",
+			},
+			want: "capg@my-proj.iam.gserviceaccount.com",
+		},
+		{
+			name: "invalid impersonation URL",
+			credential: &Credential{
+				Type:                           ExternalAccountCredential,
+				ServiceAccountImpersonationURL: "https://example.com/token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "service account of the access token",
+			credential: &Credential{
+				Type:        ExternalAccountCredential,
+				TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "service-account-token"}),
+			},
+			want: "capg@my-proj.iam.gserviceaccount.com",
+		},
+		{
+			name: "federated identity without service account",
+			credential: &Credential{
+				Type:        ExternalAccountCredential,
+				TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "federated-token"}),
+			},
+		},
+		{
+			name: "user credentials",
+			credential: &Credential{
+				Type:        "authorized_user",
+				TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "user-token"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveServiceAccountEmail(context.TODO(), tt.credential)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveServiceAccountEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("resolveServiceAccountEmail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
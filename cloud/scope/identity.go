@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// credentialsSource is the source of the credentials of a cluster.
+type credentialsSource struct {
+	credentialsRef *infrav1.ObjectReference
+	impersonation  *infrav1.ServiceAccountImpersonation
+}
+
+// getCredentialsSource returns the source of the credentials of a cluster in the namespace, resolving the
+// identity it references. It fails if the identity does not allow the namespace of the cluster.
+func getCredentialsSource(ctx context.Context, namespace string, credentialsRef *infrav1.ObjectReference, impersonation *infrav1.ServiceAccountImpersonation, identityRef *infrav1.GCPIdentityReference, crClient client.Client) (*credentialsSource, error) {
+	if identityRef == nil {
+		// Only identities can share credentials across namespaces. New clusters are rejected by the webhooks,
+		// the clusters created before keep using their credentials until they are moved to an identity.
+		if credentialsRef != nil && credentialsRef.Namespace != namespace {
+			log.FromContext(ctx).Info("Deprecated: the credentials secret is not in the namespace of the cluster, use an identityRef instead",
+				"secret", credentialsRef.Namespace+"/"+credentialsRef.Name)
+		}
+
+		return &credentialsSource{credentialsRef: credentialsRef, impersonation: impersonation}, nil
+	}
+
+	var (
+		spec   infrav1.GCPClusterIdentitySpec
+		source credentialsSource
+	)
+	key := types.NamespacedName{Name: identityRef.Name}
+	switch identityRef.Kind {
+	case infrav1.GCPClusterStaticIdentityKind:
+		identity := &infrav1.GCPClusterStaticIdentity{}
+		if err := crClient.Get(ctx, key, identity); err != nil {
+			return nil, fmt.Errorf("getting %s %s: %w", identityRef.Kind, identityRef.Name, err)
+		}
+		spec = identity.Spec.GCPClusterIdentitySpec
+		source.credentialsRef = &identity.Spec.SecretRef
+	case infrav1.GCPClusterImpersonationIdentityKind:
+		identity := &infrav1.GCPClusterImpersonationIdentity{}
+		if err := crClient.Get(ctx, key, identity); err != nil {
+			return nil, fmt.Errorf("getting %s %s: %w", identityRef.Kind, identityRef.Name, err)
+		}
+		spec = identity.Spec.GCPClusterIdentitySpec
+		source.credentialsRef = identity.Spec.SecretRef
+		source.impersonation = &identity.Spec.ServiceAccountImpersonation
+	default:
+		return nil, errors.Errorf("unsupported identity kind %q", identityRef.Kind)
+	}
+
+	allowed, err := isNamespaceAllowed(ctx, namespace, spec.AllowedNamespaces, crClient)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.Errorf("namespace %q is not allowed to use %s %s", namespace, identityRef.Kind, identityRef.Name)
+	}
+
+	return &source, nil
+}
+
+// isNamespaceAllowed returns whether the namespace is listed or selected by the allowed namespaces.
+func isNamespaceAllowed(ctx context.Context, namespace string, allowedNamespaces *infrav1.AllowedNamespaces, crClient client.Client) (bool, error) {
+	if allowedNamespaces == nil {
+		return false, nil
+	}
+
+	for _, name := range allowedNamespaces.NamespaceList {
+		if name == namespace {
+			return true, nil
+		}
+	}
+
+	if allowedNamespaces.Selector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(allowedNamespaces.Selector)
+	if err != nil {
+		return false, fmt.Errorf("parsing allowed namespaces selector: %w", err)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := crClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, fmt.Errorf("getting namespace %s: %w", namespace, err)
+	}
+
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
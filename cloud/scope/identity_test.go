@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetCredentialsSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+
+	secretRef := infrav1.ObjectReference{Namespace: "capg-system", Name: "tenant-credentials"}
+	impersonation := infrav1.ServiceAccountImpersonation{TargetServiceAccount: "capg@tenant-project.iam.gserviceaccount.com"}
+	crClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tenant": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&infrav1.GCPClusterStaticIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "listed"},
+			Spec: infrav1.GCPClusterStaticIdentitySpec{
+				GCPClusterIdentitySpec: infrav1.GCPClusterIdentitySpec{
+					AllowedNamespaces: &infrav1.AllowedNamespaces{NamespaceList: []string{"team-a"}},
+				},
+				SecretRef: secretRef,
+			},
+		},
+		&infrav1.GCPClusterStaticIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "unset"},
+			Spec:       infrav1.GCPClusterStaticIdentitySpec{SecretRef: secretRef},
+		},
+		&infrav1.GCPClusterImpersonationIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "selected"},
+			Spec: infrav1.GCPClusterImpersonationIdentitySpec{
+				GCPClusterIdentitySpec: infrav1.GCPClusterIdentitySpec{
+					AllowedNamespaces: &infrav1.AllowedNamespaces{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+					},
+				},
+				ServiceAccountImpersonation: impersonation,
+			},
+		},
+		&infrav1.GCPClusterImpersonationIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec: infrav1.GCPClusterImpersonationIdentitySpec{
+				GCPClusterIdentitySpec: infrav1.GCPClusterIdentitySpec{
+					AllowedNamespaces: &infrav1.AllowedNamespaces{Selector: &metav1.LabelSelector{}},
+				},
+				ServiceAccountImpersonation: impersonation,
+				SecretRef:                   &secretRef,
+			},
+		},
+	).Build()
+
+	tests := []struct {
+		name           string
+		namespace      string
+		credentialsRef *infrav1.ObjectReference
+		identityRef    *infrav1.GCPIdentityReference
+		want           *credentialsSource
+		wantErr        bool
+	}{
+		{
+			name:      "no identity",
+			namespace: "team-b",
+			want:      &credentialsSource{},
+		},
+		{
+			name:           "credentials in the namespace",
+			namespace:      "team-b",
+			credentialsRef: &infrav1.ObjectReference{Namespace: "team-b", Name: "credentials"},
+			want:           &credentialsSource{credentialsRef: &infrav1.ObjectReference{Namespace: "team-b", Name: "credentials"}},
+		},
+		{
+			name:           "credentials in another namespace of a cluster created before identities",
+			namespace:      "team-b",
+			credentialsRef: &secretRef,
+			want:           &credentialsSource{credentialsRef: &secretRef},
+		},
+		{
+			name:        "listed namespace",
+			namespace:   "team-a",
+			identityRef: &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterStaticIdentityKind, Name: "listed"},
+			want:        &credentialsSource{credentialsRef: &secretRef},
+		},
+		{
+			name:        "namespace not listed",
+			namespace:   "team-b",
+			identityRef: &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterStaticIdentityKind, Name: "listed"},
+			wantErr:     true,
+		},
+		{
+			name:        "no allowed namespaces",
+			namespace:   "team-a",
+			identityRef: &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterStaticIdentityKind, Name: "unset"},
+			wantErr:     true,
+		},
+		{
+			name:        "selected namespace",
+			namespace:   "team-a",
+			identityRef: &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterImpersonationIdentityKind, Name: "selected"},
+			want:        &credentialsSource{impersonation: &impersonation},
+		},
+		{
+			name:        "namespace not selected",
+			namespace:   "team-b",
+			identityRef: &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterImpersonationIdentityKind, Name: "selected"},
+			wantErr:     true,
+		},
+		{
+			name:        "empty selector",
+			namespace:   "team-b",
+			identityRef: &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterImpersonationIdentityKind, Name: "all"},
+			want:        &credentialsSource{credentialsRef: &secretRef, impersonation: &impersonation},
+		},
+		{
+			name:        "missing identity",
+			namespace:   "team-a",
+			identityRef: &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterStaticIdentityKind, Name: "missing"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getCredentialsSource(context.TODO(), tt.namespace, tt.credentialsRef, nil, tt.identityRef, crClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getCredentialsSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getCredentialsSource() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
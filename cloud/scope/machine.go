@@ -19,10 +19,12 @@ package scope
 
 import (
 	"context"
-	"fmt"
+	"hash/fnv"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -30,6 +32,7 @@ import (
 	"golang.org/x/mod/semver"
 	"google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -50,6 +54,8 @@ type MachineScopeParams struct {
 	ClusterGetter cloud.ClusterGetter
 	Machine       *clusterv1.Machine
 	GCPMachine    *infrav1.GCPMachine
+	// DryRun does not patch the GCPMachine, the ClusterGetter records the changes of the GCP resources.
+	DryRun bool
 }
 
 // NewMachineScope creates a new MachineScope from the supplied parameters.
@@ -76,6 +82,7 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 		GCPMachine:    params.GCPMachine,
 		ClusterGetter: params.ClusterGetter,
 		patchHelper:   helper,
+		dryRun:        params.DryRun,
 	}, nil
 }
 
@@ -83,6 +90,7 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 type MachineScope struct {
 	client        client.Client
 	patchHelper   *patch.Helper
+	dryRun        bool
 	ClusterGetter cloud.ClusterGetter
 	Machine       *clusterv1.Machine
 	GCPMachine    *infrav1.GCPMachine
@@ -95,21 +103,46 @@ func (m *MachineScope) Cloud() cloud.Cloud {
 	return m.ClusterGetter.Cloud()
 }
 
+// LabelSetter returns the setter of the labels of the compute resources.
+func (m *MachineScope) LabelSetter() cloud.LabelSetter {
+	return m.ClusterGetter.LabelSetter()
+}
+
+// InstanceConsole returns the reader of the guest attributes and serial console of the instances.
+func (m *MachineScope) InstanceConsole() cloud.InstanceConsole {
+	return m.ClusterGetter.InstanceConsole()
+}
+
+// TagValues returns the lookup of the tag values of the resource-manager tags with the credentials of the cluster.
+func (m *MachineScope) TagValues() cloud.TagValues {
+	return m.ClusterGetter.TagValues()
+}
+
+// TagBindings returns the tag bindings of the resources in the location, a zone, a region or global.
+func (m *MachineScope) TagBindings(ctx context.Context, location string) (cloud.TagBindings, error) {
+	return m.ClusterGetter.TagBindings(ctx, location)
+}
+
 // Zone returns the FailureDomain for the GCPMachine.
 func (m *MachineScope) Zone() string {
-	if m.Machine.Spec.FailureDomain == nil {
-		fd := m.ClusterGetter.FailureDomains()
-		if len(fd) == 0 {
-			return ""
-		}
-		zones := make([]string, 0, len(fd))
-		for zone := range fd {
-			zones = append(zones, zone)
-		}
-		sort.Strings(zones)
-		return zones[0]
+	if m.Machine.Spec.FailureDomain != nil {
+		return *m.Machine.Spec.FailureDomain
+	}
+
+	if zone := gcpMachineZone(m.GCPMachine); zone != "" {
+		return zone
+	}
+
+	fd := m.ClusterGetter.FailureDomains()
+	if len(fd) == 0 {
+		return ""
 	}
-	return *m.Machine.Spec.FailureDomain
+	zones := make([]string, 0, len(fd))
+	for zone := range fd {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones[0]
 }
 
 // Project return the project for the GCPMachine's cluster.
@@ -122,6 +155,45 @@ func (m *MachineScope) Name() string {
 	return m.GCPMachine.Name
 }
 
+// InstanceName returns the name of the instance of the machine, see ClusterGetter.ResourceName.
+func (m *MachineScope) InstanceName() string {
+	if existing := m.ExistingInstance(); existing != "" {
+		if id, err := providerid.Parse(existing); err == nil {
+			return id.Name()
+		}
+
+		return existing
+	}
+
+	return m.ClusterGetter.ResourceName(m.Name())
+}
+
+// ExistingInstance returns the name or the provider ID of the existing instance adopted by the machine, if any.
+func (m *MachineScope) ExistingInstance() string {
+	return pointer.StringDeref(m.GCPMachine.Spec.ExistingInstance, "")
+}
+
+// defaultBootstrapTimeout is the timeout of the bootstrap checks without one.
+const defaultBootstrapTimeout = 20 * time.Minute
+
+// BootstrapCheck returns the bootstrap check of the machine with its defaults, or nil if it has none or adopts
+// an existing instance, whose bootstrap is not run by CAPG.
+func (m *MachineScope) BootstrapCheck() *infrav1.BootstrapCheck {
+	if m.GCPMachine.Spec.BootstrapCheck == nil || m.ExistingInstance() != "" {
+		return nil
+	}
+
+	check := m.GCPMachine.Spec.BootstrapCheck.DeepCopy()
+	if check.Method == "" {
+		check.Method = infrav1.BootstrapCheckMethodGuestAttributes
+	}
+	if check.Timeout == nil {
+		check.Timeout = &metav1.Duration{Duration: defaultBootstrapTimeout}
+	}
+
+	return check
+}
+
 // Namespace returns the namespace name.
 func (m *MachineScope) Namespace() string {
 	return m.GCPMachine.Namespace
@@ -129,7 +201,7 @@ func (m *MachineScope) Namespace() string {
 
 // ControlPlaneGroupName returns the control-plane instance group name.
 func (m *MachineScope) ControlPlaneGroupName() string {
-	return fmt.Sprintf("%s-%s-%s", m.ClusterGetter.Name(), infrav1.APIServerRoleTagValue, m.Zone())
+	return m.ClusterGetter.ResourceName(m.ClusterGetter.Name(), infrav1.APIServerRoleTagValue, m.Zone())
 }
 
 // IsControlPlane returns true if the machine is a control plane.
@@ -137,6 +209,12 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// IsClusterOrphanOnDelete reports whether the GCP resources of the cluster of the machine are left behind when it is
+// deleted.
+func (m *MachineScope) IsClusterOrphanOnDelete() bool {
+	return m.ClusterGetter.IsOrphanOnDelete()
+}
+
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
@@ -169,12 +247,163 @@ func (m *MachineScope) GetProviderID() string {
 
 // ANCHOR: MachineSetter
 
+// ReconcileZone places a GCPMachine whose Machine has no failure domain in one of the cluster failure domains,
+// and records the zone in the GCPMachine status. The zone is picked from a hash of the machine name, weighted by
+// the failure domains, so that machines created together are spread without relying on each other's status.
+func (m *MachineScope) ReconcileZone() error {
+	if m.Machine.Spec.FailureDomain != nil || gcpMachineZone(m.GCPMachine) != "" {
+		return nil
+	}
+
+	weights := make(map[string]uint64, len(m.ClusterGetter.FailureDomains()))
+	total := uint64(0)
+	for zone, fd := range m.ClusterGetter.FailureDomains() {
+		if m.IsControlPlane() && !fd.ControlPlane {
+			continue
+		}
+
+		weight := 1
+		if v, ok := fd.Attributes[infrav1.WeightAttribute]; ok {
+			parsed, err := strconv.Atoi(v)
+			if err != nil {
+				return errors.Wrapf(err, "invalid weight for failure domain %s", zone)
+			}
+			weight = parsed
+		}
+
+		if weight > 0 {
+			weights[zone] = uint64(weight)
+			total += uint64(weight)
+		}
+	}
+
+	if len(weights) == 0 {
+		return nil
+	}
+
+	zones := make([]string, 0, len(weights))
+	for zone := range weights {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(m.Namespace() + "/" + m.Name()))
+	rank := h.Sum64() % total
+	for _, zone := range zones {
+		if rank < weights[zone] {
+			m.GCPMachine.Status.Zone = pointer.String(zone)
+			return nil
+		}
+		rank -= weights[zone]
+	}
+
+	return nil
+}
+
+// gcpMachineZone returns the zone recorded for the GCPMachine, if any.
+func gcpMachineZone(gcpMachine *infrav1.GCPMachine) string {
+	if gcpMachine.Spec.ProviderID != nil {
+		if id, err := providerid.Parse(*gcpMachine.Spec.ProviderID); err == nil {
+			return id.Location()
+		}
+	}
+
+	if gcpMachine.Spec.ExistingInstance != nil {
+		if id, err := providerid.Parse(*gcpMachine.Spec.ExistingInstance); err == nil {
+			return id.Location()
+		}
+	}
+
+	return pointer.StringDeref(gcpMachine.Status.Zone, "")
+}
+
+// PendingOperations returns the GCE operations started for the machine that are not done yet.
+func (m *MachineScope) PendingOperations() *[]infrav1.Operation {
+	return &m.GCPMachine.Status.PendingOperations
+}
+
 // SetProviderID sets the GCPMachine providerID in spec.
 func (m *MachineScope) SetProviderID() {
-	providerID, _ := providerid.New(m.ClusterGetter.Project(), m.Zone(), m.Name())
+	providerID, _ := providerid.New(m.ClusterGetter.Project(), m.Zone(), m.InstanceName())
 	m.GCPMachine.Spec.ProviderID = pointer.String(providerID.String())
 }
 
+// GetAdoptedInstance returns the self link of the existing instance adopted by the machine.
+func (m *MachineScope) GetAdoptedInstance() *string {
+	return m.GCPMachine.Status.AdoptedInstance
+}
+
+// SetAdoptedInstance records the adoption of the existing instance with the differences between the instance
+// and the spec of the machine, which are reported by the InstanceAdopted condition.
+func (m *MachineScope) SetAdoptedInstance(selfLink string, mismatches []string) {
+	m.GCPMachine.Status.AdoptedInstance = pointer.String(selfLink)
+	if len(mismatches) > 0 {
+		conditions.MarkFalse(m.GCPMachine, infrav1.InstanceAdoptedCondition, infrav1.InstanceSpecMismatchReason, clusterv1.ConditionSeverityWarning,
+			"Instance differs from the spec: %s", strings.Join(mismatches, "; "))
+		return
+	}
+
+	conditions.MarkTrue(m.GCPMachine, infrav1.InstanceAdoptedCondition)
+}
+
+// IsBootstrapPending returns whether the result of the bootstrap of the instance is not known yet: it neither
+// succeeded nor failed.
+func (m *MachineScope) IsBootstrapPending() bool {
+	condition := conditions.Get(m.GCPMachine, infrav1.BootstrapSucceededCondition)
+	return condition == nil || (condition.Status != corev1.ConditionTrue && condition.Severity != clusterv1.ConditionSeverityError)
+}
+
+// SetBootstrapWaiting reports that the instance has not reported the result of its bootstrap yet.
+func (m *MachineScope) SetBootstrapWaiting() {
+	conditions.MarkFalse(m.GCPMachine, infrav1.BootstrapSucceededCondition, infrav1.WaitingForBootstrapReason, clusterv1.ConditionSeverityInfo, "Waiting for the instance to report the result of its bootstrap")
+}
+
+// BootstrapSerialOffset returns the byte position in the serial console output of the instance up to which the
+// result of the bootstrap was looked for.
+func (m *MachineScope) BootstrapSerialOffset() int64 {
+	return pointer.Int64Deref(m.GCPMachine.Status.BootstrapSerialOffset, 0)
+}
+
+// SetBootstrapSerialOffset records the byte position in the serial console output of the instance up to which the
+// result of the bootstrap was looked for.
+func (m *MachineScope) SetBootstrapSerialOffset(offset int64) {
+	m.GCPMachine.Status.BootstrapSerialOffset = pointer.Int64(offset)
+}
+
+// LabelKeys returns the keys of the labels set by CAPG on the instance and its disks.
+func (m *MachineScope) LabelKeys() []string {
+	return m.GCPMachine.Status.LabelKeys
+}
+
+// SetLabelKeys sets the keys of the labels set by CAPG on the instance and its disks.
+func (m *MachineScope) SetLabelKeys(keys []string) {
+	m.GCPMachine.Status.LabelKeys = keys
+}
+
+// ResourceManagerTagValues returns the tag values bound by CAPG to the instance and its disks.
+func (m *MachineScope) ResourceManagerTagValues() []string {
+	return m.GCPMachine.Status.ResourceManagerTagValues
+}
+
+// SetResourceManagerTagValues sets the tag values bound by CAPG to the instance and its disks.
+func (m *MachineScope) SetResourceManagerTagValues(tagValues []string) {
+	m.GCPMachine.Status.ResourceManagerTagValues = tagValues
+}
+
+// SetBootstrapSucceeded reports that the instance reported the success of its bootstrap.
+func (m *MachineScope) SetBootstrapSucceeded() {
+	conditions.MarkTrue(m.GCPMachine, infrav1.BootstrapSucceededCondition)
+}
+
+// SetBootstrapFailed reports that the bootstrap of the instance failed or timed out. It is the failure of the
+// machine, whose instance must be recreated.
+func (m *MachineScope) SetBootstrapFailed(reason string, err error) {
+	conditions.MarkFalse(m.GCPMachine, infrav1.BootstrapSucceededCondition, reason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	m.SetFailureReason(capierrors.CreateMachineError)
+	m.SetFailureMessage(err)
+}
+
 // GetInstanceStatus returns the GCPMachine instance status.
 func (m *MachineScope) GetInstanceStatus() *infrav1.InstanceStatus {
 	return m.GCPMachine.Status.InstanceStatus
@@ -242,7 +471,7 @@ func (m *MachineScope) InstanceImageSpec() *compute.AttachedDisk {
 		InitializeParams: &compute.AttachedDiskInitializeParams{
 			DiskSizeGb:          m.GCPMachine.Spec.RootDeviceSize,
 			DiskType:            path.Join("zones", m.Zone(), "diskTypes", string(diskType)),
-			ResourceManagerTags: shared.ResourceTagConvert(context.TODO(), m.GCPMachine.Spec.ResourceManagerTags),
+			ResourceManagerTags: m.resourceManagerTagsMap(m.GCPMachine.Spec.ResourceManagerTags),
 			SourceImage:         sourceImage,
 		},
 	}
@@ -257,7 +486,7 @@ func (m *MachineScope) InstanceAdditionalDiskSpec() []*compute.AttachedDisk {
 			InitializeParams: &compute.AttachedDiskInitializeParams{
 				DiskSizeGb:          pointer.Int64Deref(disk.Size, 30),
 				DiskType:            path.Join("zones", m.Zone(), "diskTypes", string(*disk.DeviceType)),
-				ResourceManagerTags: shared.ResourceTagConvert(context.TODO(), m.GCPMachine.Spec.ResourceManagerTags),
+				ResourceManagerTags: m.resourceManagerTagsMap(m.GCPMachine.Spec.ResourceManagerTags),
 			},
 		}
 		if strings.HasSuffix(additionalDisk.InitializeParams.DiskType, string(infrav1.LocalSsdDiskType)) {
@@ -328,29 +557,62 @@ func (m *MachineScope) InstanceAdditionalMetadataSpec() *compute.Metadata {
 	return metadata
 }
 
+// InstanceBootstrapCheckMetadataSpec adds the metadata of the bootstrap check of the machine, if any, which are
+// not set by the additional metadata: the startup script reporting the result of the bootstrap, and the guest
+// attributes it is reported in.
+func (m *MachineScope) InstanceBootstrapCheckMetadataSpec(metadata *compute.Metadata) {
+	check := m.BootstrapCheck()
+	if check == nil {
+		return
+	}
+
+	items := map[string]string{"startup-script": shared.BootstrapCheckScript}
+	if check.Method == infrav1.BootstrapCheckMethodGuestAttributes {
+		items["enable-guest-attributes"] = "TRUE"
+	}
+	for _, item := range metadata.Items {
+		delete(items, item.Key)
+	}
+
+	for _, key := range []string{"startup-script", "enable-guest-attributes"} {
+		if value, ok := items[key]; ok {
+			metadata.Items = append(metadata.Items, &compute.MetadataItems{
+				Key:   key,
+				Value: pointer.String(value),
+			})
+		}
+	}
+}
+
+// Labels returns the labels of the instance and its disks: the ownership labels, and the additional labels
+// of the cluster merged with the ones of the machine.
+func (m *MachineScope) Labels() infrav1.Labels {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: m.ClusterGetter.ResourceName(m.ClusterGetter.Name()),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Role:        pointer.String(m.Role()),
+		// TODO(vincepri): Check what needs to be added for the cloud provider label.
+		Additional: infrav1.Labels{}.AddLabels(m.ClusterGetter.AdditionalLabels()).AddLabels(m.GCPMachine.Spec.AdditionalLabels),
+	})
+}
+
 // InstanceSpec returns instance spec.
 func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
 	instance := &compute.Instance{
-		Name:        m.Name(),
+		Name:        m.InstanceName(),
 		Zone:        m.Zone(),
 		MachineType: path.Join("zones", m.Zone(), "machineTypes", m.GCPMachine.Spec.InstanceType),
 		Tags: &compute.Tags{
 			Items: append(
 				m.GCPMachine.Spec.AdditionalNetworkTags,
-				fmt.Sprintf("%s-%s", m.ClusterGetter.Name(), m.Role()),
-				m.ClusterGetter.Name(),
+				m.ClusterGetter.ResourceName(m.ClusterGetter.Name(), m.Role()),
+				m.ClusterGetter.ResourceName(m.ClusterGetter.Name()),
 			),
 		},
 		Params: &compute.InstanceParams{
-			ResourceManagerTags: shared.ResourceTagConvert(context.TODO(), m.ResourceManagerTags()),
+			ResourceManagerTags: m.resourceManagerTagsMap(m.ResourceManagerTags()),
 		},
-		Labels: infrav1.Build(infrav1.BuildParams{
-			ClusterName: m.ClusterGetter.Name(),
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Role:        pointer.String(m.Role()),
-			// TODO(vincepri): Check what needs to be added for the cloud provider label.
-			Additional: m.ClusterGetter.AdditionalLabels().AddLabels(m.GCPMachine.Spec.AdditionalLabels),
-		}),
+		Labels: m.Labels(),
 		Scheduling: &compute.Scheduling{
 			Preemptible: m.GCPMachine.Spec.Preemptible,
 		},
@@ -397,7 +659,11 @@ func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
 
 	instance.Disks = append(instance.Disks, m.InstanceImageSpec())
 	instance.Disks = append(instance.Disks, m.InstanceAdditionalDiskSpec()...)
+	for _, disk := range instance.Disks {
+		disk.InitializeParams.Labels = instance.Labels
+	}
 	instance.Metadata = m.InstanceAdditionalMetadataSpec()
+	m.InstanceBootstrapCheckMetadataSpec(instance.Metadata)
 	instance.ServiceAccounts = append(instance.ServiceAccounts, m.InstanceServiceAccountsSpec())
 	instance.NetworkInterfaces = append(instance.NetworkInterfaces, m.InstanceNetworkInterfaceSpec())
 	return instance
@@ -425,8 +691,12 @@ func (m *MachineScope) GetBootstrapData() (string, error) {
 	return string(value), nil
 }
 
-// PatchObject persists the cluster configuration and status.
+// PatchObject persists the machine configuration and status, unless the scope is a dry run.
 func (m *MachineScope) PatchObject() error {
+	if m.dryRun {
+		return nil
+	}
+
 	return m.patchHelper.Patch(context.TODO(), m.GCPMachine)
 }
 
@@ -435,6 +705,16 @@ func (m *MachineScope) Close() error {
 	return m.PatchObject()
 }
 
+// resourceManagerTagsMap returns the tag values of the resource-manager tags in the format of the GCP API, looking
+// them up only when there are tags.
+func (m *MachineScope) resourceManagerTagsMap(tags infrav1.ResourceManagerTags) infrav1.ResourceManagerTagsMap {
+	if len(tags) == 0 {
+		return infrav1.ResourceManagerTagsMap{}
+	}
+
+	return m.TagValues().ResourceManagerTagsMap(context.TODO(), tags)
+}
+
 // ResourceManagerTags merges ResourceManagerTags from the scope's GCPCluster and GCPMachine. If the same key is present in both,
 // the value from GCPMachine takes precedence. The returned ResourceManagerTags will never be nil.
 func (m *MachineScope) ResourceManagerTags() infrav1.ResourceManagerTags {
@@ -1,10 +1,15 @@
 package scope
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -72,3 +77,137 @@ func TestMachineLocalSSDDiskType(t *testing.T) {
 	assert.Equal(t, "NVME", localSSDTest.Interface)
 	assert.Equal(t, int64(375), localSSDTest.InitializeParams.DiskSizeGb)
 }
+
+// fakeClusterGetter provides the failure domains of the cluster to the MachineScope.
+type fakeClusterGetter struct {
+	cloud.ClusterGetter
+	failureDomains clusterv1.FailureDomains
+}
+
+func (f *fakeClusterGetter) Name() string {
+	return "my-cluster"
+}
+
+func (f *fakeClusterGetter) FailureDomains() clusterv1.FailureDomains {
+	return f.failureDomains
+}
+
+// This test verifies that machines without failure domain are spread across
+// the cluster failure domains according to their weight.
+func TestMachineReconcileZone(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+
+	clusterGetter := &fakeClusterGetter{
+		failureDomains: clusterv1.FailureDomains{
+			"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+			"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true, Attributes: map[string]string{infrav1.WeightAttribute: "2"}},
+			"us-central1-c": clusterv1.FailureDomainSpec{ControlPlane: true, Attributes: map[string]string{infrav1.WeightAttribute: "0"}},
+		},
+	}
+
+	reconcileZone := func(name string) string {
+		gcpMachine := &infrav1.GCPMachine{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		machineScope, err := NewMachineScope(MachineScopeParams{
+			Client:        fake.NewClientBuilder().WithScheme(schema).Build(),
+			ClusterGetter: clusterGetter,
+			Machine:       &clusterv1.Machine{},
+			GCPMachine:    gcpMachine,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, machineScope.ReconcileZone())
+		return pointer.StringDeref(gcpMachine.Status.Zone, "")
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		name := fmt.Sprintf("md-0-%d", i)
+		zone := reconcileZone(name)
+		// Machines reconciled concurrently get the same zone without reading each other's status.
+		assert.Equal(t, zone, reconcileZone(name))
+		counts[zone]++
+	}
+
+	assert.Zero(t, counts["us-central1-c"])
+	assert.Zero(t, counts[""])
+	assert.InDelta(t, 1000, counts["us-central1-a"], 150)
+	assert.InDelta(t, 2000, counts["us-central1-b"], 150)
+
+	// The zone recorded in the status does not change.
+	gcpMachine := &infrav1.GCPMachine{ObjectMeta: metav1.ObjectMeta{Name: "md-0-0", Namespace: "default"}}
+	gcpMachine.Status.Zone = pointer.String("us-central1-c")
+	machineScope, err := NewMachineScope(MachineScopeParams{
+		Client:        fake.NewClientBuilder().WithScheme(schema).Build(),
+		ClusterGetter: clusterGetter,
+		Machine:       &clusterv1.Machine{},
+		GCPMachine:    gcpMachine,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, machineScope.ReconcileZone())
+	assert.Equal(t, "us-central1-c", machineScope.Zone())
+}
+
+// This test verifies that the metadata of the bootstrap check are added to the instance
+// without overriding the additional metadata of the machine.
+func TestMachineInstanceBootstrapCheckMetadataSpec(t *testing.T) {
+	testCases := []struct {
+		name     string
+		check    *infrav1.BootstrapCheck
+		existing string
+		metadata []infrav1.MetadataItem
+		expected map[string]string
+	}{
+		{
+			name:     "no bootstrap check",
+			expected: map[string]string{},
+		},
+		{
+			name:  "guest attributes are enabled by default",
+			check: &infrav1.BootstrapCheck{},
+			expected: map[string]string{
+				"startup-script":          shared.BootstrapCheckScript,
+				"enable-guest-attributes": "TRUE",
+			},
+		},
+		{
+			name:  "serial console",
+			check: &infrav1.BootstrapCheck{Method: infrav1.BootstrapCheckMethodSerialConsole},
+			expected: map[string]string{
+				"startup-script": shared.BootstrapCheckScript,
+			},
+		},
+		{
+			name:     "startup script of the user is kept",
+			check:    &infrav1.BootstrapCheck{},
+			metadata: []infrav1.MetadataItem{{Key: "startup-script", Value: pointer.String("echo hello")}},
+			expected: map[string]string{
+				"startup-script":          "echo hello",
+				"enable-guest-attributes": "TRUE",
+			},
+		},
+		{
+			name:     "existing instance is not checked",
+			check:    &infrav1.BootstrapCheck{},
+			existing: "existing-vm",
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gcpMachine := &infrav1.GCPMachine{Spec: infrav1.GCPMachineSpec{BootstrapCheck: tc.check, AdditionalMetadata: tc.metadata}}
+			if tc.existing != "" {
+				gcpMachine.Spec.ExistingInstance = pointer.String(tc.existing)
+			}
+			machineScope := &MachineScope{GCPMachine: gcpMachine}
+
+			metadata := machineScope.InstanceAdditionalMetadataSpec()
+			machineScope.InstanceBootstrapCheckMetadataSpec(metadata)
+			items := map[string]string{}
+			for _, item := range metadata.Items {
+				items[item.Key] = pointer.StringDeref(item.Value, "")
+			}
+			assert.Equal(t, tc.expected, items)
+		})
+	}
+}
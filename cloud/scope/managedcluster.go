@@ -26,7 +26,11 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/operations"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/ratelimit"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,8 +55,13 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 		return nil, errors.New("failed to generate new scope from nil GCPManagedCluster")
 	}
 
+	source, err := getCredentialsSource(ctx, params.GCPManagedCluster.Namespace, params.GCPManagedCluster.Spec.CredentialsRef, params.GCPManagedCluster.Spec.ServiceAccountImpersonation, params.GCPManagedCluster.Spec.IdentityRef, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("getting gcp credentials source: %w", err)
+	}
+
 	if params.GCPServices.Compute == nil {
-		computeSvc, err := newComputeService(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		computeSvc, err := newComputeService(ctx, source.credentialsRef, source.impersonation, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp compute client: %v", err)
 		}
@@ -71,6 +80,7 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 		GCPManagedCluster:      params.GCPManagedCluster,
 		GCPManagedControlPlane: params.GCPManagedControlPlane,
 		GCPServices:            params.GCPServices,
+		credentials:            source,
 		patchHelper:            helper,
 	}, nil
 }
@@ -79,6 +89,7 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 type ManagedClusterScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	credentials *credentialsSource
 
 	Cluster                *clusterv1.Cluster
 	GCPManagedCluster      *infrav1exp.GCPManagedCluster
@@ -93,6 +104,47 @@ func (s *ManagedClusterScope) Cloud() cloud.Cloud {
 	return newCloud(s.Project(), s.GCPServices)
 }
 
+// ResourceName returns the name of a GCE resource of the cluster made of the parts, see resourceName.
+func (s *ManagedClusterScope) ResourceName(parts ...string) string {
+	return resourceName(s.Namespace(), namespacedResourceNames(s.GCPManagedCluster), parts...)
+}
+
+// IsOrphanOnDelete reports whether the GCP resources of the cluster are left behind when it is deleted.
+func (s *ManagedClusterScope) IsOrphanOnDelete() bool {
+	return reconciler.IsOrphanOnDelete(s.GCPManagedCluster)
+}
+
+// DefaultResourceNaming records the naming scheme of the GCE resources of the cluster when it is first reconciled.
+// It must be called before the finalizer is added.
+func (s *ManagedClusterScope) DefaultResourceNaming() {
+	defaultResourceNaming(s.GCPManagedCluster, infrav1exp.ClusterFinalizer)
+}
+
+// LabelSetter returns the setter of the labels of the compute resources.
+func (s *ManagedClusterScope) LabelSetter() cloud.LabelSetter {
+	return operations.NewLabelSetter(s.Compute, s.Project(), ratelimit.ForProject(s.Project()))
+}
+
+// InstanceConsole returns the reader of the guest attributes and serial console of the instances.
+func (s *ManagedClusterScope) InstanceConsole() cloud.InstanceConsole {
+	return newInstanceConsole(s.Compute, s.Project(), ratelimit.ForProject(s.Project()))
+}
+
+// TagValues returns the lookup of the tag values of the resource-manager tags with the credentials of the cluster.
+func (s *ManagedClusterScope) TagValues() cloud.TagValues {
+	return newTagValues(s.credentials, s.client)
+}
+
+// TagBindings returns the tag bindings of the resources in the location, a zone, a region or global.
+func (s *ManagedClusterScope) TagBindings(ctx context.Context, location string) (cloud.TagBindings, error) {
+	client, err := newTagBindingsClient(ctx, s.credentials.credentialsRef, s.credentials.impersonation, s.client, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.NewTagBindings(client), nil
+}
+
 // Project returns the current project name.
 func (s *ManagedClusterScope) Project() string {
 	return s.GCPManagedCluster.Spec.Project
@@ -118,6 +170,12 @@ func (s *ManagedClusterScope) NetworkName() string {
 	return pointer.StringDeref(s.GCPManagedCluster.Spec.Network.Name, "default")
 }
 
+// ExistingRouter returns the name or the self link of the existing router of the network used by the cluster,
+// empty if CAPG creates the Cloud NAT router.
+func (s *ManagedClusterScope) ExistingRouter() string {
+	return pointer.StringDeref(s.GCPManagedCluster.Spec.Network.Router, "")
+}
+
 // NetworkLink returns the partial URL for the network.
 func (s *ManagedClusterScope) NetworkLink() string {
 	return fmt.Sprintf("projects/%s/global/networks/%s", s.Project(), s.NetworkName())
@@ -182,7 +240,7 @@ func (s *ManagedClusterScope) NetworkSpec() *compute.Network {
 	createSubnet := pointer.BoolDeref(s.GCPManagedCluster.Spec.Network.AutoCreateSubnetworks, true)
 	network := &compute.Network{
 		Name:                  s.NetworkName(),
-		Description:           infrav1.ClusterTagKey(s.Name()),
+		Description:           infrav1.ClusterTagKey(s.ResourceName(s.Name())),
 		AutoCreateSubnetworks: createSubnet,
 		ForceSendFields:       []string{"AutoCreateSubnetworks"},
 	}
@@ -194,10 +252,10 @@ func (s *ManagedClusterScope) NetworkSpec() *compute.Network {
 func (s *ManagedClusterScope) NatRouterSpec() *compute.Router {
 	networkSpec := s.NetworkSpec()
 	return &compute.Router{
-		Name: fmt.Sprintf("%s-%s", networkSpec.Name, "router"),
+		Name: s.ResourceName(networkSpec.Name, "router"),
 		Nats: []*compute.RouterNat{
 			{
-				Name:                          fmt.Sprintf("%s-%s", networkSpec.Name, "nat"),
+				Name:                          s.ResourceName(networkSpec.Name, "nat"),
 				NatIpAllocateOption:           "AUTO_ONLY",
 				SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
 			},
@@ -222,7 +280,7 @@ func (s *ManagedClusterScope) SubnetSpecs() []*compute.Subnetwork {
 			PrivateIpGoogleAccess: pointer.BoolDeref(subnetwork.PrivateGoogleAccess, false),
 			IpCidrRange:           subnetwork.CidrBlock,
 			SecondaryIpRanges:     secondaryIPRanges,
-			Description:           pointer.StringDeref(subnetwork.Description, infrav1.ClusterTagKey(s.Name())),
+			Description:           pointer.StringDeref(subnetwork.Description, infrav1.ClusterTagKey(s.ResourceName(s.Name()))),
 			Network:               s.NetworkLink(),
 			Purpose:               pointer.StringDeref(subnetwork.Purpose, "PRIVATE_RFC_1918"),
 			Role:                  "ACTIVE",
@@ -238,7 +296,7 @@ func (s *ManagedClusterScope) SubnetSpecs() []*compute.Subnetwork {
 func (s *ManagedClusterScope) FirewallRulesSpec() []*compute.Firewall {
 	firewallRules := []*compute.Firewall{
 		{
-			Name:    fmt.Sprintf("allow-%s-healthchecks", s.Name()),
+			Name:    s.ResourceName("allow", s.Name(), "healthchecks"),
 			Network: s.NetworkLink(),
 			Allowed: []*compute.FirewallAllowed{
 				{
@@ -254,11 +312,11 @@ func (s *ManagedClusterScope) FirewallRulesSpec() []*compute.Firewall {
 				"130.211.0.0/22",
 			},
 			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
+				s.ResourceName(s.Name(), "control-plane"),
 			},
 		},
 		{
-			Name:    fmt.Sprintf("allow-%s-cluster", s.Name()),
+			Name:    s.ResourceName("allow", s.Name(), "cluster"),
 			Network: s.NetworkLink(),
 			Allowed: []*compute.FirewallAllowed{
 				{
@@ -267,12 +325,12 @@ func (s *ManagedClusterScope) FirewallRulesSpec() []*compute.Firewall {
 			},
 			Direction: "INGRESS",
 			SourceTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
+				s.ResourceName(s.Name(), "control-plane"),
+				s.ResourceName(s.Name(), "node"),
 			},
 			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
+				s.ResourceName(s.Name(), "control-plane"),
+				s.ResourceName(s.Name(), "node"),
 			},
 		},
 	}
@@ -282,6 +340,11 @@ func (s *ManagedClusterScope) FirewallRulesSpec() []*compute.Firewall {
 
 // ANCHOR_END: ClusterFirewallSpec
 
+// ValidateCredentials checks that the credentials of the cluster can authenticate with GCP.
+func (s *ManagedClusterScope) ValidateCredentials(ctx context.Context) error {
+	return validateCredentials(ctx, s.credentials, s.client)
+}
+
 // PatchObject persists the cluster configuration and status.
 func (s *ManagedClusterScope) PatchObject() error {
 	return s.patchHelper.Patch(context.TODO(), s.GCPManagedCluster)
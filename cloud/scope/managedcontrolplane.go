@@ -28,6 +28,8 @@ import (
 	credentials "cloud.google.com/go/iam/credentials/apiv1"
 	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
 	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -64,20 +66,25 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		return nil, errors.New("failed to generate new scope from nil GCPManagedControlPlane")
 	}
 
-	credential, err := getCredentials(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+	source, err := getCredentialsSource(ctx, params.GCPManagedCluster.Namespace, params.GCPManagedCluster.Spec.CredentialsRef, params.GCPManagedCluster.Spec.ServiceAccountImpersonation, params.GCPManagedCluster.Spec.IdentityRef, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("getting gcp credentials source: %w", err)
+	}
+
+	credential, err := getCredentials(ctx, source.credentialsRef, source.impersonation, params.Client)
 	if err != nil {
 		return nil, fmt.Errorf("getting gcp credentials: %w", err)
 	}
 
 	if params.ManagedClusterClient == nil {
-		managedClusterClient, err := newClusterManagerClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		managedClusterClient, err := newClusterManagerClient(ctx, source.credentialsRef, source.impersonation, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp managed cluster client: %v", err)
 		}
 		params.ManagedClusterClient = managedClusterClient
 	}
 	if params.TagBindingsClient == nil {
-		tagBindingsClient, err := newTagBindingsClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client, params.GCPManagedCluster.Spec.Region)
+		tagBindingsClient, err := newTagBindingsClient(ctx, source.credentialsRef, source.impersonation, params.Client, params.GCPManagedCluster.Spec.Region)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp tag bindings client: %v", err)
 		}
@@ -85,7 +92,7 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 	}
 	if params.CredentialsClient == nil {
 		var credentialsClient *credentials.IamCredentialsClient
-		credentialsClient, err = newIamCredentialsClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		credentialsClient, err = newIamCredentialsClient(ctx, source.credentialsRef, source.impersonation, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp credentials client: %v", err)
 		}
@@ -106,6 +113,7 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		tagBindingsClient:      params.TagBindingsClient,
 		credentialsClient:      params.CredentialsClient,
 		credential:             credential,
+		credentials:            source,
 		patchHelper:            helper,
 	}, nil
 }
@@ -122,6 +130,7 @@ type ManagedControlPlaneScope struct {
 	tagBindingsClient      *resourcemanager.TagBindingsClient
 	credentialsClient      *credentials.IamCredentialsClient
 	credential             *Credential
+	credentials            *credentialsSource
 
 	AllMachinePools        []clusterv1exp.MachinePool
 	AllManagedMachinePools []infrav1exp.GCPManagedMachinePool
@@ -137,14 +146,18 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1exp.GKEControlPlaneCreatingCondition,
 			infrav1exp.GKEControlPlaneUpdatingCondition,
 			infrav1exp.GKEControlPlaneDeletingCondition,
+			infrav1.CredentialsValidCondition,
 		}})
 }
 
+// ValidateCredentials checks that the credentials of the cluster can authenticate with GCP.
+func (s *ManagedControlPlaneScope) ValidateCredentials(ctx context.Context) error {
+	return validateCredentials(ctx, s.credentials, s.client)
+}
+
 // Close closes the current scope persisting the managed control plane configuration and status.
+// The GCP clients are shared through the client cache and stay open.
 func (s *ManagedControlPlaneScope) Close() error {
-	s.mcClient.Close()
-	s.tagBindingsClient.Close()
-	s.credentialsClient.Close()
 	return s.PatchObject()
 }
 
@@ -168,6 +181,11 @@ func (s *ManagedControlPlaneScope) TagBindingsClient() *resourcemanager.TagBindi
 	return s.tagBindingsClient
 }
 
+// TagValues returns the lookup of the tag values of the resource-manager tags with the credentials of the cluster.
+func (s *ManagedControlPlaneScope) TagValues() cloud.TagValues {
+	return newTagValues(s.credentials, s.client)
+}
+
 // CredentialsClient returns a client used to interact with IAM.
 func (s *ManagedControlPlaneScope) CredentialsClient() *credentials.IamCredentialsClient {
 	return s.credentialsClient
@@ -204,6 +222,11 @@ func (s *ManagedControlPlaneScope) GetAllNodePools(ctx context.Context) ([]infra
 	return s.AllManagedMachinePools, s.AllMachinePools, nil
 }
 
+// Project returns the project of the GKE cluster.
+func (s *ManagedControlPlaneScope) Project() string {
+	return s.GCPManagedControlPlane.Spec.Project
+}
+
 // Region returns the region of the GKE cluster.
 func (s *ManagedControlPlaneScope) Region() string {
 	loc, _ := location.Parse(s.GCPManagedControlPlane.Spec.Location)
@@ -31,6 +31,7 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -69,15 +70,20 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		return nil, errors.New("failed to generate new scope from nil GCPManagedMachinePool")
 	}
 
+	source, err := getCredentialsSource(ctx, params.GCPManagedCluster.Namespace, params.GCPManagedCluster.Spec.CredentialsRef, params.GCPManagedCluster.Spec.ServiceAccountImpersonation, params.GCPManagedCluster.Spec.IdentityRef, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("getting gcp credentials source: %w", err)
+	}
+
 	if params.ManagedClusterClient == nil {
-		managedClusterClient, err := newClusterManagerClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		managedClusterClient, err := newClusterManagerClient(ctx, source.credentialsRef, source.impersonation, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp managed cluster client: %v", err)
 		}
 		params.ManagedClusterClient = managedClusterClient
 	}
 	if params.InstanceGroupManagersClient == nil {
-		instanceGroupManagersClient, err := newInstanceGroupManagerClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		instanceGroupManagersClient, err := newInstanceGroupManagerClient(ctx, source.credentialsRef, source.impersonation, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp instance group manager client: %v", err)
 		}
@@ -97,6 +103,7 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		GCPManagedMachinePool:  params.GCPManagedMachinePool,
 		mcClient:               params.ManagedClusterClient,
 		migClient:              params.InstanceGroupManagersClient,
+		credentials:            source,
 		patchHelper:            helper,
 	}, nil
 }
@@ -113,6 +120,7 @@ type ManagedMachinePoolScope struct {
 	GCPManagedMachinePool  *infrav1exp.GCPManagedMachinePool
 	mcClient               *container.ClusterManagerClient
 	migClient              *compute.InstanceGroupManagersClient
+	credentials            *credentialsSource
 }
 
 // PatchObject persists the managed control plane configuration and status.
@@ -125,13 +133,18 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 			infrav1exp.GKEMachinePoolCreatingCondition,
 			infrav1exp.GKEMachinePoolUpdatingCondition,
 			infrav1exp.GKEMachinePoolDeletingCondition,
+			infrav1.CredentialsValidCondition,
 		}})
 }
 
+// ValidateCredentials checks that the credentials of the cluster can authenticate with GCP.
+func (s *ManagedMachinePoolScope) ValidateCredentials(ctx context.Context) error {
+	return validateCredentials(ctx, s.credentials, s.client)
+}
+
 // Close closes the current scope persisting the managed control plane configuration and status.
+// The GCP clients are shared through the client cache and stay open.
 func (s *ManagedMachinePoolScope) Close() error {
-	s.mcClient.Close()
-	s.migClient.Close()
 	return s.PatchObject()
 }
 
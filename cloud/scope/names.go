@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/hash"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// maxResourceNameLength is the maximum length of the names of GCE resources and network tags.
+	maxResourceNameLength = 63
+
+	// resourceNameHashLength is the length of the hash suffix of the names.
+	resourceNameHashLength = 6
+)
+
+// resourceName returns the name of a GCE resource made of the parts joined with dashes.
+// With the namespaced scheme, a hash of the namespace and the name is appended, so the resources of the objects with
+// the same name in different namespaces sharing a project do not collide. With the legacy scheme, the name is left
+// as is when it is valid. Names longer than 63 characters are truncated before the hash.
+func resourceName(namespace string, namespaced bool, parts ...string) string {
+	return resourceNameWithin(maxResourceNameLength, namespace, namespaced, parts...)
+}
+
+// resourceNameWithin returns the name of resourceName within the length, for the names used as prefix.
+func resourceNameWithin(length int, namespace string, namespaced bool, parts ...string) string {
+	name := strings.Join(parts, "-")
+	if !namespaced && len(name) <= length {
+		return name
+	}
+
+	// The hash cannot fail with a valid length.
+	suffix, _ := hash.Base36TruncatedHash(namespace+"/"+name, resourceNameHashLength)
+	if limit := length - resourceNameHashLength - 1; len(name) > limit {
+		name = strings.TrimRight(name[:limit], "-")
+	}
+
+	return name + "-" + suffix
+}
+
+// namespacedResourceNames returns whether the resources of the cluster object use the namespaced scheme.
+// The objects without the annotation, which were not reconciled yet, use the legacy scheme.
+func namespacedResourceNames(obj client.Object) bool {
+	return obj.GetAnnotations()[infrav1.ResourceNamingAnnotation] == infrav1.ResourceNamingNamespaced
+}
+
+// defaultResourceNaming records the naming scheme of the cluster object when it is first reconciled: the legacy
+// scheme for the clusters reconciled by a previous version, which already have their finalizer, and the namespaced
+// scheme for the new clusters.
+func defaultResourceNaming(obj client.Object, finalizer string) {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[infrav1.ResourceNamingAnnotation]; ok {
+		return
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[infrav1.ResourceNamingAnnotation] = infrav1.ResourceNamingNamespaced
+	if controllerutil.ContainsFinalizer(obj, finalizer) {
+		annotations[infrav1.ResourceNamingAnnotation] = infrav1.ResourceNamingLegacy
+	}
+	obj.SetAnnotations(annotations)
+}
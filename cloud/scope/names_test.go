@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/hash"
+)
+
+var gceNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+func TestResourceName(t *testing.T) {
+	longName := strings.Repeat("a", 60)
+
+	tests := []struct {
+		name       string
+		namespace  string
+		namespaced bool
+		parts      []string
+		want       string
+	}{
+		{
+			name:      "legacy name",
+			namespace: "default",
+			parts:     []string{"my-cluster", "apiserver"},
+			want:      "my-cluster-apiserver",
+		},
+		{
+			name:       "namespaced name",
+			namespace:  "default",
+			namespaced: true,
+			parts:      []string{"my-cluster", "apiserver"},
+			want:       "my-cluster-apiserver-" + mustHash(t, "default/my-cluster-apiserver"),
+		},
+		{
+			name:      "legacy name too long",
+			namespace: "default",
+			parts:     []string{"allow", longName, "healthchecks"},
+			want:      "allow-" + longName[:50] + "-" + mustHash(t, "default/allow-"+longName+"-healthchecks"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resourceName(tt.namespace, tt.namespaced, tt.parts...)
+			if got != tt.want {
+				t.Errorf("resourceName() = %q, want %q", got, tt.want)
+			}
+			if len(got) > maxResourceNameLength || !gceNameRegexp.MatchString(got) {
+				t.Errorf("resourceName() = %q is not a valid GCE name", got)
+			}
+		})
+	}
+
+	if resourceName("ns1", true, "my-cluster") == resourceName("ns2", true, "my-cluster") {
+		t.Errorf("resourceName() returned the same name for clusters in different namespaces")
+	}
+}
+
+func TestResourceNameWithin(t *testing.T) {
+	got := resourceNameWithin(46, "default", false, strings.Repeat("a", 40), "podcidr")
+	if len(got) > 46 {
+		t.Errorf("resourceNameWithin() = %q, longer than 46 characters", got)
+	}
+}
+
+func TestDefaultResourceNaming(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		finalizers  []string
+		want        string
+	}{
+		{
+			name: "new cluster",
+			want: infrav1.ResourceNamingNamespaced,
+		},
+		{
+			name:       "cluster reconciled before the namespaced scheme",
+			finalizers: []string{infrav1.ClusterFinalizer},
+			want:       infrav1.ResourceNamingLegacy,
+		},
+		{
+			name:        "scheme already recorded",
+			annotations: map[string]string{infrav1.ResourceNamingAnnotation: infrav1.ResourceNamingNamespaced},
+			finalizers:  []string{infrav1.ClusterFinalizer},
+			want:        infrav1.ResourceNamingNamespaced,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcpCluster := &infrav1.GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-cluster",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+					Finalizers:  tt.finalizers,
+				},
+			}
+			defaultResourceNaming(gcpCluster, infrav1.ClusterFinalizer)
+			if got := gcpCluster.Annotations[infrav1.ResourceNamingAnnotation]; got != tt.want {
+				t.Errorf("defaultResourceNaming() set %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustHash(t *testing.T, s string) string {
+	t.Helper()
+	h, err := hash.Base36TruncatedHash(s, resourceNameHashLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h
+}
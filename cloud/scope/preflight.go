@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	computeService         = "compute.googleapis.com"
+	containerService       = "container.googleapis.com"
+	resourceManagerService = "cloudresourcemanager.googleapis.com"
+
+	// defaultNodePoolMachineType is the machine type of the GKE node pools without instance type.
+	defaultNodePoolMachineType = "e2-medium"
+)
+
+// clusterPermissions are the permissions needed to create the GCE resources of every GCPCluster and of its
+// machines, see ClusterScope.clusterPermissions for the ones depending on the spec.
+var clusterPermissions = []string{
+	"compute.disks.create",
+	"compute.instanceGroups.create",
+	"compute.instances.create",
+	"compute.instances.setLabels",
+	"compute.instances.setMetadata",
+	"compute.regions.get",
+	"compute.subnetworks.use",
+	"compute.zones.list",
+}
+
+// managedControlPlanePermissions are the permissions needed to create a GKE cluster and its node pools.
+var managedControlPlanePermissions = []string{
+	"container.clusters.create",
+	"container.clusters.get",
+	"container.clusters.update",
+	"container.operations.get",
+	"compute.instanceGroupManagers.get",
+	"compute.regions.get",
+}
+
+// PreflightSpec returns what the cluster needs from its project: the compute API, the permissions to create its
+// resources and the quotas of its control plane and machine deployments.
+func (s *ClusterScope) PreflightSpec(ctx context.Context) (*shared.PreflightSpec, error) {
+	permissions, err := s.clusterPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &shared.PreflightSpec{
+		Services:    []string{computeService},
+		Permissions: permissions,
+	}
+	if len(s.GCPCluster.Spec.ResourceManagerTags) > 0 || s.GCPCluster.Spec.Network.FirewallPolicy != nil {
+		spec.Services = append(spec.Services, resourceManagerService)
+	}
+
+	if ref := s.Cluster.Spec.ControlPlaneRef; ref != nil {
+		controlPlane := &unstructured.Unstructured{}
+		controlPlane.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+		if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.Cluster.Namespace, Name: ref.Name}, controlPlane); err != nil {
+			return nil, fmt.Errorf("getting control plane %s: %w", ref.Name, err)
+		}
+
+		replicas, found, err := unstructured.NestedInt64(controlPlane.Object, "spec", "replicas")
+		if err != nil {
+			return nil, fmt.Errorf("getting the replicas of control plane %s: %w", ref.Name, err)
+		}
+		kind, _, _ := unstructured.NestedString(controlPlane.Object, "spec", "machineTemplate", "infrastructureRef", "kind")
+		name, _, _ := unstructured.NestedString(controlPlane.Object, "spec", "machineTemplate", "infrastructureRef", "name")
+		if found && kind == "GCPMachineTemplate" {
+			machines, err := s.plannedMachines(ctx, name, replicas)
+			if err != nil {
+				return nil, err
+			}
+			spec.Machines = append(spec.Machines, machines)
+		}
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := s.client.List(ctx, machineDeployments,
+		client.InNamespace(s.Cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: s.Cluster.Name},
+	); err != nil {
+		return nil, fmt.Errorf("listing machine deployments: %w", err)
+	}
+
+	for _, md := range machineDeployments.Items {
+		ref := md.Spec.Template.Spec.InfrastructureRef
+		if ref.Kind != "GCPMachineTemplate" {
+			continue
+		}
+
+		machines, err := s.plannedMachines(ctx, ref.Name, int64(pointer.Int32Deref(md.Spec.Replicas, 1)))
+		if err != nil {
+			return nil, err
+		}
+		spec.Machines = append(spec.Machines, machines)
+	}
+
+	return spec, nil
+}
+
+// clusterPermissions returns the permissions needed to create the GCE resources of the cluster. The existing
+// network, router and load balancer components referenced by the spec are not created by CAPG.
+func (s *ClusterScope) clusterPermissions(ctx context.Context) ([]string, error) {
+	permissions := append([]string{}, clusterPermissions...)
+
+	// The router is only created in the network created by CAPG.
+	_, err := s.Cloud().Networks().Get(ctx, meta.GlobalKey(s.NetworkName()))
+	if err != nil && !gcperrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting network %s: %w", s.NetworkName(), err)
+	}
+	if gcperrors.IsNotFound(err) {
+		permissions = append(permissions, "compute.networks.create")
+		if s.ExistingRouter() == "" {
+			permissions = append(permissions, "compute.routers.create")
+		}
+	}
+
+	if len(s.GCPCluster.Spec.Network.Subnets) > 0 {
+		permissions = append(permissions, "compute.subnetworks.create")
+	}
+
+	if s.FirewallPolicy() == nil {
+		permissions = append(permissions, "compute.firewalls.create")
+	}
+
+	lb := s.LoadBalancer()
+	if lb.BackendService == nil {
+		permissions = append(permissions, "compute.backendServices.create")
+		if lb.HealthCheck == nil {
+			permissions = append(permissions, "compute.healthChecks.create")
+		}
+	}
+
+	if lb.ForwardingRule == nil {
+		permissions = append(permissions, "compute.globalForwardingRules.create", "compute.targetTcpProxies.create")
+		if lb.Address == nil {
+			permissions = append(permissions, "compute.globalAddresses.create")
+		}
+	}
+
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// plannedMachines returns the planned machines of the GCPMachineTemplate of the cluster.
+func (s *ClusterScope) plannedMachines(ctx context.Context, template string, replicas int64) (shared.PlannedMachines, error) {
+	machineTemplate := &infrav1.GCPMachineTemplate{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.Cluster.Namespace, Name: template}, machineTemplate); err != nil {
+		return shared.PlannedMachines{}, fmt.Errorf("getting GCPMachineTemplate %s: %w", template, err)
+	}
+
+	machines := shared.PlannedMachines{
+		MachineType: machineTemplate.Spec.Template.Spec.InstanceType,
+		Count:       replicas,
+	}
+	if pointer.BoolDeref(machineTemplate.Spec.Template.Spec.PublicIP, false) {
+		machines.PublicIPs = replicas
+	}
+
+	return machines, nil
+}
+
+// ProjectChecker returns the checker of the state of the project of the cluster.
+func (s *ClusterScope) ProjectChecker(ctx context.Context) (shared.ProjectChecker, error) {
+	return newProjectChecker(ctx, s.Project(), s.Compute, s.credentials.credentialsRef, s.credentials.impersonation, s.client)
+}
+
+// PreflightSpec returns what the GKE cluster needs from its project: the compute and container APIs, the
+// permissions to create the cluster and the quotas of its node pools. The nodes of an autopilot cluster are not
+// planned.
+func (s *ManagedControlPlaneScope) PreflightSpec(ctx context.Context) (*shared.PreflightSpec, error) {
+	spec := &shared.PreflightSpec{
+		Services:    []string{computeService, containerService},
+		Permissions: managedControlPlanePermissions,
+	}
+	if s.IsAutopilotCluster() {
+		return spec, nil
+	}
+
+	managedMachinePools, machinePools, err := s.GetAllNodePools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing machine pools: %w", err)
+	}
+
+	for i := range managedMachinePools {
+		// The external IP addresses of the nodes are not planned: GKE does not give any to the nodes of private
+		// clusters, which CAPG cannot tell from the spec.
+		spec.Machines = append(spec.Machines, shared.PlannedMachines{
+			MachineType: pointer.StringDeref(managedMachinePools[i].Spec.InstanceType, defaultNodePoolMachineType),
+			Count:       int64(pointer.Int32Deref(machinePools[i].Spec.Replicas, 1)),
+		})
+	}
+
+	return spec, nil
+}
+
+// ProjectChecker returns the checker of the state of the project of the GKE cluster.
+func (s *ManagedControlPlaneScope) ProjectChecker(ctx context.Context) (shared.ProjectChecker, error) {
+	computeSvc, err := newComputeService(ctx, s.credentials.credentialsRef, s.credentials.impersonation, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProjectChecker(ctx, s.Project(), computeSvc, s.credentials.credentialsRef, s.credentials.impersonation, s.client)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestClusterScope_clusterPermissions(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          infrav1.GCPClusterSpec
+		networkExists bool
+		want          []string
+	}{
+		{
+			name: "cluster creating its infrastructure",
+			spec: infrav1.GCPClusterSpec{
+				Network: infrav1.NetworkSpec{
+					Subnets: infrav1.Subnets{{Name: "my-subnet", CidrBlock: "10.0.0.0/24"}},
+				},
+			},
+			want: []string{
+				"compute.backendServices.create",
+				"compute.disks.create",
+				"compute.firewalls.create",
+				"compute.globalAddresses.create",
+				"compute.globalForwardingRules.create",
+				"compute.healthChecks.create",
+				"compute.instanceGroups.create",
+				"compute.instances.create",
+				"compute.instances.setLabels",
+				"compute.instances.setMetadata",
+				"compute.networks.create",
+				"compute.regions.get",
+				"compute.routers.create",
+				"compute.subnetworks.create",
+				"compute.subnetworks.use",
+				"compute.targetTcpProxies.create",
+				"compute.zones.list",
+			},
+		},
+		{
+			name: "cluster using existing infrastructure",
+			spec: infrav1.GCPClusterSpec{
+				Network: infrav1.NetworkSpec{
+					Router:         pointer.String("shared-router"),
+					FirewallPolicy: &infrav1.FirewallPolicySpec{},
+				},
+				LoadBalancer: &infrav1.LoadBalancerSpec{
+					BackendService: pointer.String("shared-apiserver"),
+					ForwardingRule: pointer.String("shared-apiserver"),
+				},
+			},
+			networkExists: true,
+			want: []string{
+				"compute.disks.create",
+				"compute.instanceGroups.create",
+				"compute.instances.create",
+				"compute.instances.setLabels",
+				"compute.instances.setMetadata",
+				"compute.regions.get",
+				"compute.subnetworks.use",
+				"compute.zones.list",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/projects/my-proj/global/networks/default" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if !tt.networkExists {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "not found"}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"name": "default"}`))
+			}))
+			defer server.Close()
+
+			computeSvc, err := compute.NewService(context.TODO(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.spec.Project = "my-proj"
+			tt.spec.Region = "us-central1"
+			s := &ClusterScope{
+				Cluster:     &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}},
+				GCPCluster:  &infrav1.GCPCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}, Spec: tt.spec},
+				GCPServices: GCPServices{Compute: computeSvc},
+			}
+
+			got, err := s.clusterPermissions(context.TODO())
+			if err != nil {
+				t.Fatalf("clusterPermissions() error = %v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("clusterPermissions() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
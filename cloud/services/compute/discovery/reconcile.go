@@ -0,0 +1,260 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile fills the network status of the cluster with the resources found in the cloud.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Discovering cluster network resources")
+	return s.discover(ctx)
+}
+
+// Delete fills the network status of the cluster with the resources found in the cloud,
+// so that the delete of the other services does not leak resources missing from the status.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Discovering cluster network resources before deleting")
+	return s.discover(ctx)
+}
+
+// discover looks up the resources missing from the network status by their deterministic names,
+// the resources already in the status are left as they are.
+func (s *Service) discover(ctx context.Context) error {
+	if err := s.discoverNetwork(ctx); err != nil {
+		return err
+	}
+
+	if err := s.discoverInstanceGroups(ctx); err != nil {
+		return err
+	}
+
+	return s.discoverLoadBalancer(ctx)
+}
+
+func (s *Service) discoverNetwork(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	status := s.scope.Network()
+	if status.SelfLink != nil && status.Router != nil {
+		return nil
+	}
+
+	network, err := s.networks.Get(ctx, meta.GlobalKey(s.scope.NetworkName()))
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+
+	if status.SelfLink == nil {
+		log.V(2).Info("Discovered network", "name", network.Name)
+		status.SelfLink = pointer.String(network.SelfLink)
+	}
+
+	// The router is only created in the networks created by capg.
+	if status.Router != nil || network.Description != s.owner() {
+		return nil
+	}
+
+	routerSpec := s.scope.NatRouterSpec()
+	router, err := s.routers.Get(ctx, meta.RegionalKey(routerSpec.Name, s.scope.Region()))
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+
+	if router.Description == s.owner() {
+		log.V(2).Info("Discovered cloudnat router", "name", router.Name)
+		status.Router = pointer.String(router.SelfLink)
+	}
+
+	return nil
+}
+
+func (s *Service) discoverInstanceGroups(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	zones, err := s.instanceGroupZones(ctx)
+	if err != nil {
+		return err
+	}
+
+	status := s.scope.Network()
+	for _, zone := range zones {
+		if _, ok := status.APIServerInstanceGroups[zone]; ok {
+			continue
+		}
+
+		spec := s.scope.InstanceGroupSpec(zone)
+		instancegroup, err := s.instancegroups.Get(ctx, meta.ZonalKey(spec.Name, zone))
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				continue
+			}
+
+			log.Error(err, "Error looking for instancegroup in zone", "zone", zone)
+			return err
+		}
+
+		if !s.ownedDescription(instancegroup.Description) {
+			continue
+		}
+
+		log.V(2).Info("Discovered instancegroup", "zone", zone, "name", instancegroup.Name)
+		if status.APIServerInstanceGroups == nil {
+			status.APIServerInstanceGroups = make(map[string]string)
+		}
+		status.APIServerInstanceGroups[zone] = instancegroup.SelfLink
+	}
+
+	return nil
+}
+
+// instanceGroupZones returns the zones of the failure domains of the cluster, or all the zones
+// of the region when the failure domains are missing from the status too.
+func (s *Service) instanceGroupZones(ctx context.Context) ([]string, error) {
+	zones := make([]string, 0, len(s.scope.FailureDomains()))
+	for zone := range s.scope.FailureDomains() {
+		zones = append(zones, zone)
+	}
+
+	if len(zones) > 0 {
+		return zones, nil
+	}
+
+	region, err := s.regions.Get(ctx, meta.GlobalKey(s.scope.Region()))
+	if err != nil {
+		return nil, gcperrors.IgnoreNotFound(err)
+	}
+
+	list, err := s.zones.List(ctx, filter.Regexp("region", region.SelfLink))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range list {
+		zones = append(zones, zone.Name)
+	}
+
+	return zones, nil
+}
+
+func (s *Service) discoverLoadBalancer(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	status := s.scope.Network()
+	if status.APIServerHealthCheck == nil {
+		name := s.scope.HealthCheckSpec().Name
+		healthcheck, err := s.healthchecks.Get(ctx, meta.GlobalKey(name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if healthcheck != nil && s.ownedDescription(healthcheck.Description) {
+			log.V(2).Info("Discovered healthcheck", "name", name)
+			status.APIServerHealthCheck = pointer.String(healthcheck.SelfLink)
+		}
+	}
+
+	if status.APIServerBackendService == nil {
+		name := s.scope.BackendServiceSpec().Name
+		backendsvc, err := s.backendservices.Get(ctx, meta.GlobalKey(name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if backendsvc != nil && s.ownedDescription(backendsvc.Description) {
+			log.V(2).Info("Discovered backendservice", "name", name)
+			status.APIServerBackendService = pointer.String(backendsvc.SelfLink)
+		}
+	}
+
+	if status.APIServerTargetProxy == nil {
+		name := s.scope.TargetTCPProxySpec().Name
+		target, err := s.targettcpproxies.Get(ctx, meta.GlobalKey(name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if target != nil && s.ownedDescription(target.Description) {
+			log.V(2).Info("Discovered target tcp proxy", "name", name)
+			status.APIServerTargetProxy = pointer.String(target.SelfLink)
+		}
+	}
+
+	if status.APIServerAddress == nil {
+		name := s.scope.AddressSpec().Name
+		addr, err := s.addresses.Get(ctx, meta.GlobalKey(name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if addr != nil && s.ownedLabels(addr.Labels) {
+			log.V(2).Info("Discovered address", "name", name)
+			status.APIServerAddress = pointer.String(addr.SelfLink)
+		}
+	}
+
+	if status.APIServerForwardingRule == nil {
+		name := s.scope.ForwardingRuleSpec().Name
+		forwarding, err := s.forwardingrules.Get(ctx, meta.GlobalKey(name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if forwarding != nil && s.ownedLabels(forwarding.Labels) {
+			log.V(2).Info("Discovered forwardingrule", "name", name)
+			status.APIServerForwardingRule = pointer.String(forwarding.SelfLink)
+		}
+	}
+
+	return nil
+}
+
+// owner returns the description of the resources created by capg for the cluster.
+func (s *Service) owner() string {
+	return infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name()))
+}
+
+// ownedDescription reports whether the description marks the resource as created by capg for the cluster.
+// The resources of the load balancer created before capg described them have no description at all.
+func (s *Service) ownedDescription(description string) bool {
+	return description == "" || description == s.owner()
+}
+
+// ownedLabels reports whether the labels do not mark the resource as owned by another cluster.
+// The resources created before capg labelled them have no ownership label at all.
+func (s *Service) ownedLabels(labels map[string]string) bool {
+	if infrav1.Labels(labels).HasOwned(s.scope.ResourceName(s.scope.Name())) {
+		return true
+	}
+
+	prefix := infrav1.SanitizeLabelKey(infrav1.NameGCPProviderOwned)
+	for key := range labels {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
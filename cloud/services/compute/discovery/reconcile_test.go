@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+	},
+}
+
+func getService(clusterScope *scope.ClusterScope, mockGCE *cloud.MockGCE) *Service {
+	s := New(clusterScope)
+	s.networks = mockGCE.Networks()
+	s.routers = mockGCE.Routers()
+	s.regions = mockGCE.Regions()
+	s.zones = mockGCE.Zones()
+	s.addresses = mockGCE.GlobalAddresses()
+	s.backendservices = mockGCE.BackendServices()
+	s.forwardingrules = mockGCE.GlobalForwardingRules()
+	s.healthchecks = mockGCE.HealthChecks()
+	s.instancegroups = mockGCE.InstanceGroups()
+	s.targettcpproxies = mockGCE.TargetTcpProxies()
+	return s
+}
+
+// insertClusterResources creates the resources of the cluster in the mock and returns it.
+func insertClusterResources(ctx context.Context, t *testing.T, clusterScope *scope.ClusterScope) *cloud.MockGCE {
+	t.Helper()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "my-proj"})
+	owner := infrav1.ClusterTagKey(clusterScope.Name())
+	regionLink := "https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1"
+	mockGCE.MockRegions.Objects[*meta.GlobalKey("us-central1")] = &cloud.MockRegionsObj{Obj: &compute.Region{Name: "us-central1", SelfLink: regionLink}}
+	mockGCE.MockZones.Objects[*meta.GlobalKey("us-central1-a")] = &cloud.MockZonesObj{Obj: &compute.Zone{Name: "us-central1-a", Region: regionLink}}
+	mockGCE.MockZones.Objects[*meta.GlobalKey("us-central1-b")] = &cloud.MockZonesObj{Obj: &compute.Zone{Name: "us-central1-b", Region: regionLink}}
+	for _, err := range []error{
+		mockGCE.Networks().Insert(ctx, meta.GlobalKey(clusterScope.NetworkName()), &compute.Network{Description: owner}),
+		mockGCE.Routers().Insert(ctx, meta.RegionalKey(clusterScope.NatRouterSpec().Name, "us-central1"), &compute.Router{Description: owner}),
+		mockGCE.InstanceGroups().Insert(ctx, meta.ZonalKey(clusterScope.InstanceGroupSpec("us-central1-a").Name, "us-central1-a"), &compute.InstanceGroup{Description: owner}),
+		// The healthcheck was created before capg described the resources of the load balancer.
+		mockGCE.HealthChecks().Insert(ctx, meta.GlobalKey(clusterScope.HealthCheckSpec().Name), &compute.HealthCheck{}),
+		mockGCE.BackendServices().Insert(ctx, meta.GlobalKey(clusterScope.BackendServiceSpec().Name), &compute.BackendService{Description: owner}),
+		mockGCE.TargetTcpProxies().Insert(ctx, meta.GlobalKey(clusterScope.TargetTCPProxySpec().Name), &compute.TargetTcpProxy{Description: owner}),
+		mockGCE.GlobalAddresses().Insert(ctx, meta.GlobalKey(clusterScope.AddressSpec().Name), &compute.Address{Labels: clusterScope.AddressSpec().Labels}),
+		mockGCE.GlobalForwardingRules().Insert(ctx, meta.GlobalKey(clusterScope.ForwardingRuleSpec().Name), &compute.ForwardingRule{}),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return mockGCE
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := insertClusterResources(ctx, t, clusterScope)
+
+	if err := getService(clusterScope, mockGCE).Delete(ctx); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	network := clusterScope.Network()
+	for name, link := range map[string]*string{
+		"SelfLink":                network.SelfLink,
+		"Router":                  network.Router,
+		"APIServerHealthCheck":    network.APIServerHealthCheck,
+		"APIServerBackendService": network.APIServerBackendService,
+		"APIServerTargetProxy":    network.APIServerTargetProxy,
+		"APIServerAddress":        network.APIServerAddress,
+		"APIServerForwardingRule": network.APIServerForwardingRule,
+	} {
+		if pointer.StringDeref(link, "") == "" {
+			t.Errorf("expected %s to be discovered", name)
+		}
+	}
+
+	if len(network.APIServerInstanceGroups) != 1 || network.APIServerInstanceGroups["us-central1-a"] == "" {
+		t.Errorf("APIServerInstanceGroups = %v, want the group of us-central1-a", network.APIServerInstanceGroups)
+	}
+}
+
+func TestService_ReconcileKeepsStatus(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := insertClusterResources(ctx, t, clusterScope)
+	clusterScope.Network().APIServerHealthCheck = pointer.String("existing")
+	clusterScope.Network().APIServerInstanceGroups = map[string]string{"us-central1-b": "existing"}
+
+	if err := getService(clusterScope, mockGCE).Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if got := pointer.StringDeref(clusterScope.Network().APIServerHealthCheck, ""); got != "existing" {
+		t.Errorf("APIServerHealthCheck = %q, want the existing status", got)
+	}
+	if got := clusterScope.Network().APIServerInstanceGroups; len(got) != 2 || got["us-central1-b"] != "existing" {
+		t.Errorf("APIServerInstanceGroups = %v, want the existing and the discovered groups", got)
+	}
+}
+
+func TestService_ReconcileNotOwned(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "my-proj"})
+	_ = mockGCE.Networks().Insert(ctx, meta.GlobalKey(clusterScope.NetworkName()), &compute.Network{Description: "user managed"})
+	_ = mockGCE.Routers().Insert(ctx, meta.RegionalKey(clusterScope.NatRouterSpec().Name, "us-central1"), &compute.Router{})
+	_ = mockGCE.GlobalAddresses().Insert(ctx, meta.GlobalKey(clusterScope.AddressSpec().Name), &compute.Address{
+		Labels: map[string]string{infrav1.SanitizeLabelKey(infrav1.ClusterTagKey("other-cluster")): string(infrav1.ResourceLifecycleOwned)},
+	})
+	other := infrav1.ClusterTagKey("other-cluster")
+	_ = mockGCE.InstanceGroups().Insert(ctx, meta.ZonalKey(clusterScope.InstanceGroupSpec("us-central1-a").Name, "us-central1-a"), &compute.InstanceGroup{Description: other})
+	_ = mockGCE.HealthChecks().Insert(ctx, meta.GlobalKey(clusterScope.HealthCheckSpec().Name), &compute.HealthCheck{Description: other})
+	_ = mockGCE.BackendServices().Insert(ctx, meta.GlobalKey(clusterScope.BackendServiceSpec().Name), &compute.BackendService{Description: other})
+	_ = mockGCE.TargetTcpProxies().Insert(ctx, meta.GlobalKey(clusterScope.TargetTCPProxySpec().Name), &compute.TargetTcpProxy{Description: other})
+	clusterScope.SetFailureDomains(clusterv1.FailureDomains{"us-central1-a": clusterv1.FailureDomainSpec{}})
+
+	if err := getService(clusterScope, mockGCE).Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	network := clusterScope.Network()
+	if network.SelfLink == nil {
+		t.Errorf("expected the network to be discovered")
+	}
+	if network.Router != nil {
+		t.Errorf("Router = %q, want no router in a network not owned by the cluster", *network.Router)
+	}
+	if network.APIServerAddress != nil {
+		t.Errorf("APIServerAddress = %q, want no address owned by another cluster", *network.APIServerAddress)
+	}
+	for name, link := range map[string]*string{
+		"APIServerHealthCheck":    network.APIServerHealthCheck,
+		"APIServerBackendService": network.APIServerBackendService,
+		"APIServerTargetProxy":    network.APIServerTargetProxy,
+	} {
+		if link != nil {
+			t.Errorf("%s = %q, want none owned by another cluster", name, *link)
+		}
+	}
+	if network.APIServerInstanceGroups != nil {
+		t.Errorf("APIServerInstanceGroups = %v, want none", network.APIServerInstanceGroups)
+	}
+}
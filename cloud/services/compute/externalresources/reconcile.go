@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalresources
+
+import (
+	"context"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// serviceDescriptionMarker is the key the cloud controller manager writes in the description
+	// of the load balancer resources it creates for a Service.
+	serviceDescriptionMarker = `"kubernetes.io/service-name"`
+
+	// csiDescriptionMarker is the value the PD CSI driver writes in the description of the disks it provisions.
+	csiDescriptionMarker = "pd.csi.storage.gke.io"
+
+	// firewallPrefix is the prefix of the firewall rules the cloud controller manager creates for external load balancers.
+	firewallPrefix = "k8s-fw-"
+)
+
+// Reconcile is a no-op, the resources are created by the workload cluster.
+func (s *Service) Reconcile(_ context.Context) error {
+	return nil
+}
+
+// Delete deletes the cloud resources left behind by the workload cluster cloud controller manager
+// and CSI driver in the cluster network, so that the network can be deleted.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if !s.scope.ExternalResourceGCEnabled() {
+		log.V(2).Info("Garbage collection of external resources is disabled")
+		return nil
+	}
+
+	network, err := s.networks.Get(ctx, meta.GlobalKey(s.scope.NetworkName()))
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+
+	// Resources in a network that is not managed by CAPG may be shared with other clusters.
+	if network.Description != infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
+		log.V(2).Info("Network is not owned by the cluster, skipping garbage collection of external resources", "name", s.scope.NetworkName())
+		return nil
+	}
+
+	log.Info("Deleting external resources")
+	firewalls, err := s.firewalls.List(ctx, filter.None)
+	if err != nil {
+		return err
+	}
+
+	// Load balancer resources share the name of their firewall rule, without the prefix.
+	lbNames := sets.New[string]()
+	ccmFirewalls := []string{}
+	for _, fw := range firewalls {
+		if !matchesLink(fw.Network, s.scope.NetworkLink()) || !isServiceResource(fw.Name, fw.Description) {
+			continue
+		}
+
+		ccmFirewalls = append(ccmFirewalls, fw.Name)
+		lbNames.Insert(strings.TrimPrefix(fw.Name, firewallPrefix))
+	}
+
+	if err := s.deleteForwardingRules(ctx, lbNames); err != nil {
+		return err
+	}
+
+	if err := s.deleteBackendServices(ctx, lbNames); err != nil {
+		return err
+	}
+
+	if err := s.deleteTargetPools(ctx, lbNames); err != nil {
+		return err
+	}
+
+	for _, name := range ccmFirewalls {
+		log.V(2).Info("Deleting external firewall", "name", name)
+		if err := s.firewalls.Delete(ctx, meta.GlobalKey(name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting external firewall", "name", name)
+			return err
+		}
+	}
+
+	return s.deleteDisks(ctx)
+}
+
+func (s *Service) deleteForwardingRules(ctx context.Context, lbNames sets.Set[string]) error {
+	log := log.FromContext(ctx)
+	rules, err := s.forwardingrules.List(ctx, s.scope.Region(), filter.None)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if !isServiceResource(rule.Name, rule.Description) {
+			continue
+		}
+
+		if !lbNames.Has(rule.Name) && !matchesLink(rule.Network, s.scope.NetworkLink()) {
+			continue
+		}
+
+		log.V(2).Info("Deleting external forwarding rule", "name", rule.Name)
+		if err := s.forwardingrules.Delete(ctx, meta.RegionalKey(rule.Name, s.scope.Region())); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting external forwarding rule", "name", rule.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteBackendServices(ctx context.Context, lbNames sets.Set[string]) error {
+	log := log.FromContext(ctx)
+	backendServices, err := s.backendservices.List(ctx, s.scope.Region(), filter.None)
+	if err != nil {
+		return err
+	}
+
+	for _, bs := range backendServices {
+		if !isServiceResource(bs.Name, bs.Description) {
+			continue
+		}
+
+		if !lbNames.Has(bs.Name) && !matchesLink(bs.Network, s.scope.NetworkLink()) {
+			continue
+		}
+
+		log.V(2).Info("Deleting external backend service", "name", bs.Name)
+		if err := s.backendservices.Delete(ctx, meta.RegionalKey(bs.Name, s.scope.Region())); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting external backend service", "name", bs.Name)
+			return err
+		}
+
+		for _, hc := range bs.HealthChecks {
+			// Health checks may be shared by several load balancers, keep the ones still in use.
+			name := resourceName(hc)
+			if err := s.healthchecks.Delete(ctx, meta.RegionalKey(name, s.scope.Region())); err != nil && !gcperrors.IsNotFound(err) {
+				log.V(2).Info("Unable to delete external health check", "name", name, "reason", err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteTargetPools(ctx context.Context, lbNames sets.Set[string]) error {
+	log := log.FromContext(ctx)
+	pools, err := s.targetpools.List(ctx, s.scope.Region(), filter.None)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		if !lbNames.Has(pool.Name) {
+			continue
+		}
+
+		log.V(2).Info("Deleting external target pool", "name", pool.Name)
+		if err := s.targetpools.Delete(ctx, meta.RegionalKey(pool.Name, s.scope.Region())); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting external target pool", "name", pool.Name)
+			return err
+		}
+
+		for _, hc := range pool.HealthChecks {
+			// The shared node health check is still used by the target pools of other load balancers.
+			name := resourceName(hc)
+			if err := s.httphealthchecks.Delete(ctx, meta.GlobalKey(name)); err != nil && !gcperrors.IsNotFound(err) {
+				log.V(2).Info("Unable to delete external http health check", "name", name, "reason", err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteDisks deletes the unattached disks provisioned by the PD CSI driver for the cluster.
+// The driver must be configured to label the disks with the cluster ownership label,
+// e.g. --extra-labels=capg-cluster-<cluster name>=owned.
+func (s *Service) deleteDisks(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	region, err := s.regions.Get(ctx, meta.GlobalKey(s.scope.Region()))
+	if err != nil {
+		return err
+	}
+
+	zones, err := s.zones.List(ctx, filter.Regexp("region", region.SelfLink))
+	if err != nil {
+		return err
+	}
+
+	for _, zone := range zones {
+		disks, err := s.disks.List(ctx, zone.Name, filter.None)
+		if err != nil {
+			return err
+		}
+
+		for _, disk := range disks {
+			if !infrav1.Labels(disk.Labels).HasOwned(s.scope.Name()) || !strings.Contains(disk.Description, csiDescriptionMarker) || len(disk.Users) > 0 {
+				continue
+			}
+
+			log.V(2).Info("Deleting external disk", "name", disk.Name, "zone", zone.Name)
+			if err := s.disks.Delete(ctx, meta.ZonalKey(disk.Name, zone.Name)); err != nil && !gcperrors.IsNotFound(err) {
+				log.Error(err, "Error deleting external disk", "name", disk.Name)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isServiceResource reports whether a resource was created by the cloud controller manager for a Service.
+func isServiceResource(name, description string) bool {
+	return strings.Contains(description, serviceDescriptionMarker) || strings.HasPrefix(name, firewallPrefix) || strings.HasPrefix(name, "k8s2-")
+}
+
+// matchesLink reports whether the link points to the resource identified by the partial URL.
+func matchesLink(link, partial string) bool {
+	return link != "" && strings.HasSuffix(link, partial)
+}
+
+// resourceName returns the last segment of a resource URL.
+func resourceName(link string) string {
+	return link[strings.LastIndex(link, "/")+1:]
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalresources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+	},
+}
+
+const (
+	networkLink     = "https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/default"
+	otherLink       = "https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/other"
+	lbName          = "a1b2c3d4e5f6"
+	lbDescription   = `{"kubernetes.io/service-name":"default/my-svc"}`
+	diskDescription = `{"kubernetes.io/created-for/pv/name":"pvc-1","storage.gke.io/created-by":"pd.csi.storage.gke.io"}`
+)
+
+type mocks struct {
+	firewalls       *cloud.MockFirewalls
+	forwardingrules *cloud.MockForwardingRules
+	targetpools     *cloud.MockTargetPools
+	disks           *cloud.MockDisks
+}
+
+func getService(t *testing.T, gcpCluster *infrav1.GCPCluster, networkDescription string) (*Service, mocks) {
+	t.Helper()
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: gcpCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pr := &cloud.SingleProjectRouter{ID: "my-proj"}
+	m := mocks{
+		firewalls:       cloud.NewMockFirewalls(pr, map[meta.Key]*cloud.MockFirewallsObj{}),
+		forwardingrules: cloud.NewMockForwardingRules(pr, map[meta.Key]*cloud.MockForwardingRulesObj{}),
+		targetpools:     cloud.NewMockTargetPools(pr, map[meta.Key]*cloud.MockTargetPoolsObj{}),
+		disks:           cloud.NewMockDisks(pr, map[meta.Key]*cloud.MockDisksObj{}),
+	}
+	networks := cloud.NewMockNetworks(pr, map[meta.Key]*cloud.MockNetworksObj{})
+	regions := cloud.NewMockRegions(pr, map[meta.Key]*cloud.MockRegionsObj{})
+	zones := cloud.NewMockZones(pr, map[meta.Key]*cloud.MockZonesObj{})
+
+	mustInsert := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustInsert(networks.Insert(ctx, meta.GlobalKey("default"), &compute.Network{Description: networkDescription}))
+	regionLink := "https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1"
+	regions.Objects[*meta.GlobalKey("us-central1")] = &cloud.MockRegionsObj{Obj: &compute.Region{Name: "us-central1", SelfLink: regionLink}}
+	zones.Objects[*meta.GlobalKey("us-central1-a")] = &cloud.MockZonesObj{Obj: &compute.Zone{Name: "us-central1-a", Region: regionLink}}
+
+	mustInsert(m.firewalls.Insert(ctx, meta.GlobalKey("k8s-fw-"+lbName), &compute.Firewall{Network: networkLink, Description: lbDescription}))
+	mustInsert(m.firewalls.Insert(ctx, meta.GlobalKey("k8s-fw-other"), &compute.Firewall{Network: otherLink, Description: lbDescription}))
+	mustInsert(m.firewalls.Insert(ctx, meta.GlobalKey("allow-ssh"), &compute.Firewall{Network: networkLink}))
+	mustInsert(m.forwardingrules.Insert(ctx, meta.RegionalKey(lbName, "us-central1"), &compute.ForwardingRule{Description: lbDescription}))
+	mustInsert(m.forwardingrules.Insert(ctx, meta.RegionalKey("other", "us-central1"), &compute.ForwardingRule{Description: lbDescription}))
+	mustInsert(m.targetpools.Insert(ctx, meta.RegionalKey(lbName, "us-central1"), &compute.TargetPool{}))
+	mustInsert(m.disks.Insert(ctx, meta.ZonalKey("pvc-1", "us-central1-a"), &compute.Disk{
+		Description: diskDescription,
+		Labels:      map[string]string{infrav1.ClusterTagKey("my-cluster"): "owned"},
+	}))
+	mustInsert(m.disks.Insert(ctx, meta.ZonalKey("pvc-2", "us-central1-a"), &compute.Disk{Description: diskDescription}))
+
+	s := New(clusterScope)
+	s.networks = networks
+	s.firewalls = m.firewalls
+	s.forwardingrules = m.forwardingrules
+	s.backendservices = cloud.NewMockRegionBackendServices(pr, map[meta.Key]*cloud.MockRegionBackendServicesObj{})
+	s.targetpools = m.targetpools
+	s.httphealthchecks = cloud.NewMockHttpHealthChecks(pr, map[meta.Key]*cloud.MockHttpHealthChecksObj{})
+	s.healthchecks = cloud.NewMockRegionHealthChecks(pr, map[meta.Key]*cloud.MockRegionHealthChecksObj{})
+	s.regions = regions
+	s.zones = zones
+	s.disks = m.disks
+
+	return s, m
+}
+
+func exists(t *testing.T, err error) bool {
+	t.Helper()
+	if err != nil && !gcperrors.IsNotFound(err) {
+		t.Fatal(err)
+	}
+
+	return err == nil
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.TODO()
+	s, m := getService(t, fakeGCPCluster.DeepCopy(), infrav1.ClusterTagKey("my-cluster"))
+	if err := s.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	_, err := m.firewalls.Get(ctx, meta.GlobalKey("k8s-fw-"+lbName))
+	if exists(t, err) {
+		t.Error("expected firewall of the load balancer to be deleted")
+	}
+	_, err = m.forwardingrules.Get(ctx, meta.RegionalKey(lbName, "us-central1"))
+	if exists(t, err) {
+		t.Error("expected forwarding rule of the load balancer to be deleted")
+	}
+	_, err = m.targetpools.Get(ctx, meta.RegionalKey(lbName, "us-central1"))
+	if exists(t, err) {
+		t.Error("expected target pool of the load balancer to be deleted")
+	}
+	_, err = m.disks.Get(ctx, meta.ZonalKey("pvc-1", "us-central1-a"))
+	if exists(t, err) {
+		t.Error("expected disk owned by the cluster to be deleted")
+	}
+
+	_, err = m.firewalls.Get(ctx, meta.GlobalKey("k8s-fw-other"))
+	if !exists(t, err) {
+		t.Error("expected firewall in another network to be kept")
+	}
+	_, err = m.firewalls.Get(ctx, meta.GlobalKey("allow-ssh"))
+	if !exists(t, err) {
+		t.Error("expected firewall not created by the cloud controller manager to be kept")
+	}
+	_, err = m.forwardingrules.Get(ctx, meta.RegionalKey("other", "us-central1"))
+	if !exists(t, err) {
+		t.Error("expected forwarding rule of another cluster to be kept")
+	}
+	_, err = m.disks.Get(ctx, meta.ZonalKey("pvc-2", "us-central1-a"))
+	if !exists(t, err) {
+		t.Error("expected disk not owned by the cluster to be kept")
+	}
+}
+
+func TestService_DeleteSkipped(t *testing.T) {
+	optedOut := fakeGCPCluster.DeepCopy()
+	optedOut.Annotations = map[string]string{infrav1.ExternalResourceGCAnnotation: "false"}
+
+	tests := []struct {
+		name               string
+		gcpCluster         *infrav1.GCPCluster
+		networkDescription string
+	}{
+		{
+			name:               "cluster opted out",
+			gcpCluster:         optedOut,
+			networkDescription: infrav1.ClusterTagKey("my-cluster"),
+		},
+		{
+			name:               "network not owned by the cluster",
+			gcpCluster:         fakeGCPCluster.DeepCopy(),
+			networkDescription: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s, m := getService(t, tt.gcpCluster, tt.networkDescription)
+			if err := s.Delete(ctx); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+
+			_, err := m.firewalls.Get(ctx, meta.GlobalKey("k8s-fw-"+lbName))
+			if !exists(t, err) {
+				t.Error("expected firewall to be kept")
+			}
+			_, err = m.disks.Get(ctx, meta.ZonalKey("pvc-1", "us-central1-a"))
+			if !exists(t, err) {
+				t.Error("expected disk to be kept")
+			}
+		})
+	}
+}
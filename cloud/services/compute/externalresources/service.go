@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalresources
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type networksInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Network, error)
+}
+
+type firewallsInterface interface {
+	List(ctx context.Context, fl *filter.F) ([]*compute.Firewall, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type forwardingrulesInterface interface {
+	List(ctx context.Context, region string, fl *filter.F) ([]*compute.ForwardingRule, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type backendservicesInterface interface {
+	List(ctx context.Context, region string, fl *filter.F) ([]*compute.BackendService, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type targetpoolsInterface interface {
+	List(ctx context.Context, region string, fl *filter.F) ([]*compute.TargetPool, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type httphealthchecksInterface interface {
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type healthchecksInterface interface {
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type regionsInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Region, error)
+}
+
+type zonesInterface interface {
+	List(ctx context.Context, fl *filter.F) ([]*compute.Zone, error)
+}
+
+type disksInterface interface {
+	List(ctx context.Context, zone string, fl *filter.F) ([]*compute.Disk, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.ClusterGetter
+	NetworkLink() string
+	ExternalResourceGCEnabled() bool
+}
+
+// Service implements the garbage collection of cloud-provider-created resources.
+type Service struct {
+	scope            Scope
+	networks         networksInterface
+	firewalls        firewallsInterface
+	forwardingrules  forwardingrulesInterface
+	backendservices  backendservicesInterface
+	targetpools      targetpoolsInterface
+	httphealthchecks httphealthchecksInterface
+	healthchecks     healthchecksInterface
+	regions          regionsInterface
+	zones            zonesInterface
+	disks            disksInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope:            scope,
+		networks:         scope.Cloud().Networks(),
+		firewalls:        scope.Cloud().Firewalls(),
+		forwardingrules:  scope.Cloud().ForwardingRules(),
+		backendservices:  scope.Cloud().RegionBackendServices(),
+		targetpools:      scope.Cloud().TargetPools(),
+		httphealthchecks: scope.Cloud().HttpHealthChecks(),
+		healthchecks:     scope.Cloud().RegionHealthChecks(),
+		regions:          scope.Cloud().Regions(),
+		zones:            scope.Cloud().Zones(),
+		disks:            scope.Cloud().Disks(),
+	}
+}
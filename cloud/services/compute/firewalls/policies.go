@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewalls
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/operations"
+)
+
+type firewallPoliciesInterface interface {
+	Get(ctx context.Context, name string) (*compute.FirewallPolicy, error)
+	Insert(ctx context.Context, obj *compute.FirewallPolicy) error
+	Delete(ctx context.Context, name string) error
+	AddAssociation(ctx context.Context, name string, obj *compute.FirewallPolicyAssociation) error
+	RemoveAssociation(ctx context.Context, name, associationName string) error
+	AddRule(ctx context.Context, name string, obj *compute.FirewallPolicyRule) error
+	PatchRule(ctx context.Context, name string, obj *compute.FirewallPolicyRule) error
+}
+
+// networkFirewallPolicies implements firewallPoliciesInterface using the GA compute API,
+// since the cloud wrapper only exposes the alpha network firewall policies.
+// Mutating calls return an operations.PendingError while the global operation is running.
+type networkFirewallPolicies struct {
+	service     *compute.Service
+	project     string
+	rateLimiter cloud.RateLimiter
+}
+
+var _ firewallPoliciesInterface = &networkFirewallPolicies{}
+
+func (p *networkFirewallPolicies) Get(ctx context.Context, name string) (*compute.FirewallPolicy, error) {
+	var policy *compute.FirewallPolicy
+	err := observer.Call(ctx, p.rateLimiter, p.key("Get"), func() (err error) {
+		policy, err = p.service.NetworkFirewallPolicies.Get(p.project, name).Context(ctx).Do()
+		return err
+	})
+	return policy, err
+}
+
+func (p *networkFirewallPolicies) Insert(ctx context.Context, obj *compute.FirewallPolicy) error {
+	return p.do(ctx, "Insert", p.service.NetworkFirewallPolicies.Insert(p.project, obj).Context(ctx).Do)
+}
+
+func (p *networkFirewallPolicies) Delete(ctx context.Context, name string) error {
+	return p.do(ctx, "Delete", p.service.NetworkFirewallPolicies.Delete(p.project, name).Context(ctx).Do)
+}
+
+func (p *networkFirewallPolicies) AddAssociation(ctx context.Context, name string, obj *compute.FirewallPolicyAssociation) error {
+	return p.do(ctx, "AddAssociation", p.service.NetworkFirewallPolicies.AddAssociation(p.project, name, obj).Context(ctx).Do)
+}
+
+func (p *networkFirewallPolicies) RemoveAssociation(ctx context.Context, name, associationName string) error {
+	return p.do(ctx, "RemoveAssociation", p.service.NetworkFirewallPolicies.RemoveAssociation(p.project, name).Name(associationName).Context(ctx).Do)
+}
+
+func (p *networkFirewallPolicies) AddRule(ctx context.Context, name string, obj *compute.FirewallPolicyRule) error {
+	return p.do(ctx, "AddRule", p.service.NetworkFirewallPolicies.AddRule(p.project, name, obj).Context(ctx).Do)
+}
+
+func (p *networkFirewallPolicies) PatchRule(ctx context.Context, name string, obj *compute.FirewallPolicyRule) error {
+	return p.do(ctx, "PatchRule", p.service.NetworkFirewallPolicies.PatchRule(p.project, name, obj).Priority(obj.Priority).Context(ctx).Do)
+}
+
+func (p *networkFirewallPolicies) do(ctx context.Context, operation string, call func(...googleapi.CallOption) (*compute.Operation, error)) error {
+	return operations.Do(ctx, p.rateLimiter, p.key(operation), call)
+}
+
+func (p *networkFirewallPolicies) key(operation string) *cloud.RateLimitKey {
+	return &cloud.RateLimitKey{ProjectID: p.project, Operation: operation, Version: meta.VersionGA, Service: "NetworkFirewallPolicies"}
+}
@@ -18,8 +18,13 @@ package firewalls
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -28,6 +33,10 @@ import (
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling firewall resources")
+	if s.scope.FirewallPolicy() != nil {
+		return s.reconcileFirewallPolicy(ctx)
+	}
+
 	for _, spec := range s.scope.FirewallRulesSpec() {
 		log.V(2).Info("Looking firewall", "name", spec.Name)
 		firewallKey := meta.GlobalKey(spec.Name)
@@ -61,5 +70,216 @@ func (s *Service) Delete(ctx context.Context) error {
 		}
 	}
 
+	if !s.usesFirewallPolicy() {
+		return nil
+	}
+
+	return s.deleteFirewallPolicy(ctx)
+}
+
+// Orphan returns the firewall rules of the spec and the firewall policy created by capg, which are left behind
+// instead of being deleted.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx)
+	orphaned := []string{}
+	for _, spec := range s.scope.FirewallRulesSpec() {
+		log.V(2).Info("Looking for firewall before orphaning", "name", spec.Name)
+		firewall, err := s.firewalls.Get(ctx, meta.GlobalKey(spec.Name))
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				continue
+			}
+
+			log.Error(err, "Error looking for firewall", "name", spec.Name)
+			return nil, err
+		}
+
+		orphaned = append(orphaned, firewall.SelfLink)
+	}
+
+	if !s.usesFirewallPolicy() {
+		return orphaned, nil
+	}
+
+	spec := s.scope.FirewallPolicySpec()
+	policy, err := s.firewallpolicies.Get(ctx, spec.Name)
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			return orphaned, nil
+		}
+
+		log.Error(err, "Error looking for firewall policy", "name", spec.Name)
+		return nil, err
+	}
+
+	if policy.Description == infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
+		orphaned = append(orphaned, policy.SelfLink)
+	}
+
+	return orphaned, nil
+}
+
+func (s *Service) reconcileFirewallPolicy(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	config := s.scope.FirewallPolicy()
+	controlPlaneTag, err := s.tagValueName(ctx, config.ControlPlaneTag)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve control plane tag value: %w", err)
+	}
+
+	nodeTag, err := s.tagValueName(ctx, config.NodeTag)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve node tag value: %w", err)
+	}
+
+	spec := s.scope.FirewallPolicySpec()
+	log.V(2).Info("Looking firewall policy", "name", spec.Name)
+	policy, err := s.firewallpolicies.Get(ctx, spec.Name)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for firewall policy", "name", spec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating firewall policy", "name", spec.Name)
+		if err := s.firewallpolicies.Insert(ctx, spec); err != nil {
+			log.Error(err, "Error creating firewall policy", "name", spec.Name)
+			return err
+		}
+
+		policy, err = s.firewallpolicies.Get(ctx, spec.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if policy.Description != spec.Description {
+		return fmt.Errorf("firewall policy %s already exists and is not owned by this cluster", spec.Name)
+	}
+
+	association := s.scope.FirewallPolicyAssociationSpec()
+	if !hasAssociation(policy, association) {
+		log.V(2).Info("Associating firewall policy", "name", spec.Name, "network", association.AttachmentTarget)
+		if err := s.firewallpolicies.AddAssociation(ctx, spec.Name, association); err != nil {
+			log.Error(err, "Error associating firewall policy", "name", spec.Name)
+			return err
+		}
+	}
+
+	existing := make(map[int64]*compute.FirewallPolicyRule, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		existing[rule.Priority] = rule
+	}
+
+	for _, rule := range s.scope.FirewallPolicyRulesSpec(controlPlaneTag, nodeTag) {
+		current, ok := existing[rule.Priority]
+		switch {
+		case !ok:
+			log.V(2).Info("Adding firewall policy rule", "name", rule.RuleName, "priority", rule.Priority)
+			if err := s.firewallpolicies.AddRule(ctx, spec.Name, rule); err != nil {
+				log.Error(err, "Error adding firewall policy rule", "name", rule.RuleName)
+				return err
+			}
+		case !ruleMatchesSpec(current, rule):
+			log.V(2).Info("Updating firewall policy rule", "name", rule.RuleName, "priority", rule.Priority)
+			if err := s.firewallpolicies.PatchRule(ctx, spec.Name, rule); err != nil {
+				log.Error(err, "Error updating firewall policy rule", "name", rule.RuleName)
+				return err
+			}
+		}
+	}
+
+	s.scope.Network().FirewallPolicy = &policy.SelfLink
+	return nil
+}
+
+func (s *Service) deleteFirewallPolicy(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.FirewallPolicySpec()
+	policy, err := s.firewallpolicies.Get(ctx, spec.Name)
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			s.scope.Network().FirewallPolicy = nil
+			return nil
+		}
+
+		log.Error(err, "Error looking for firewall policy", "name", spec.Name)
+		return err
+	}
+
+	if policy.Description != infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
+		log.V(2).Info("Firewall policy is not owned by this cluster, skipping deletion", "name", spec.Name)
+		return nil
+	}
+
+	association := s.scope.FirewallPolicyAssociationSpec()
+	if hasAssociation(policy, association) {
+		log.V(2).Info("Removing firewall policy association", "name", spec.Name, "association", association.Name)
+		if err := s.firewallpolicies.RemoveAssociation(ctx, spec.Name, association.Name); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error removing firewall policy association", "name", spec.Name)
+			return err
+		}
+	}
+
+	log.V(2).Info("Deleting firewall policy", "name", spec.Name)
+	if err := s.firewallpolicies.Delete(ctx, spec.Name); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting firewall policy", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().FirewallPolicy = nil
 	return nil
 }
+
+// usesFirewallPolicy reports whether the cluster is configured with a firewall policy or one was created for it,
+// so clusters that never used a policy don't look one up on deletion.
+func (s *Service) usesFirewallPolicy() bool {
+	return s.scope.FirewallPolicy() != nil || s.scope.Network().FirewallPolicy != nil
+}
+
+// hasAssociation reports whether the policy is already associated with the spec attachment target.
+func hasAssociation(policy *compute.FirewallPolicy, spec *compute.FirewallPolicyAssociation) bool {
+	for _, association := range policy.Associations {
+		if association.Name == spec.Name || association.AttachmentTarget == spec.AttachmentTarget {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ruleMatchesSpec compares the fields of a firewall policy rule managed by the reconciler.
+func ruleMatchesSpec(rule, spec *compute.FirewallPolicyRule) bool {
+	if rule.Action != spec.Action || rule.Direction != spec.Direction || rule.Match == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(sortedStrings(rule.Match.SrcIpRanges), sortedStrings(spec.Match.SrcIpRanges)) &&
+		reflect.DeepEqual(layer4Configs(rule.Match.Layer4Configs), layer4Configs(spec.Match.Layer4Configs)) &&
+		reflect.DeepEqual(secureTagNames(rule.Match.SrcSecureTags), secureTagNames(spec.Match.SrcSecureTags)) &&
+		reflect.DeepEqual(secureTagNames(rule.TargetSecureTags), secureTagNames(spec.TargetSecureTags))
+}
+
+func layer4Configs(configs []*compute.FirewallPolicyRuleMatcherLayer4Config) []string {
+	out := make([]string, 0, len(configs))
+	for _, c := range configs {
+		out = append(out, fmt.Sprintf("%s:%v", c.IpProtocol, sortedStrings(c.Ports)))
+	}
+
+	return sortedStrings(out)
+}
+
+func secureTagNames(tags []*compute.FirewallPolicyRuleSecureTag) []string {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, t.Name)
+	}
+
+	return sortedStrings(out)
+}
+
+func sortedStrings(in []string) []string {
+	out := append([]string{}, in...)
+	sort.Strings(out)
+	return out
+}
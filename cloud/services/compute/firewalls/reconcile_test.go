@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewalls
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			FirewallPolicy: &infrav1.FirewallPolicySpec{
+				ControlPlaneTag: infrav1.ResourceManagerTag{ParentID: "my-proj", Key: "role", Value: "control-plane"},
+				NodeTag:         infrav1.ResourceManagerTag{ParentID: "my-proj", Key: "role", Value: "node"},
+			},
+		},
+	},
+}
+
+// fakeFirewallPolicies is an in-memory firewallPoliciesInterface.
+type fakeFirewallPolicies struct {
+	policies map[string]*compute.FirewallPolicy
+	gets     int
+}
+
+func (f *fakeFirewallPolicies) Get(_ context.Context, name string) (*compute.FirewallPolicy, error) {
+	f.gets++
+	policy, ok := f.policies[name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	return policy, nil
+}
+
+func (f *fakeFirewallPolicies) Insert(_ context.Context, obj *compute.FirewallPolicy) error {
+	obj.SelfLink = "https://www.googleapis.com/compute/v1/projects/my-proj/global/firewallPolicies/" + obj.Name
+	f.policies[obj.Name] = obj
+	return nil
+}
+
+func (f *fakeFirewallPolicies) Delete(_ context.Context, name string) error {
+	delete(f.policies, name)
+	return nil
+}
+
+func (f *fakeFirewallPolicies) AddAssociation(_ context.Context, name string, obj *compute.FirewallPolicyAssociation) error {
+	f.policies[name].Associations = append(f.policies[name].Associations, obj)
+	return nil
+}
+
+func (f *fakeFirewallPolicies) RemoveAssociation(_ context.Context, name, associationName string) error {
+	policy := f.policies[name]
+	associations := policy.Associations[:0]
+	for _, a := range policy.Associations {
+		if a.Name != associationName {
+			associations = append(associations, a)
+		}
+	}
+	policy.Associations = associations
+	return nil
+}
+
+func (f *fakeFirewallPolicies) AddRule(_ context.Context, name string, obj *compute.FirewallPolicyRule) error {
+	f.policies[name].Rules = append(f.policies[name].Rules, obj)
+	return nil
+}
+
+func (f *fakeFirewallPolicies) PatchRule(_ context.Context, name string, obj *compute.FirewallPolicyRule) error {
+	for i, rule := range f.policies[name].Rules {
+		if rule.Priority == obj.Priority {
+			f.policies[name].Rules[i] = obj
+		}
+	}
+	return nil
+}
+
+func fakeTagValueName(_ context.Context, tag infrav1.ResourceManagerTag) (string, error) {
+	return "tagValues/" + tag.Value, nil
+}
+
+func TestService_ReconcileFirewallPolicy(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policies := &fakeFirewallPolicies{policies: map[string]*compute.FirewallPolicy{}}
+	mockFirewalls := &cloud.MockFirewalls{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+	}
+	s := New(clusterScope)
+	s.firewalls = mockFirewalls
+	s.firewallpolicies = policies
+	s.tagValueName = fakeTagValueName
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if len(mockFirewalls.Objects) != 0 {
+		t.Errorf("expected no VPC firewall rules, got %d", len(mockFirewalls.Objects))
+	}
+
+	policy, ok := policies.policies["my-cluster-firewall-policy"]
+	if !ok {
+		t.Fatal("expected firewall policy to be created")
+	}
+	if len(policy.Associations) != 1 || policy.Associations[0].AttachmentTarget != clusterScope.NetworkLink() {
+		t.Errorf("unexpected associations: %+v", policy.Associations)
+	}
+	if len(policy.Rules) != 2 {
+		t.Errorf("expected 2 rules, got %d", len(policy.Rules))
+	}
+	if clusterScope.Network().FirewallPolicy == nil || *clusterScope.Network().FirewallPolicy != policy.SelfLink {
+		t.Errorf("expected status to reference the firewall policy")
+	}
+
+	// Drift on an existing rule is patched back to the spec.
+	policy.Rules[0].Action = "deny"
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(policy.Rules) != 2 || policy.Rules[0].Action != "allow" {
+		t.Errorf("expected drifted rule to be patched, got %+v", policy.Rules[0])
+	}
+
+	if err := s.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := policies.policies["my-cluster-firewall-policy"]; ok {
+		t.Error("expected firewall policy to be deleted")
+	}
+	if clusterScope.Network().FirewallPolicy != nil {
+		t.Error("expected status firewall policy to be cleared")
+	}
+}
+
+func TestService_DeleteFirewallPolicyNotOwned(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policies := &fakeFirewallPolicies{policies: map[string]*compute.FirewallPolicy{
+		"my-cluster-firewall-policy": {Name: "my-cluster-firewall-policy", Description: "user managed"},
+	}}
+	s := New(clusterScope)
+	s.firewalls = &cloud.MockFirewalls{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+	}
+	s.firewallpolicies = policies
+
+	if err := s.Delete(context.TODO()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := policies.policies["my-cluster-firewall-policy"]; !ok {
+		t.Error("expected firewall policy not owned by the cluster to be kept")
+	}
+}
+
+func TestService_DeleteWithoutFirewallPolicy(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterScope.GCPCluster.Spec.Network.FirewallPolicy = nil
+	policies := &fakeFirewallPolicies{policies: map[string]*compute.FirewallPolicy{}}
+	s := New(clusterScope)
+	s.firewalls = &cloud.MockFirewalls{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+	}
+	s.firewallpolicies = policies
+
+	if err := s.Delete(context.TODO()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Orphan(context.TODO()); err != nil {
+		t.Fatalf("Orphan() error = %v", err)
+	}
+	if policies.gets != 0 {
+		t.Errorf("expected no firewall policy lookups, got %d", policies.gets)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 )
 
@@ -34,21 +35,37 @@ type firewallsInterface interface {
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.ClusterGetter
+	ComputeService() *compute.Service
+	RateLimiter() cloud.RateLimiter
 	FirewallRulesSpec() []*compute.Firewall
+	FirewallPolicy() *infrav1.FirewallPolicySpec
+	FirewallPolicySpec() *compute.FirewallPolicy
+	FirewallPolicyAssociationSpec() *compute.FirewallPolicyAssociation
+	FirewallPolicyRulesSpec(controlPlaneTag, nodeTag string) []*compute.FirewallPolicyRule
 }
 
 // Service implements firewalls reconciler.
 type Service struct {
-	scope     Scope
-	firewalls firewallsInterface
+	scope            Scope
+	firewalls        firewallsInterface
+	firewallpolicies firewallPoliciesInterface
+	tagValueName     func(ctx context.Context, tag infrav1.ResourceManagerTag) (string, error)
 }
 
 var _ cloud.Reconciler = &Service{}
 
+var _ cloud.Orphaner = &Service{}
+
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
 		scope:     scope,
 		firewalls: scope.Cloud().Firewalls(),
+		firewallpolicies: &networkFirewallPolicies{
+			service:     scope.ComputeService(),
+			project:     scope.Project(),
+			rateLimiter: scope.RateLimiter(),
+		},
+		tagValueName: scope.TagValues().TagValueName,
 	}
 }
@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instances
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// serialOutputTailLines is the number of lines of the serial console output attached to a bootstrap failure.
+	serialOutputTailLines = 20
+	// serialOutputTailBytes caps the serial console output attached to a bootstrap failure.
+	serialOutputTailBytes = 2048
+)
+
+// BootstrapFailedError is returned when the instance of a machine reported the failure of its bootstrap, or did
+// not report its success before the timeout of the bootstrap check.
+type BootstrapFailedError struct {
+	// Reason is infrav1.BootstrapFailedReason or infrav1.BootstrapTimedOutReason.
+	Reason string
+	// Message describes the failure.
+	Message string
+	// SerialOutputTail is the end of the output of the serial console of the instance, empty if it is unavailable.
+	SerialOutputTail string
+}
+
+func (e *BootstrapFailedError) Error() string {
+	return e.Message
+}
+
+// reconcileBootstrap looks up the result of the bootstrap reported by the running instance of a machine with a
+// bootstrap check, until it succeeds or fails.
+func (s *Service) reconcileBootstrap(ctx context.Context, instance *compute.Instance) error {
+	log := log.FromContext(ctx)
+	check := s.scope.BootstrapCheck()
+	if check == nil || !s.scope.IsBootstrapPending() || instance.Status != string(infrav1.InstanceStatusRunning) {
+		return nil
+	}
+
+	key := meta.ZonalKey(instance.Name, s.scope.Zone())
+	var result string
+	var err error
+	if check.Method == infrav1.BootstrapCheckMethodSerialConsole {
+		result, err = s.serialBootstrapResult(ctx, key)
+	} else {
+		result, err = s.guestAttributeBootstrapResult(ctx, key)
+	}
+	if err != nil {
+		log.Error(err, "Error looking for the bootstrap result of instance", "name", instance.Name, "method", check.Method)
+		return err
+	}
+
+	switch result {
+	case shared.BootstrapResultSucceeded:
+		log.Info("Instance bootstrap succeeded", "name", instance.Name)
+		s.scope.SetBootstrapSucceeded()
+		return nil
+	case shared.BootstrapResultFailed:
+		return s.bootstrapFailed(ctx, key, infrav1.BootstrapFailedReason, fmt.Sprintf("instance %s reported the failure of its bootstrap", instance.Name))
+	}
+
+	if created, err := time.Parse(time.RFC3339, instance.CreationTimestamp); err == nil && time.Since(created) > check.Timeout.Duration {
+		return s.bootstrapFailed(ctx, key, infrav1.BootstrapTimedOutReason,
+			fmt.Sprintf("instance %s did not report the success of its bootstrap within %s", instance.Name, check.Timeout.Duration))
+	}
+
+	log.V(2).Info("Waiting for instance bootstrap", "name", instance.Name)
+	s.scope.SetBootstrapWaiting()
+	return nil
+}
+
+// guestAttributeBootstrapResult returns the bootstrap result in the guest attributes of the instance, empty if it
+// has not been reported yet.
+func (s *Service) guestAttributeBootstrapResult(ctx context.Context, key *meta.Key) (string, error) {
+	attributes, err := s.console.GetGuestAttributes(ctx, key, shared.BootstrapResultGuestAttribute)
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	if attributes.QueryValue == nil {
+		return "", nil
+	}
+
+	for _, item := range attributes.QueryValue.Items {
+		if path.Join(item.Namespace, item.Key) == shared.BootstrapResultGuestAttribute {
+			return strings.TrimSpace(item.Value), nil
+		}
+	}
+
+	return "", nil
+}
+
+// serialBootstrapResult returns the last bootstrap result in the serial console output of the instance, empty if
+// it has not been reported yet. Only the output written since the previous lookup is read.
+func (s *Service) serialBootstrapResult(ctx context.Context, key *meta.Key) (string, error) {
+	output, err := s.console.GetSerialPortOutput(ctx, key, s.scope.BootstrapSerialOffset())
+	if err != nil {
+		return "", err
+	}
+
+	// Only the complete lines are consumed, an incomplete last line is read again by the next lookup. The output
+	// starts later than requested when the older output was overwritten.
+	complete := output.Contents[:strings.LastIndex(output.Contents, "\n")+1]
+	s.scope.SetBootstrapSerialOffset(output.Start + int64(len(complete)))
+
+	lines := strings.Split(complete, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if _, result, found := strings.Cut(lines[i], shared.BootstrapResultSerialMarker); found {
+			return strings.TrimSpace(result), nil
+		}
+	}
+
+	return "", nil
+}
+
+// bootstrapFailed returns the BootstrapFailedError of the instance with the tail of its serial console output.
+func (s *Service) bootstrapFailed(ctx context.Context, key *meta.Key, reason, message string) error {
+	log := log.FromContext(ctx)
+	bootstrapErr := &BootstrapFailedError{Reason: reason, Message: message}
+	output, err := s.console.GetSerialPortOutput(ctx, key, 0)
+	if err != nil {
+		log.Error(err, "Error getting the serial console output of instance", "name", key.Name)
+		return bootstrapErr
+	}
+
+	bootstrapErr.SerialOutputTail = serialOutputTail(output.Contents)
+	return bootstrapErr
+}
+
+// serialOutputTail returns the last lines of the serial console output, within serialOutputTailBytes.
+func serialOutputTail(contents string) string {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(contents, "\r", ""), "\n"), "\n")
+	if len(lines) > serialOutputTailLines {
+		lines = lines[len(lines)-serialOutputTailLines:]
+	}
+
+	tail := strings.Join(lines, "\n")
+	if len(tail) > serialOutputTailBytes {
+		tail = tail[len(tail)-serialOutputTailBytes:]
+	}
+
+	return tail
+}
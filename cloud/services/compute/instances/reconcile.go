@@ -19,6 +19,8 @@ package instances
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -29,6 +31,9 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -36,11 +41,30 @@ import (
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling instance resources")
-	instance, err := s.createOrGetInstance(ctx)
+	var instance *compute.Instance
+	var err error
+	if s.scope.ExistingInstance() != "" {
+		instance, err = s.adoptInstance(ctx)
+	} else {
+		instance, err = s.createOrGetInstance(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	disks, err := s.specDisks(ctx, instance)
 	if err != nil {
 		return err
 	}
 
+	if err := s.reconcileLabels(ctx, instance, disks); err != nil {
+		return err
+	}
+
+	if err := s.reconcileTagBindings(ctx, instance, disks); err != nil {
+		return err
+	}
+
 	addresses := make([]corev1.NodeAddress, 0, len(instance.NetworkInterfaces))
 	for _, iface := range instance.NetworkInterfaces {
 		addresses = append(addresses, corev1.NodeAddress{
@@ -56,7 +80,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
-	machineName := s.scope.Name()
+	machineName := s.scope.InstanceName()
 	zone := s.scope.Zone()
 	project := s.scope.Project()
 
@@ -91,13 +115,18 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return s.reconcileBootstrap(ctx, instance)
 }
 
 // Delete delete machine instance.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Deleting instance resources")
+	if s.scope.ExistingInstance() != "" && s.scope.GetAdoptedInstance() == nil {
+		log.V(2).Info("Existing instance was not adopted, skipping deletion", "name", s.scope.InstanceName())
+		return nil
+	}
+
 	instanceSpec := s.scope.InstanceSpec(log)
 	instanceName := instanceSpec.Name
 	instanceKey := meta.ZonalKey(instanceName, s.scope.Zone())
@@ -122,6 +151,81 @@ func (s *Service) Delete(ctx context.Context) error {
 	return gcperrors.IgnoreNotFound(s.instances.Delete(ctx, instanceKey))
 }
 
+// Orphan removes the CAPG labels of the instance and its disks, and returns them to be left behind instead of
+// deleting them. A control plane instance is deregistered from the instance group of the cluster, unless the cluster
+// is orphaned too, so that the load balancer stops serving it.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx)
+	if s.scope.ExistingInstance() != "" && s.scope.GetAdoptedInstance() == nil {
+		log.V(2).Info("Existing instance was not adopted, skipping orphaning", "name", s.scope.InstanceName())
+		return nil, nil
+	}
+
+	instanceName := s.scope.InstanceName()
+	instanceKey := meta.ZonalKey(instanceName, s.scope.Zone())
+	log.V(2).Info("Looking for instance before orphaning", "name", instanceName, "zone", s.scope.Zone())
+	instance, err := s.instances.Get(ctx, instanceKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for instance before orphaning", "name", instanceName)
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	if s.scope.IsControlPlane() && !s.scope.IsClusterOrphanOnDelete() {
+		if err := s.deregisterControlPlaneInstance(ctx, instance); err != nil {
+			return nil, err
+		}
+	}
+
+	desired := s.scope.Labels()
+	if labels, changed := shared.OrphanLabels(instance.Labels, desired); changed {
+		log.V(2).Info("Removing CAPG labels of instance", "name", instance.Name, "zone", s.scope.Zone())
+		if err := s.labels.SetInstanceLabels(ctx, instanceKey, &compute.InstancesSetLabelsRequest{
+			Labels:           labels,
+			LabelFingerprint: instance.LabelFingerprint,
+		}); err != nil {
+			log.Error(err, "Error removing CAPG labels of instance", "name", instance.Name, "zone", s.scope.Zone())
+			return nil, err
+		}
+	}
+
+	orphaned := []string{instance.SelfLink}
+	for _, attached := range instance.Disks {
+		if attached.Type == "SCRATCH" || attached.Source == "" {
+			continue
+		}
+
+		diskKey := meta.ZonalKey(path.Base(attached.Source), s.scope.Zone())
+		disk, err := s.disks.Get(ctx, diskKey)
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				continue
+			}
+
+			log.Error(err, "Error looking for disk", "name", diskKey.Name, "zone", s.scope.Zone())
+			return nil, err
+		}
+
+		if labels, changed := shared.OrphanLabels(disk.Labels, desired); changed {
+			log.V(2).Info("Removing CAPG labels of disk", "name", disk.Name, "zone", s.scope.Zone())
+			if err := s.labels.SetDiskLabels(ctx, diskKey, &compute.ZoneSetLabelsRequest{
+				Labels:           labels,
+				LabelFingerprint: disk.LabelFingerprint,
+			}); err != nil {
+				log.Error(err, "Error removing CAPG labels of disk", "name", disk.Name, "zone", s.scope.Zone())
+				return nil, err
+			}
+		}
+
+		orphaned = append(orphaned, disk.SelfLink)
+	}
+
+	return orphaned, nil
+}
+
 func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, error) {
 	log := log.FromContext(ctx)
 	log.V(2).Info("Getting bootstrap data for machine")
@@ -162,6 +266,228 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 	return instance, nil
 }
 
+// adoptInstance gets the existing instance of the spec and checks that the machine can adopt it.
+// The differences between the instance and the spec are reported, the instance is never recreated.
+func (s *Service) adoptInstance(ctx context.Context) (*compute.Instance, error) {
+	log := log.FromContext(ctx)
+	existing := s.scope.ExistingInstance()
+	if id, err := providerid.Parse(existing); err == nil {
+		if id.Project() != s.scope.Project() {
+			return nil, s.invalidAdoption(errors.Errorf("existing instance %s is not in the project %s of the cluster", existing, s.scope.Project()))
+		}
+
+		if id.Location() != s.scope.Zone() {
+			return nil, s.invalidAdoption(errors.Errorf("existing instance %s is not in the zone %s of the machine", existing, s.scope.Zone()))
+		}
+	}
+
+	instanceName := s.scope.InstanceName()
+	log.V(2).Info("Looking for existing instance", "name", instanceName, "zone", s.scope.Zone())
+	instance, err := s.instances.Get(ctx, meta.ZonalKey(instanceName, s.scope.Zone()))
+	if err != nil {
+		log.Error(err, "Error looking for existing instance", "name", instanceName, "zone", s.scope.Zone())
+		return nil, err
+	}
+
+	spec := s.scope.InstanceSpec(log)
+	if !hasNetwork(instance, spec.NetworkInterfaces[0].Network) {
+		return nil, s.invalidAdoption(errors.Errorf("existing instance %s is not in the network %s of the cluster", instanceName, spec.NetworkInterfaces[0].Network))
+	}
+
+	desired := s.scope.Labels()
+	ownerPrefix := infrav1.SanitizeLabelKey(infrav1.NameGCPProviderOwned)
+	for key := range instance.Labels {
+		if _, ok := desired[key]; !ok && strings.HasPrefix(key, ownerPrefix) {
+			return nil, s.invalidAdoption(errors.Errorf("existing instance %s is owned by another cluster (label %s)", instanceName, key))
+		}
+	}
+
+	mismatches := instanceMismatches(instance, spec)
+	for _, mismatch := range mismatches {
+		log.Info("Existing instance differs from the spec", "name", instanceName, "difference", mismatch)
+	}
+
+	if s.scope.GetAdoptedInstance() == nil {
+		log.Info("Adopting existing instance", "name", instanceName, "zone", s.scope.Zone())
+	}
+	s.scope.SetAdoptedInstance(instance.SelfLink, mismatches)
+	return instance, nil
+}
+
+// invalidAdoption reports an existing instance which cannot be adopted as the failure of the machine,
+// since the spec is immutable.
+func (s *Service) invalidAdoption(err error) error {
+	s.scope.SetFailureReason(capierrors.InvalidConfigurationMachineError)
+	s.scope.SetFailureMessage(err)
+	return err
+}
+
+// hasNetwork reports whether an interface of the instance is in the network.
+func hasNetwork(instance *compute.Instance, network string) bool {
+	for _, iface := range instance.NetworkInterfaces {
+		if strings.HasSuffix(iface.Network, network) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// instanceMismatches returns the differences between an existing instance and the spec which would have
+// created it. They are not reconciled since most of them require to recreate the instance.
+func instanceMismatches(instance, spec *compute.Instance) []string {
+	var mismatches []string
+	if path.Base(instance.MachineType) != path.Base(spec.MachineType) {
+		mismatches = append(mismatches, fmt.Sprintf("machine type is %s instead of %s", path.Base(instance.MachineType), path.Base(spec.MachineType)))
+	}
+
+	if instance.CanIpForward != spec.CanIpForward {
+		mismatches = append(mismatches, fmt.Sprintf("IP forwarding is %t instead of %t", instance.CanIpForward, spec.CanIpForward))
+	}
+
+	preemptible := instance.Scheduling != nil && instance.Scheduling.Preemptible
+	if preemptible != spec.Scheduling.Preemptible {
+		mismatches = append(mismatches, fmt.Sprintf("preemptible is %t instead of %t", preemptible, spec.Scheduling.Preemptible))
+	}
+
+	tags := sets.New[string]()
+	if instance.Tags != nil {
+		tags.Insert(instance.Tags.Items...)
+	}
+	if missing := sets.New(spec.Tags.Items...).Difference(tags); missing.Len() > 0 {
+		// The firewall rules of the cluster select the instances by network tags.
+		mismatches = append(mismatches, fmt.Sprintf("network tags %s are missing", strings.Join(sets.List(missing), ", ")))
+	}
+
+	return mismatches
+}
+
+// reconcileLabels sets the labels of the spec on the instance and its disks created with it, and removes the labels
+// previously set by CAPG which are not part of the spec anymore. The labels of an existing instance and its disks are
+// shared with the tooling which created them, only the labels of the spec are added.
+func (s *Service) reconcileLabels(ctx context.Context, instance *compute.Instance, disks []*compute.Disk) error {
+	log := log.FromContext(ctx)
+	desired := s.scope.Labels()
+	labelsToSet := func(existing infrav1.Labels) (infrav1.Labels, bool) {
+		return shared.LabelsToSet(existing, desired, s.scope.LabelKeys())
+	}
+	if s.scope.ExistingInstance() != "" {
+		labelsToSet = func(existing infrav1.Labels) (infrav1.Labels, bool) {
+			return shared.SharedLabelsToSet(existing, desired)
+		}
+	}
+
+	if labels, changed := labelsToSet(instance.Labels); changed {
+		log.V(2).Info("Updating labels of instance", "name", instance.Name, "zone", s.scope.Zone())
+		if err := s.labels.SetInstanceLabels(ctx, meta.ZonalKey(instance.Name, s.scope.Zone()), &compute.InstancesSetLabelsRequest{
+			Labels:           labels,
+			LabelFingerprint: instance.LabelFingerprint,
+		}); err != nil {
+			log.Error(err, "Error updating labels of instance", "name", instance.Name, "zone", s.scope.Zone())
+			return err
+		}
+	}
+
+	for _, disk := range disks {
+		if labels, changed := labelsToSet(disk.Labels); changed {
+			log.V(2).Info("Updating labels of disk", "name", disk.Name, "zone", s.scope.Zone())
+			if err := s.labels.SetDiskLabels(ctx, meta.ZonalKey(disk.Name, s.scope.Zone()), &compute.ZoneSetLabelsRequest{
+				Labels:           labels,
+				LabelFingerprint: disk.LabelFingerprint,
+			}); err != nil {
+				log.Error(err, "Error updating labels of disk", "name", disk.Name, "zone", s.scope.Zone())
+				return err
+			}
+		}
+	}
+
+	// The labels removed from the spec are forgotten once they are removed from the instance and all its disks.
+	s.scope.SetLabelKeys(shared.LabelKeys(desired))
+
+	return nil
+}
+
+// reconcileTagBindings binds the resource manager tags of the machine to the instance and its disks created with it,
+// and removes the bindings of the tags previously applied by CAPG which are not part of the spec anymore. The tags
+// are only set when the instance is created, the bindings keep them up to date afterwards.
+func (s *Service) reconcileTagBindings(ctx context.Context, instance *compute.Instance, disks []*compute.Disk) error {
+	applied := s.scope.ResourceManagerTagValues()
+	if len(s.scope.ResourceManagerTags()) == 0 && len(applied) == 0 {
+		return nil
+	}
+
+	tagValues, err := shared.TagValueNames(ctx, s.scope.ResourceManagerTags(), s.tagValueName)
+	if err != nil {
+		return err
+	}
+
+	bindings, err := s.tagBindings(ctx, s.scope.Zone())
+	if err != nil {
+		return err
+	}
+
+	resources := []string{shared.ComputeResourceName(instance.SelfLink, instance.Id)}
+	for _, disk := range disks {
+		resources = append(resources, shared.ComputeResourceName(disk.SelfLink, disk.Id))
+	}
+
+	for _, resource := range resources {
+		if err := shared.ReconcileTagBindings(ctx, bindings, resource, tagValues, sets.New(applied...)); err != nil {
+			return err
+		}
+	}
+
+	// The tag values removed from the spec are forgotten once they are unbound from the instance and all its disks.
+	s.scope.SetResourceManagerTagValues(sets.List(tagValues))
+
+	return nil
+}
+
+// specDisks returns the disks created with the instance: the boot disk and the additional disks of the spec.
+func (s *Service) specDisks(ctx context.Context, instance *compute.Instance) ([]*compute.Disk, error) {
+	log := log.FromContext(ctx)
+	deviceNames := s.specDiskDeviceNames()
+	var disks []*compute.Disk
+	for _, attached := range instance.Disks {
+		if attached.Type == "SCRATCH" || attached.Source == "" {
+			// Local SSDs are scratch disks without labels nor tags.
+			continue
+		}
+
+		if !attached.Boot && !deviceNames.Has(attached.DeviceName) {
+			// The disks attached to the instance afterwards, e.g. the persistent volumes of the PD CSI driver,
+			// are not managed by CAPG.
+			continue
+		}
+
+		diskKey := meta.ZonalKey(path.Base(attached.Source), s.scope.Zone())
+		disk, err := s.disks.Get(ctx, diskKey)
+		if err != nil {
+			log.Error(err, "Error looking for disk", "name", diskKey.Name, "zone", s.scope.Zone())
+			return nil, err
+		}
+		disks = append(disks, disk)
+	}
+
+	return disks, nil
+}
+
+// specDiskDeviceNames returns the device names of the disks created with the instance of the spec. Since the spec
+// does not name them, GCE names them persistent-disk-<index> after their position in the spec, the boot disk first.
+func (s *Service) specDiskDeviceNames() sets.Set[string] {
+	disks := append([]*compute.AttachedDisk{s.scope.InstanceImageSpec()}, s.scope.InstanceAdditionalDiskSpec()...)
+	deviceNames := sets.New[string]()
+	for i, disk := range disks {
+		if disk.DeviceName != "" {
+			deviceNames.Insert(disk.DeviceName)
+			continue
+		}
+		deviceNames.Insert(fmt.Sprintf("persistent-disk-%d", i))
+	}
+
+	return deviceNames
+}
+
 func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *compute.Instance) error {
 	log := log.FromContext(ctx)
 	instancegroupName := s.scope.ControlPlaneGroupName()
@@ -18,13 +18,20 @@ package instances
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	rmpb "cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 	corev1 "k8s.io/api/core/v1"
@@ -32,8 +39,10 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	capgcloud "sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -233,6 +242,7 @@ func TestService_createOrGetInstance(t *testing.T) {
 						Boot:       true,
 						InitializeParams: &compute.AttachedDiskInitializeParams{
 							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							Labels:              map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"},
 							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
 							ResourceManagerTags: map[string]string{},
 						},
@@ -298,6 +308,7 @@ func TestService_createOrGetInstance(t *testing.T) {
 						Boot:       true,
 						InitializeParams: &compute.AttachedDiskInitializeParams{
 							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							Labels:              map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"},
 							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
 							ResourceManagerTags: map[string]string{},
 						},
@@ -365,6 +376,7 @@ func TestService_createOrGetInstance(t *testing.T) {
 						Boot:       true,
 						InitializeParams: &compute.AttachedDiskInitializeParams{
 							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							Labels:              map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"},
 							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
 							ResourceManagerTags: map[string]string{},
 						},
@@ -432,6 +444,7 @@ func TestService_createOrGetInstance(t *testing.T) {
 						Boot:       true,
 						InitializeParams: &compute.AttachedDiskInitializeParams{
 							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							Labels:              map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"},
 							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
 							ResourceManagerTags: map[string]string{},
 						},
@@ -502,6 +515,7 @@ func TestService_createOrGetInstance(t *testing.T) {
 						Boot:       true,
 						InitializeParams: &compute.AttachedDiskInitializeParams{
 							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							Labels:              map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"},
 							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
 							ResourceManagerTags: map[string]string{},
 						},
@@ -565,6 +579,7 @@ func TestService_createOrGetInstance(t *testing.T) {
 						Boot:       true,
 						InitializeParams: &compute.AttachedDiskInitializeParams{
 							DiskType:            "zones/us-central1-a/diskTypes/pd-standard",
+							Labels:              map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"},
 							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
 							ResourceManagerTags: map[string]string{},
 						},
@@ -627,3 +642,787 @@ func TestService_createOrGetInstance(t *testing.T) {
 		})
 	}
 }
+
+// fakeLabelSetter records the labels set on the instances and disks.
+type fakeLabelSetter struct {
+	instances map[string]map[string]string
+	disks     map[string]map[string]string
+}
+
+func (f *fakeLabelSetter) SetInstanceLabels(_ context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error {
+	f.instances[key.Name] = req.Labels
+	return nil
+}
+
+func (f *fakeLabelSetter) SetDiskLabels(_ context.Context, key *meta.Key, req *compute.ZoneSetLabelsRequest) error {
+	f.disks[key.Name] = req.Labels
+	return nil
+}
+
+func TestService_reconcileLabels(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localSsd := infrav1.LocalSsdDiskType
+	pdSsd := infrav1.PdSsdDiskType
+	gcpMachine := getFakeGCPMachine()
+	gcpMachine.Spec.AdditionalDisks = []infrav1.AttachedDiskSpec{
+		{DeviceType: &localSsd},
+		{DeviceType: &pdSsd},
+	}
+	gcpMachine.Status.LabelKeys = []string{"capg-cluster-my-cluster", "capg-role", "foo", "removed"}
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    gcpMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desired := map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"}
+	mockDisks := &cloud.MockDisks{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+		Objects: map[meta.Key]*cloud.MockDisksObj{
+			{Name: "my-machine", Zone: "us-central1-c"}: {Obj: &compute.Disk{
+				Name:   "my-machine",
+				Labels: desired,
+			}},
+			{Name: "my-machine-data", Zone: "us-central1-c"}: {Obj: &compute.Disk{
+				Name:   "my-machine-data",
+				Labels: map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "baz"},
+			}},
+			{Name: "pvc-1234", Zone: "us-central1-c"}: {Obj: &compute.Disk{
+				Name:   "pvc-1234",
+				Labels: map[string]string{"goog-k8s-cluster-name": "my-cluster"},
+			}},
+		},
+	}
+	labels := &fakeLabelSetter{instances: map[string]map[string]string{}, disks: map[string]map[string]string{}}
+	s := New(machineScope)
+	s.disks = mockDisks
+	s.labels = labels
+
+	instance := &compute.Instance{
+		Name: "my-machine",
+		Labels: map[string]string{
+			"capg-cluster-my-cluster": "owned",
+			"capg-role":               "node",
+			"removed":                 "label",
+			"goog-ops-agent-policy":   "v1",
+			"team":                    "infra",
+		},
+		Disks: []*compute.AttachedDisk{
+			{Boot: true, DeviceName: "persistent-disk-0", Source: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/my-machine"},
+			{Type: "SCRATCH", DeviceName: "local-ssd-0"},
+			{DeviceName: "persistent-disk-2", Source: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/my-machine-data"},
+			{DeviceName: "pvc-1234", Source: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/pvc-1234"},
+		},
+	}
+	disks, err := s.specDisks(context.TODO(), instance)
+	if err != nil {
+		t.Fatalf("Service.specDisks() error = %v", err)
+	}
+	if err := s.reconcileLabels(context.TODO(), instance, disks); err != nil {
+		t.Fatalf("Service.reconcileLabels() error = %v", err)
+	}
+
+	wantInstance := map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar", "goog-ops-agent-policy": "v1", "team": "infra"}
+	if d := cmp.Diff(wantInstance, labels.instances["my-machine"]); d != "" {
+		t.Errorf("instance labels mismatch (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff([]string{"capg-cluster-my-cluster", "capg-role", "foo"}, gcpMachine.Status.LabelKeys); d != "" {
+		t.Errorf("applied label keys mismatch (-want +got):\n%s", d)
+	}
+	if _, ok := labels.disks["my-machine"]; ok {
+		t.Errorf("expected the labels of the up to date disk to be left untouched")
+	}
+	if d := cmp.Diff(desired, labels.disks["my-machine-data"]); d != "" {
+		t.Errorf("disk labels mismatch (-want +got):\n%s", d)
+	}
+	if _, ok := labels.disks["pvc-1234"]; ok {
+		t.Errorf("expected the labels of the disk attached by the PD CSI driver to be left untouched")
+	}
+}
+
+func TestService_reconcileLabelsExistingInstance(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gcpMachine := getFakeGCPMachine()
+	gcpMachine.Spec.ExistingInstance = pointer.String("existing-vm")
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    gcpMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockDisks := &cloud.MockDisks{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+		Objects: map[meta.Key]*cloud.MockDisksObj{
+			{Name: "existing-vm", Zone: "us-central1-c"}: {Obj: &compute.Disk{
+				Name:   "existing-vm",
+				Labels: map[string]string{"team": "infra"},
+			}},
+		},
+	}
+	labels := &fakeLabelSetter{instances: map[string]map[string]string{}, disks: map[string]map[string]string{}}
+	s := New(machineScope)
+	s.disks = mockDisks
+	s.labels = labels
+
+	instance := &compute.Instance{
+		Name:   "existing-vm",
+		Labels: map[string]string{"team": "infra", "foo": "baz"},
+		Disks: []*compute.AttachedDisk{
+			{Boot: true, DeviceName: "existing-vm", Source: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/existing-vm"},
+		},
+	}
+	disks, err := s.specDisks(context.TODO(), instance)
+	if err != nil {
+		t.Fatalf("Service.specDisks() error = %v", err)
+	}
+	if err := s.reconcileLabels(context.TODO(), instance, disks); err != nil {
+		t.Fatalf("Service.reconcileLabels() error = %v", err)
+	}
+
+	want := map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar", "team": "infra"}
+	if d := cmp.Diff(want, labels.instances["existing-vm"]); d != "" {
+		t.Errorf("instance labels mismatch (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff(want, labels.disks["existing-vm"]); d != "" {
+		t.Errorf("disk labels mismatch (-want +got):\n%s", d)
+	}
+}
+
+// fakeTagBindings is an in-memory TagBindings.
+type fakeTagBindings struct {
+	bindings map[string]*rmpb.TagBinding
+}
+
+func (f *fakeTagBindings) List(_ context.Context, parent string) ([]*rmpb.TagBinding, error) {
+	var bindings []*rmpb.TagBinding
+	for _, binding := range f.bindings {
+		if binding.Parent == parent {
+			bindings = append(bindings, binding)
+		}
+	}
+
+	return bindings, nil
+}
+
+func (f *fakeTagBindings) Create(_ context.Context, parent, tagValue string) error {
+	name := fmt.Sprintf("tagBindings/%s/%s", parent, tagValue)
+	f.bindings[name] = &rmpb.TagBinding{Name: name, Parent: parent, TagValue: tagValue}
+	return nil
+}
+
+func (f *fakeTagBindings) Delete(_ context.Context, name string) error {
+	delete(f.bindings, name)
+	return nil
+}
+
+func TestService_reconcileTagBindings(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.ResourceManagerTags = infrav1.ResourceManagerTags{{ParentID: "my-proj", Key: "env", Value: "prod"}}
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: gcpCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gcpMachine := getFakeGCPMachine()
+	gcpMachine.Spec.ResourceManagerTags = infrav1.ResourceManagerTags{{ParentID: "my-proj", Key: "team", Value: "infra"}}
+	gcpMachine.Status.ResourceManagerTagValues = []string{"tagValues/infra", "tagValues/removed"}
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    gcpMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instanceName := "//compute.googleapis.com/projects/proj-id/zones/us-central1-c/instances/1"
+	diskName := "//compute.googleapis.com/projects/proj-id/zones/us-central1-c/disks/2"
+	bindings := &fakeTagBindings{bindings: map[string]*rmpb.TagBinding{}}
+	for _, binding := range []*rmpb.TagBinding{
+		{Parent: instanceName, TagValue: "tagValues/removed"},
+		{Parent: instanceName, TagValue: "tagValues/other"},
+		{Parent: diskName, TagValue: "tagValues/infra"},
+	} {
+		if err := bindings.Create(context.TODO(), binding.Parent, binding.TagValue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := New(machineScope)
+	var location string
+	s.tagBindings = func(_ context.Context, l string) (capgcloud.TagBindings, error) {
+		location = l
+		return bindings, nil
+	}
+	s.tagValueName = func(_ context.Context, tag infrav1.ResourceManagerTag) (string, error) {
+		return "tagValues/" + tag.Value, nil
+	}
+
+	instance := &compute.Instance{
+		Id:       1,
+		Name:     "my-machine",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+	}
+	disks := []*compute.Disk{{
+		Id:       2,
+		Name:     "my-machine",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/my-machine",
+	}}
+	if err := s.reconcileTagBindings(context.TODO(), instance, disks); err != nil {
+		t.Fatalf("Service.reconcileTagBindings() error = %v", err)
+	}
+
+	if location != "us-central1-c" {
+		t.Errorf("expected the tag bindings of the zone of the instance, got %q", location)
+	}
+
+	got := map[string][]string{}
+	for _, binding := range bindings.bindings {
+		got[binding.Parent] = append(got[binding.Parent], binding.TagValue)
+	}
+	for _, tagValues := range got {
+		sort.Strings(tagValues)
+	}
+	want := map[string][]string{
+		instanceName: {"tagValues/infra", "tagValues/other", "tagValues/prod"},
+		diskName:     {"tagValues/infra", "tagValues/prod"},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("tag bindings mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff([]string{"tagValues/infra", "tagValues/prod"}, gcpMachine.Status.ResourceManagerTagValues); d != "" {
+		t.Errorf("applied tag values mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestService_Orphan(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockInstances := &cloud.MockInstances{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+		Objects: map[meta.Key]*cloud.MockInstancesObj{
+			{Name: "my-machine", Zone: "us-central1-c"}: {Obj: &compute.Instance{
+				Name:     "my-machine",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Labels:   map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node", "foo": "bar"},
+				Disks: []*compute.AttachedDisk{
+					{Source: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/my-machine"},
+					{Type: "SCRATCH"},
+				},
+			}},
+		},
+	}
+	mockDisks := &cloud.MockDisks{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+		Objects: map[meta.Key]*cloud.MockDisksObj{
+			{Name: "my-machine", Zone: "us-central1-c"}: {Obj: &compute.Disk{
+				Name:     "my-machine",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/my-machine",
+				Labels:   map[string]string{"capg-cluster-my-cluster": "owned", "capg-role": "node"},
+			}},
+		},
+	}
+	labels := &fakeLabelSetter{instances: map[string]map[string]string{}, disks: map[string]map[string]string{}}
+	s := New(machineScope)
+	s.instances = mockInstances
+	s.disks = mockDisks
+	s.labels = labels
+
+	orphaned, err := s.Orphan(context.TODO())
+	if err != nil {
+		t.Fatalf("Service.Orphan() error = %v", err)
+	}
+
+	want := []string{
+		"https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+		"https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/my-machine",
+	}
+	if d := cmp.Diff(want, orphaned); d != "" {
+		t.Errorf("orphaned resources mismatch (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff(map[string]string{"foo": "bar"}, labels.instances["my-machine"]); d != "" {
+		t.Errorf("instance labels mismatch (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff(map[string]string{}, labels.disks["my-machine"]); d != "" {
+		t.Errorf("disk labels mismatch (-want +got):\n%s", d)
+	}
+	if _, err := mockInstances.Get(context.TODO(), meta.ZonalKey("my-machine", "us-central1-c")); err != nil {
+		t.Errorf("expected the instance to be left behind, got error %v", err)
+	}
+}
+
+// fakeInstanceGroups records the instances of the instance groups.
+type fakeInstanceGroups struct {
+	instances map[string][]string
+}
+
+func (f *fakeInstanceGroups) AddInstances(_ context.Context, key *meta.Key, req *compute.InstanceGroupsAddInstancesRequest) error {
+	for _, ref := range req.Instances {
+		f.instances[key.Name] = append(f.instances[key.Name], ref.Instance)
+	}
+
+	return nil
+}
+
+func (f *fakeInstanceGroups) ListInstances(_ context.Context, key *meta.Key, _ *compute.InstanceGroupsListInstancesRequest, _ *filter.F) ([]*compute.InstanceWithNamedPorts, error) {
+	var instances []*compute.InstanceWithNamedPorts
+	for _, instance := range f.instances[key.Name] {
+		instances = append(instances, &compute.InstanceWithNamedPorts{Instance: instance})
+	}
+
+	return instances, nil
+}
+
+func (f *fakeInstanceGroups) RemoveInstances(_ context.Context, key *meta.Key, req *compute.InstanceGroupsRemoveInstancesRequest) error {
+	for _, ref := range req.Instances {
+		var instances []string
+		for _, instance := range f.instances[key.Name] {
+			if instance != ref.Instance {
+				instances = append(instances, instance)
+			}
+		}
+		f.instances[key.Name] = instances
+	}
+
+	return nil
+}
+
+func TestService_OrphanControlPlane(t *testing.T) {
+	const selfLink = "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine"
+	tests := []struct {
+		name            string
+		clusterOrphaned bool
+		want            []string
+	}{
+		{
+			name: "deregisters the instance when only the machine is orphaned",
+			want: nil,
+		},
+		{
+			name:            "keeps the instance registered when the cluster is orphaned",
+			clusterOrphaned: true,
+			want:            []string{selfLink},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakec := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build()
+
+			gcpCluster := fakeGCPCluster.DeepCopy()
+			if tt.clusterOrphaned {
+				gcpCluster.Annotations = map[string]string{infrav1.OrphanOnDeleteAnnotation: "true"}
+			}
+
+			clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+				Client:     fakec,
+				Cluster:    fakeCluster,
+				GCPCluster: gcpCluster,
+				GCPServices: scope.GCPServices{
+					Compute: &compute.Service{},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			machine := fakeMachine.DeepCopy()
+			machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       machine,
+				GCPMachine:    fakeGCPMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mockInstances := &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockInstancesObj{
+					{Name: "my-machine", Zone: "us-central1-c"}: {Obj: &compute.Instance{
+						Name:     "my-machine",
+						SelfLink: selfLink,
+					}},
+				},
+			}
+			groupName := machineScope.ControlPlaneGroupName()
+			instancegroups := &fakeInstanceGroups{instances: map[string][]string{groupName: {selfLink}}}
+			s := New(machineScope)
+			s.instances = mockInstances
+			s.instancegroups = instancegroups
+			s.labels = &fakeLabelSetter{instances: map[string]map[string]string{}, disks: map[string]map[string]string{}}
+
+			if _, err := s.Orphan(context.TODO()); err != nil {
+				t.Fatalf("Service.Orphan() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, instancegroups.instances[groupName]); d != "" {
+				t.Errorf("instancegroup instances mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestService_adoptInstance(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	network := "https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/default"
+	tests := []struct {
+		name           string
+		existing       string
+		instance       *compute.Instance
+		wantErr        bool
+		wantMismatches bool
+	}{
+		{
+			name:     "instance matching the spec",
+			existing: "existing-vm",
+			instance: &compute.Instance{
+				MachineType:       "zones/us-central1-c/machineTypes/n1-standard-2",
+				CanIpForward:      true,
+				NetworkInterfaces: []*compute.NetworkInterface{{Network: network}},
+			},
+		},
+		{
+			name:     "instance referenced by provider ID",
+			existing: "gce://my-proj/us-central1-c/existing-vm",
+			instance: &compute.Instance{
+				MachineType:       "zones/us-central1-c/machineTypes/n1-standard-2",
+				CanIpForward:      true,
+				NetworkInterfaces: []*compute.NetworkInterface{{Network: network}},
+			},
+		},
+		{
+			name:     "instance differing from the spec is adopted with warnings",
+			existing: "existing-vm",
+			instance: &compute.Instance{
+				MachineType:       "zones/us-central1-c/machineTypes/e2-medium",
+				NetworkInterfaces: []*compute.NetworkInterface{{Network: network}},
+			},
+			wantMismatches: true,
+		},
+		{
+			name:     "instance in another network",
+			existing: "existing-vm",
+			instance: &compute.Instance{
+				MachineType:       "zones/us-central1-c/machineTypes/n1-standard-2",
+				NetworkInterfaces: []*compute.NetworkInterface{{Network: "https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/other"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "instance owned by another cluster",
+			existing: "existing-vm",
+			instance: &compute.Instance{
+				MachineType:       "zones/us-central1-c/machineTypes/n1-standard-2",
+				NetworkInterfaces: []*compute.NetworkInterface{{Network: network}},
+				Labels:            map[string]string{"capg-cluster-other-cluster": "owned"},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "instance in another project",
+			existing: "gce://other-proj/us-central1-c/existing-vm",
+			instance: &compute.Instance{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcpMachine := getFakeGCPMachine()
+			gcpMachine.Spec.InstanceType = "n1-standard-2"
+			gcpMachine.Spec.ExistingInstance = pointer.String(tt.existing)
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.instance.Name = "existing-vm"
+			if tt.instance.Tags == nil && !tt.wantMismatches {
+				tt.instance.Tags = machineScope.InstanceSpec(logr.Discard()).Tags
+			}
+			s := New(machineScope)
+			s.instances = &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstancesObj{
+					{Name: "existing-vm", Zone: "us-central1-c"}: {Obj: tt.instance},
+				},
+			}
+
+			got, err := s.adoptInstance(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.adoptInstance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if gcpMachine.Status.FailureReason == nil {
+					t.Errorf("expected the failure reason of the machine to be set")
+				}
+				return
+			}
+
+			if got.Name != "existing-vm" || machineScope.InstanceName() != "existing-vm" {
+				t.Errorf("adopted instance %q, instance name %q, want existing-vm", got.Name, machineScope.InstanceName())
+			}
+			if gcpMachine.Status.AdoptedInstance == nil {
+				t.Errorf("expected the adopted instance to be recorded")
+			}
+			if mismatched := conditions.IsFalse(gcpMachine, infrav1.InstanceAdoptedCondition); mismatched != tt.wantMismatches {
+				t.Errorf("InstanceAdopted condition false = %t, want %t: %s", mismatched, tt.wantMismatches, conditions.GetMessage(gcpMachine, infrav1.InstanceAdoptedCondition))
+			}
+		})
+	}
+}
+
+// fakeConsole returns the guest attributes and serial console output of an instance.
+type fakeConsole struct {
+	attributes *compute.GuestAttributes
+	serial     string
+}
+
+func (f *fakeConsole) GetGuestAttributes(_ context.Context, _ *meta.Key, _ string) (*compute.GuestAttributes, error) {
+	if f.attributes == nil {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	return f.attributes, nil
+}
+
+func (f *fakeConsole) GetSerialPortOutput(_ context.Context, _ *meta.Key, start int64) (*compute.SerialPortOutput, error) {
+	if start > int64(len(f.serial)) {
+		start = int64(len(f.serial))
+	}
+
+	return &compute.SerialPortOutput{Contents: f.serial[start:], Start: start, Next: int64(len(f.serial))}, nil
+}
+
+func TestService_reconcileBootstrap(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attribute := func(value string) *compute.GuestAttributes {
+		return &compute.GuestAttributes{QueryValue: &compute.GuestAttributesValue{
+			Items: []*compute.GuestAttributesEntry{{Namespace: "capg", Key: "bootstrap", Value: value}},
+		}}
+	}
+	recent := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	old := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	tests := []struct {
+		name       string
+		method     infrav1.BootstrapCheckMethod
+		created    string
+		console    *fakeConsole
+		offset     int64
+		wantStatus corev1.ConditionStatus
+		wantReason string
+		wantOffset int64
+	}{
+		{
+			name:       "guest attribute not reported yet",
+			created:    recent,
+			console:    &fakeConsole{},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: infrav1.WaitingForBootstrapReason,
+		},
+		{
+			name:       "guest attribute reporting a success",
+			created:    old,
+			console:    &fakeConsole{attributes: attribute("succeeded")},
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name:       "guest attribute reporting a failure",
+			created:    recent,
+			console:    &fakeConsole{attributes: attribute("failed"), serial: "booting\r\ncloud-init failed\r\n"},
+			wantReason: infrav1.BootstrapFailedReason,
+		},
+		{
+			name:       "guest attribute not reported in time",
+			created:    old,
+			console:    &fakeConsole{serial: "booting\ncloud-init failed\n"},
+			wantReason: infrav1.BootstrapTimedOutReason,
+		},
+		{
+			name:       "serial console reporting a success",
+			method:     infrav1.BootstrapCheckMethodSerialConsole,
+			created:    recent,
+			console:    &fakeConsole{serial: "booting\n[   12.3] capg-bootstrap: succeeded\n"},
+			wantStatus: corev1.ConditionTrue,
+			wantOffset: 44,
+		},
+		{
+			name:       "serial console not reported yet",
+			method:     infrav1.BootstrapCheckMethodSerialConsole,
+			created:    recent,
+			console:    &fakeConsole{serial: "booting\ncapg-boot"},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: infrav1.WaitingForBootstrapReason,
+			wantOffset: 8,
+		},
+		{
+			name:       "serial console result before the previous lookup",
+			method:     infrav1.BootstrapCheckMethodSerialConsole,
+			created:    recent,
+			console:    &fakeConsole{serial: "capg-bootstrap: failed\nrebooting\n"},
+			offset:     23,
+			wantStatus: corev1.ConditionFalse,
+			wantReason: infrav1.WaitingForBootstrapReason,
+			wantOffset: 33,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcpMachine := getFakeGCPMachine()
+			gcpMachine.Spec.BootstrapCheck = &infrav1.BootstrapCheck{Method: tt.method}
+			if tt.offset != 0 {
+				gcpMachine.Status.BootstrapSerialOffset = pointer.Int64(tt.offset)
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := New(machineScope)
+			s.console = tt.console
+			err = s.reconcileBootstrap(context.TODO(), &compute.Instance{Name: "my-machine", Status: "RUNNING", CreationTimestamp: tt.created})
+			if tt.wantStatus == "" {
+				var bootstrapErr *BootstrapFailedError
+				if !errors.As(err, &bootstrapErr) {
+					t.Fatalf("Service.reconcileBootstrap() error = %v, want a BootstrapFailedError", err)
+				}
+				if bootstrapErr.Reason != tt.wantReason {
+					t.Errorf("BootstrapFailedError reason = %s, want %s", bootstrapErr.Reason, tt.wantReason)
+				}
+				if want := "booting\ncloud-init failed"; bootstrapErr.SerialOutputTail != want {
+					t.Errorf("BootstrapFailedError serial output tail = %q, want %q", bootstrapErr.SerialOutputTail, want)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Service.reconcileBootstrap() error = %v", err)
+			}
+			condition := conditions.Get(gcpMachine, infrav1.BootstrapSucceededCondition)
+			if condition == nil || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("BootstrapSucceeded condition = %+v, want status %s and reason %q", condition, tt.wantStatus, tt.wantReason)
+			}
+			if got := machineScope.BootstrapSerialOffset(); got != tt.wantOffset {
+				t.Errorf("BootstrapSerialOffset() = %d, want %d", got, tt.wantOffset)
+			}
+		})
+	}
+}
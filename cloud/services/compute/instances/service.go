@@ -24,6 +24,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 )
 
@@ -39,12 +40,47 @@ type instancegroupsInterface interface {
 	RemoveInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsRemoveInstancesRequest) error
 }
 
+type disksInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Disk, error)
+}
+
+type labelsInterface interface {
+	SetInstanceLabels(ctx context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error
+	SetDiskLabels(ctx context.Context, key *meta.Key, req *compute.ZoneSetLabelsRequest) error
+}
+
+type consoleInterface interface {
+	GetGuestAttributes(ctx context.Context, key *meta.Key, queryPath string) (*compute.GuestAttributes, error)
+	GetSerialPortOutput(ctx context.Context, key *meta.Key, start int64) (*compute.SerialPortOutput, error)
+}
+
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.Machine
+	InstanceName() string
+	ExistingInstance() string
+	GetAdoptedInstance() *string
+	SetAdoptedInstance(selfLink string, mismatches []string)
 	InstanceSpec(log logr.Logger) *compute.Instance
 	InstanceImageSpec() *compute.AttachedDisk
 	InstanceAdditionalDiskSpec() []*compute.AttachedDisk
+	Labels() infrav1.Labels
+	LabelSetter() cloud.LabelSetter
+	InstanceConsole() cloud.InstanceConsole
+	LabelKeys() []string
+	SetLabelKeys(keys []string)
+	ResourceManagerTags() infrav1.ResourceManagerTags
+	ResourceManagerTagValues() []string
+	SetResourceManagerTagValues(tagValues []string)
+	TagValues() cloud.TagValues
+	TagBindings(ctx context.Context, location string) (cloud.TagBindings, error)
+	IsClusterOrphanOnDelete() bool
+	BootstrapCheck() *infrav1.BootstrapCheck
+	IsBootstrapPending() bool
+	SetBootstrapWaiting()
+	BootstrapSerialOffset() int64
+	SetBootstrapSerialOffset(offset int64)
+	SetBootstrapSucceeded()
 }
 
 // Service implements instances reconciler.
@@ -52,15 +88,27 @@ type Service struct {
 	scope          Scope
 	instances      instancesInterface
 	instancegroups instancegroupsInterface
+	disks          disksInterface
+	labels         labelsInterface
+	console        consoleInterface
+	tagBindings    func(ctx context.Context, location string) (cloud.TagBindings, error)
+	tagValueName   func(ctx context.Context, tag infrav1.ResourceManagerTag) (string, error)
 }
 
 var _ cloud.Reconciler = &Service{}
 
+var _ cloud.Orphaner = &Service{}
+
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
 		scope:          scope,
 		instances:      scope.Cloud().Instances(),
 		instancegroups: scope.Cloud().InstanceGroups(),
+		disks:          scope.Cloud().Disks(),
+		labels:         scope.LabelSetter(),
+		console:        scope.InstanceConsole(),
+		tagBindings:    scope.TagBindings,
+		tagValueName:   scope.TagValues().TagValueName,
 	}
 }
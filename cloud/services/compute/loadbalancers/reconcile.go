@@ -18,11 +18,16 @@ package loadbalancers
 
 import (
 	"context"
+	"path"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -35,14 +40,35 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
-	healthcheck, err := s.createOrGetHealthCheck(ctx)
-	if err != nil {
-		return err
+	var backendsvc *compute.BackendService
+	lb := s.scope.LoadBalancer()
+	if lb.BackendService != nil {
+		backendsvc, err = s.getExistingBackendService(ctx, *lb.BackendService, instancegroups)
+		if err != nil {
+			return err
+		}
+	} else {
+		healthcheck, err := s.createOrGetHealthCheck(ctx)
+		if err != nil {
+			return err
+		}
+
+		backendsvc, err = s.createOrGetBackendService(ctx, instancegroups, healthcheck)
+		if err != nil {
+			return err
+		}
 	}
 
-	backendsvc, err := s.createOrGetBackendService(ctx, instancegroups, healthcheck)
-	if err != nil {
-		return err
+	if lb.ForwardingRule != nil {
+		var addr *compute.Address
+		if lb.Address != nil {
+			addr, err = s.getExistingAddress(ctx, *lb.Address)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.getExistingForwardingRule(ctx, *lb.ForwardingRule, backendsvc, addr)
 	}
 
 	target, err := s.createOrGetTargetTCPProxy(ctx, backendsvc)
@@ -55,36 +81,161 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
-	return s.createForwardingRule(ctx, target, addr)
+	if err := s.createForwardingRule(ctx, target, addr); err != nil {
+		return err
+	}
+
+	// The labels removed from the spec are forgotten once they are removed from the address and the forwarding rule.
+	s.scope.SetLabelKeys(shared.LabelKeys(s.scope.ForwardingRuleSpec().Labels))
+
+	return nil
 }
 
 // Delete delete cluster control-plane loadbalancer compoenents.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Deleting loadbalancer resources")
-	if err := s.deleteForwardingRule(ctx); err != nil {
-		return err
-	}
+	// The existing components referenced by the spec are shared, they are never deleted.
+	lb := s.scope.LoadBalancer()
+	if lb.ForwardingRule == nil {
+		if err := s.deleteForwardingRule(ctx); err != nil {
+			return err
+		}
 
-	if err := s.deleteAddress(ctx); err != nil {
-		return err
+		if err := s.deleteTargetTCPProxy(ctx); err != nil {
+			return err
+		}
+	} else {
+		s.scope.Network().APIServerForwardingRule = nil
+		s.scope.Network().APIServerTargetProxy = nil
 	}
 
-	if err := s.deleteTargetTCPProxy(ctx); err != nil {
-		return err
+	if lb.Address == nil {
+		if err := s.deleteAddress(ctx); err != nil {
+			return err
+		}
+	} else {
+		s.scope.Network().APIServerAddress = nil
 	}
 
-	if err := s.deleteBackendService(ctx); err != nil {
+	if lb.BackendService == nil {
+		if err := s.deleteBackendService(ctx); err != nil {
+			return err
+		}
+	} else if err := s.removeExistingBackends(ctx, *lb.BackendService); err != nil {
 		return err
 	}
 
-	if err := s.deleteHealthCheck(ctx); err != nil {
-		return err
+	if lb.HealthCheck == nil {
+		if err := s.deleteHealthCheck(ctx); err != nil {
+			return err
+		}
+	} else {
+		s.scope.Network().APIServerHealthCheck = nil
 	}
 
 	return s.deleteInstanceGroups(ctx)
 }
 
+// Orphan removes the CAPG labels of the address and the forwarding rule created by capg, and returns the components
+// created by capg to be left behind instead of deleting them. The shared components are left untouched.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx)
+	lb := s.scope.LoadBalancer()
+	orphaned := []string{}
+	if lb.ForwardingRule == nil {
+		spec := s.scope.ForwardingRuleSpec()
+		forwarding, err := s.forwardingrules.Get(ctx, meta.GlobalKey(spec.Name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for forwardingrule", "name", spec.Name)
+			return nil, err
+		}
+
+		if forwarding != nil {
+			if labels, changed := shared.OrphanLabels(forwarding.Labels, spec.Labels); changed {
+				log.V(2).Info("Removing CAPG labels of forwardingrule", "name", spec.Name)
+				if err := s.labels.SetGlobalForwardingRuleLabels(ctx, meta.GlobalKey(spec.Name), &compute.GlobalSetLabelsRequest{
+					Labels:           labels,
+					LabelFingerprint: forwarding.LabelFingerprint,
+				}); err != nil {
+					log.Error(err, "Error removing CAPG labels of forwardingrule", "name", spec.Name)
+					return nil, err
+				}
+			}
+
+			orphaned = append(orphaned, forwarding.SelfLink)
+		}
+
+		target, err := s.targettcpproxies.Get(ctx, meta.GlobalKey(s.scope.TargetTCPProxySpec().Name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if target != nil {
+			orphaned = append(orphaned, target.SelfLink)
+		}
+	}
+
+	if lb.Address == nil {
+		spec := s.scope.AddressSpec()
+		addr, err := s.addresses.Get(ctx, meta.GlobalKey(spec.Name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for address", "name", spec.Name)
+			return nil, err
+		}
+
+		if addr != nil {
+			if labels, changed := shared.OrphanLabels(addr.Labels, spec.Labels); changed {
+				log.V(2).Info("Removing CAPG labels of address", "name", spec.Name)
+				if err := s.labels.SetGlobalAddressLabels(ctx, meta.GlobalKey(spec.Name), &compute.GlobalSetLabelsRequest{
+					Labels:           labels,
+					LabelFingerprint: addr.LabelFingerprint,
+				}); err != nil {
+					log.Error(err, "Error removing CAPG labels of address", "name", spec.Name)
+					return nil, err
+				}
+			}
+
+			orphaned = append(orphaned, addr.SelfLink)
+		}
+	}
+
+	if lb.BackendService == nil {
+		backendsvc, err := s.backendservices.Get(ctx, meta.GlobalKey(s.scope.BackendServiceSpec().Name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if backendsvc != nil {
+			orphaned = append(orphaned, backendsvc.SelfLink)
+		}
+	}
+
+	if lb.HealthCheck == nil {
+		healthcheck, err := s.healthchecks.Get(ctx, meta.GlobalKey(s.scope.HealthCheckSpec().Name))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if healthcheck != nil {
+			orphaned = append(orphaned, healthcheck.SelfLink)
+		}
+	}
+
+	for zone := range s.scope.Network().APIServerInstanceGroups {
+		instancegroup, err := s.instancegroups.Get(ctx, meta.ZonalKey(s.scope.InstanceGroupSpec(zone).Name, zone))
+		if err != nil && !gcperrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if instancegroup != nil {
+			orphaned = append(orphaned, instancegroup.SelfLink)
+		}
+	}
+
+	return orphaned, nil
+}
+
 func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.InstanceGroup, error) {
 	log := log.FromContext(ctx)
 	fd := s.scope.FailureDomains()
@@ -131,6 +282,10 @@ func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.Ins
 
 func (s *Service) createOrGetHealthCheck(ctx context.Context) (*compute.HealthCheck, error) {
 	log := log.FromContext(ctx)
+	if existing := s.scope.LoadBalancer().HealthCheck; existing != nil {
+		return s.getExistingHealthCheck(ctx, *existing)
+	}
+
 	healthcheckSpec := s.scope.HealthCheckSpec()
 	log.V(2).Info("Looking for healthcheck", "name", healthcheckSpec.Name)
 	healthcheck, err := s.healthchecks.Get(ctx, meta.GlobalKey(healthcheckSpec.Name))
@@ -230,6 +385,10 @@ func (s *Service) createOrGetTargetTCPProxy(ctx context.Context, service *comput
 
 func (s *Service) createOrGetAddress(ctx context.Context) (*compute.Address, error) {
 	log := log.FromContext(ctx)
+	if existing := s.scope.LoadBalancer().Address; existing != nil {
+		return s.getExistingAddress(ctx, *existing)
+	}
+
 	addrSpec := s.scope.AddressSpec()
 	log.V(2).Info("Looking for address", "name", addrSpec.Name)
 	addr, err := s.addresses.Get(ctx, meta.GlobalKey(addrSpec.Name))
@@ -251,6 +410,17 @@ func (s *Service) createOrGetAddress(ctx context.Context) (*compute.Address, err
 		}
 	}
 
+	if labels, changed := shared.LabelsToSet(addr.Labels, addrSpec.Labels, s.scope.LabelKeys()); changed {
+		log.V(2).Info("Updating labels of address", "name", addrSpec.Name)
+		if err := s.labels.SetGlobalAddressLabels(ctx, meta.GlobalKey(addrSpec.Name), &compute.GlobalSetLabelsRequest{
+			Labels:           labels,
+			LabelFingerprint: addr.LabelFingerprint,
+		}); err != nil {
+			log.Error(err, "Error updating labels of address", "name", addrSpec.Name)
+			return nil, err
+		}
+	}
+
 	s.scope.Network().APIServerAddress = pointer.String(addr.SelfLink)
 	endpoint := s.scope.ControlPlaneEndpoint()
 	endpoint.Host = addr.Address
@@ -284,10 +454,242 @@ func (s *Service) createForwardingRule(ctx context.Context, target *compute.Targ
 		}
 	}
 
+	if labels, changed := shared.LabelsToSet(forwarding.Labels, spec.Labels, s.scope.LabelKeys()); changed {
+		log.V(2).Info("Updating labels of forwardingrule", "name", spec.Name)
+		if err := s.labels.SetGlobalForwardingRuleLabels(ctx, key, &compute.GlobalSetLabelsRequest{
+			Labels:           labels,
+			LabelFingerprint: forwarding.LabelFingerprint,
+		}); err != nil {
+			log.Error(err, "Error updating labels of forwardingrule", "name", spec.Name)
+			return err
+		}
+	}
+
+	s.scope.Network().APIServerForwardingRule = pointer.String(forwarding.SelfLink)
+	return nil
+}
+
+func (s *Service) getExistingHealthCheck(ctx context.Context, ref string) (*compute.HealthCheck, error) {
+	log := log.FromContext(ctx)
+	name, err := shared.ResourceRefName(ref, s.scope.Project())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid healthcheck reference")
+	}
+
+	log.V(2).Info("Looking for existing healthcheck", "name", name)
+	healthcheck, err := s.healthchecks.Get(ctx, meta.GlobalKey(name))
+	if err != nil {
+		log.Error(err, "Error looking for existing healthcheck", "name", name)
+		return nil, err
+	}
+
+	s.scope.Network().APIServerHealthCheck = pointer.String(healthcheck.SelfLink)
+	return healthcheck, nil
+}
+
+// getExistingBackendService adds the instance groups of the control plane to the backends of an existing
+// backend service, keeping the backends it already has.
+func (s *Service) getExistingBackendService(ctx context.Context, ref string, instancegroups []*compute.InstanceGroup) (*compute.BackendService, error) {
+	log := log.FromContext(ctx)
+	name, err := shared.ResourceRefName(ref, s.scope.Project())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid backendservice reference")
+	}
+
+	log.V(2).Info("Looking for existing backendservice", "name", name)
+	backendsvc, err := s.backendservices.Get(ctx, meta.GlobalKey(name))
+	if err != nil {
+		log.Error(err, "Error looking for existing backendservice", "name", name)
+		return nil, err
+	}
+
+	if backendsvc.Protocol != "TCP" {
+		return nil, errors.Errorf("backendservice %s uses protocol %s, the control plane requires TCP", name, backendsvc.Protocol)
+	}
+
+	balancingMode := "UTILIZATION"
+	if len(backendsvc.Backends) > 0 && backendsvc.Backends[0].BalancingMode != "" {
+		balancingMode = backendsvc.Backends[0].BalancingMode
+	}
+
+	changed := false
+	for _, group := range instancegroups {
+		if hasBackend(backendsvc, group.SelfLink) {
+			continue
+		}
+
+		backendsvc.Backends = append(backendsvc.Backends, &compute.Backend{
+			BalancingMode: balancingMode,
+			Group:         group.SelfLink,
+		})
+		changed = true
+	}
+
+	if changed {
+		log.V(2).Info("Adding instancegroups to existing backendservice", "name", name)
+		if err := s.backendservices.Update(ctx, meta.GlobalKey(name), backendsvc); err != nil {
+			log.Error(err, "Error updating existing backendservice", "name", name)
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIServerBackendService = pointer.String(backendsvc.SelfLink)
+	return backendsvc, nil
+}
+
+func (s *Service) getExistingAddress(ctx context.Context, ref string) (*compute.Address, error) {
+	log := log.FromContext(ctx)
+	name, err := shared.ResourceRefName(ref, s.scope.Project())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid address reference")
+	}
+
+	log.V(2).Info("Looking for existing address", "name", name)
+	addr, err := s.addresses.Get(ctx, meta.GlobalKey(name))
+	if err != nil {
+		log.Error(err, "Error looking for existing address", "name", name)
+		return nil, err
+	}
+
+	if labels, changed := shared.SharedLabelsToSet(addr.Labels, s.scope.SharedLabels(infrav1.APIServerRoleTagValue)); changed {
+		log.V(2).Info("Updating labels of existing address", "name", name)
+		if err := s.labels.SetGlobalAddressLabels(ctx, meta.GlobalKey(name), &compute.GlobalSetLabelsRequest{
+			Labels:           labels,
+			LabelFingerprint: addr.LabelFingerprint,
+		}); err != nil {
+			log.Error(err, "Error updating labels of existing address", "name", name)
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIServerAddress = pointer.String(addr.SelfLink)
+	endpoint := s.scope.ControlPlaneEndpoint()
+	endpoint.Host = addr.Address
+	s.scope.SetControlPlaneEndpoint(endpoint)
+	return addr, nil
+}
+
+// getExistingForwardingRule validates that an existing forwarding rule routes to the backend service of the
+// control plane through a target TCP proxy and, when an address is referenced, that it uses this address.
+func (s *Service) getExistingForwardingRule(ctx context.Context, ref string, backendsvc *compute.BackendService, addr *compute.Address) error {
+	log := log.FromContext(ctx)
+	name, err := shared.ResourceRefName(ref, s.scope.Project())
+	if err != nil {
+		return errors.Wrap(err, "invalid forwardingrule reference")
+	}
+
+	log.V(2).Info("Looking for existing forwardingrule", "name", name)
+	forwarding, err := s.forwardingrules.Get(ctx, meta.GlobalKey(name))
+	if err != nil {
+		log.Error(err, "Error looking for existing forwardingrule", "name", name)
+		return err
+	}
+
+	if !strings.Contains(forwarding.Target, "/targetTcpProxies/") {
+		return errors.Errorf("forwardingrule %s targets %s, the control plane requires a targettcpproxy", name, forwarding.Target)
+	}
+
+	target, err := s.targettcpproxies.Get(ctx, meta.GlobalKey(path.Base(forwarding.Target)))
+	if err != nil {
+		log.Error(err, "Error looking for targettcpproxy of existing forwardingrule", "name", name)
+		return err
+	}
+
+	if path.Base(target.Service) != backendsvc.Name {
+		return errors.Errorf("forwardingrule %s routes to backendservice %s instead of %s", name, target.Service, backendsvc.Name)
+	}
+
+	endpoint := s.scope.ControlPlaneEndpoint()
+	if addr != nil {
+		if forwarding.IPAddress != addr.Address && forwarding.IPAddress != addr.SelfLink {
+			return errors.Errorf("forwardingrule %s uses IP address %s instead of %s", name, forwarding.IPAddress, addr.Address)
+		}
+	} else {
+		endpoint.Host = forwarding.IPAddress
+		s.scope.SetControlPlaneEndpoint(endpoint)
+	}
+
+	if labels, changed := shared.SharedLabelsToSet(forwarding.Labels, s.scope.SharedLabels(infrav1.APIServerRoleTagValue)); changed {
+		log.V(2).Info("Updating labels of existing forwardingrule", "name", name)
+		if err := s.labels.SetGlobalForwardingRuleLabels(ctx, meta.GlobalKey(name), &compute.GlobalSetLabelsRequest{
+			Labels:           labels,
+			LabelFingerprint: forwarding.LabelFingerprint,
+		}); err != nil {
+			log.Error(err, "Error updating labels of existing forwardingrule", "name", name)
+			return err
+		}
+	}
+
+	s.scope.Network().APIServerTargetProxy = pointer.String(target.SelfLink)
 	s.scope.Network().APIServerForwardingRule = pointer.String(forwarding.SelfLink)
 	return nil
 }
 
+// removeExistingBackends removes the instance groups of the control plane from the backends of an existing
+// backend service, so they can be deleted while the backend service is kept.
+func (s *Service) removeExistingBackends(ctx context.Context, ref string) error {
+	log := log.FromContext(ctx)
+	name, err := shared.ResourceRefName(ref, s.scope.Project())
+	if err != nil {
+		return errors.Wrap(err, "invalid backendservice reference")
+	}
+
+	backendsvc, err := s.backendservices.Get(ctx, meta.GlobalKey(name))
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for existing backendservice", "name", name)
+			return err
+		}
+
+		s.scope.Network().APIServerBackendService = nil
+		return nil
+	}
+
+	groups := make(map[string]bool, len(s.scope.Network().APIServerInstanceGroups))
+	for zone := range s.scope.Network().APIServerInstanceGroups {
+		groups["zones/"+zone+"/instanceGroups/"+s.scope.InstanceGroupSpec(zone).Name] = true
+	}
+
+	backends := make([]*compute.Backend, 0, len(backendsvc.Backends))
+	for _, backend := range backendsvc.Backends {
+		if !isGroup(backend.Group, groups) {
+			backends = append(backends, backend)
+		}
+	}
+
+	if len(backends) != len(backendsvc.Backends) {
+		log.V(2).Info("Removing instancegroups from existing backendservice", "name", name)
+		backendsvc.Backends = backends
+		if err := s.backendservices.Update(ctx, meta.GlobalKey(name), backendsvc); err != nil {
+			log.Error(err, "Error updating existing backendservice", "name", name)
+			return err
+		}
+	}
+
+	s.scope.Network().APIServerBackendService = nil
+	return nil
+}
+
+func hasBackend(backendsvc *compute.BackendService, group string) bool {
+	for _, backend := range backendsvc.Backends {
+		if backend.Group == group {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isGroup(link string, groups map[string]bool) bool {
+	for group := range groups {
+		if strings.HasSuffix(link, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Service) deleteForwardingRule(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	spec := s.scope.ForwardingRuleSpec()
@@ -0,0 +1,259 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		LoadBalancer: &infrav1.LoadBalancerSpec{
+			BackendService: pointer.String("projects/my-proj/global/backendServices/shared-apiserver"),
+			ForwardingRule: pointer.String("shared-apiserver"),
+		},
+	},
+	Status: infrav1.GCPClusterStatus{
+		FailureDomains: clusterv1.FailureDomains{
+			"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+		},
+	},
+}
+
+// fakeLabelSetter records the labels set on the addresses and forwarding rules.
+type fakeLabelSetter struct {
+	labels map[string]map[string]string
+}
+
+func (f *fakeLabelSetter) SetGlobalAddressLabels(_ context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error {
+	f.labels[key.Name] = req.Labels
+	return nil
+}
+
+func (f *fakeLabelSetter) SetGlobalForwardingRuleLabels(_ context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error {
+	f.labels[key.Name] = req.Labels
+	return nil
+}
+
+// insertSharedLoadBalancer creates an existing load balancer, with a backend outside the cluster, in the mock.
+func insertSharedLoadBalancer(ctx context.Context, t *testing.T) *cloud.MockGCE {
+	t.Helper()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "my-proj"})
+	mockGCE.MockBackendServices.UpdateHook = mock.UpdateBackendServiceHook
+	for _, err := range []error{
+		mockGCE.BackendServices().Insert(ctx, meta.GlobalKey("shared-apiserver"), &compute.BackendService{
+			Protocol: "TCP",
+			Backends: []*compute.Backend{{BalancingMode: "CONNECTION", Group: "projects/my-proj/zones/us-central1-b/instanceGroups/other"}},
+		}),
+		mockGCE.TargetTcpProxies().Insert(ctx, meta.GlobalKey("shared-apiserver"), &compute.TargetTcpProxy{
+			Service: "projects/my-proj/global/backendServices/shared-apiserver",
+		}),
+		mockGCE.GlobalForwardingRules().Insert(ctx, meta.GlobalKey("shared-apiserver"), &compute.ForwardingRule{
+			IPAddress: "10.0.0.1",
+			Target:    "projects/my-proj/global/targetTcpProxies/shared-apiserver",
+		}),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return mockGCE
+}
+
+func getService(clusterScope *scope.ClusterScope, mockGCE *cloud.MockGCE, labels *fakeLabelSetter) *Service {
+	s := New(clusterScope)
+	s.addresses = mockGCE.GlobalAddresses()
+	s.backendservices = mockGCE.BackendServices()
+	s.forwardingrules = mockGCE.GlobalForwardingRules()
+	s.healthchecks = mockGCE.HealthChecks()
+	s.instancegroups = mockGCE.InstanceGroups()
+	s.targettcpproxies = mockGCE.TargetTcpProxies()
+	s.labels = labels
+	return s
+}
+
+func TestService_ReconcileExisting(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := insertSharedLoadBalancer(ctx, t)
+	labels := &fakeLabelSetter{labels: map[string]map[string]string{}}
+
+	if err := getService(clusterScope, mockGCE, labels).Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	backendsvc, err := mockGCE.BackendServices().Get(ctx, meta.GlobalKey("shared-apiserver"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backendsvc.Backends) != 2 || backendsvc.Backends[1].BalancingMode != "CONNECTION" {
+		t.Errorf("Reconcile() backends = %+v, want the instance group added with the existing balancing mode", backendsvc.Backends)
+	}
+
+	if _, err := mockGCE.HealthChecks().Get(ctx, meta.GlobalKey(clusterScope.HealthCheckSpec().Name)); err == nil {
+		t.Error("Reconcile() created a healthcheck for an existing backendservice")
+	}
+	if _, err := mockGCE.GlobalForwardingRules().Get(ctx, meta.GlobalKey(clusterScope.ForwardingRuleSpec().Name)); err == nil {
+		t.Error("Reconcile() created a forwardingrule along an existing one")
+	}
+
+	if got := clusterScope.ControlPlaneEndpoint().Host; got != "10.0.0.1" {
+		t.Errorf("Reconcile() endpoint = %q, want the IP address of the existing forwardingrule", got)
+	}
+
+	lifecycle := labels.labels["shared-apiserver"][infrav1.SanitizeLabelKey(infrav1.ClusterTagKey(clusterScope.Name()))]
+	if lifecycle != string(infrav1.ResourceLifecycleShared) {
+		t.Errorf("Reconcile() forwardingrule lifecycle label = %q, want %q", lifecycle, infrav1.ResourceLifecycleShared)
+	}
+}
+
+func TestService_ReconcileExistingWrongTarget(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := insertSharedLoadBalancer(ctx, t)
+	if err := mockGCE.BackendServices().Insert(ctx, meta.GlobalKey("other"), &compute.BackendService{Protocol: "TCP"}); err != nil {
+		t.Fatal(err)
+	}
+	clusterScope.GCPCluster.Spec.LoadBalancer.BackendService = pointer.String("other")
+
+	err = getService(clusterScope, mockGCE, &fakeLabelSetter{labels: map[string]map[string]string{}}).Reconcile(ctx)
+	if err == nil || !strings.Contains(err.Error(), "routes to backendservice") {
+		t.Fatalf("Reconcile() error = %v, want a target mismatch", err)
+	}
+}
+
+func TestService_DeleteExisting(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := insertSharedLoadBalancer(ctx, t)
+	s := getService(clusterScope, mockGCE, &fakeLabelSetter{labels: map[string]map[string]string{}})
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if err := s.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	backendsvc, err := mockGCE.BackendServices().Get(ctx, meta.GlobalKey("shared-apiserver"))
+	if err != nil {
+		t.Fatalf("Delete() deleted the existing backendservice: %v", err)
+	}
+	if len(backendsvc.Backends) != 1 || !strings.HasSuffix(backendsvc.Backends[0].Group, "/instanceGroups/other") {
+		t.Errorf("Delete() backends = %+v, want only the backend outside the cluster", backendsvc.Backends)
+	}
+
+	for _, err := range []error{
+		func() error {
+			_, err := mockGCE.TargetTcpProxies().Get(ctx, meta.GlobalKey("shared-apiserver"))
+			return err
+		}(),
+		func() error {
+			_, err := mockGCE.GlobalForwardingRules().Get(ctx, meta.GlobalKey("shared-apiserver"))
+			return err
+		}(),
+	} {
+		if err != nil {
+			t.Errorf("Delete() deleted an existing component: %v", err)
+		}
+	}
+
+	if _, err := mockGCE.InstanceGroups().Get(ctx, meta.ZonalKey(clusterScope.InstanceGroupSpec("us-central1-a").Name, "us-central1-a")); err == nil {
+		t.Error("Delete() kept the instancegroup of the cluster")
+	}
+
+	network := clusterScope.Network()
+	if network.APIServerBackendService != nil || network.APIServerForwardingRule != nil || network.APIServerTargetProxy != nil {
+		t.Errorf("Delete() kept the status of the existing components: %+v", network)
+	}
+}
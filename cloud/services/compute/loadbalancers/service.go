@@ -22,6 +22,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 )
 
@@ -63,9 +64,18 @@ type targettcpproxiesInterface interface {
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
+type labelsInterface interface {
+	SetGlobalAddressLabels(ctx context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error
+	SetGlobalForwardingRuleLabels(ctx context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest) error
+}
+
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.Cluster
+	LoadBalancer() *infrav1.LoadBalancerSpec
+	SharedLabels(role string) infrav1.Labels
+	LabelKeys() []string
+	SetLabelKeys(keys []string)
 	AddressSpec() *compute.Address
 	BackendServiceSpec() *compute.BackendService
 	ForwardingRuleSpec() *compute.ForwardingRule
@@ -83,10 +93,13 @@ type Service struct {
 	healthchecks     healthchecksInterface
 	instancegroups   instancegroupsInterface
 	targettcpproxies targettcpproxiesInterface
+	labels           labelsInterface
 }
 
 var _ cloud.Reconciler = &Service{}
 
+var _ cloud.Orphaner = &Service{}
+
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
@@ -97,5 +110,6 @@ func New(scope Scope) *Service {
 		healthchecks:     scope.Cloud().HealthChecks(),
 		instancegroups:   scope.Cloud().InstanceGroups(),
 		targettcpproxies: scope.Cloud().TargetTcpProxies(),
+		labels:           scope.LabelSetter(),
 	}
 }
@@ -18,12 +18,16 @@ package networks
 
 import (
 	"context"
+	"path"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -36,7 +40,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
-	if network.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+	if existing := s.scope.ExistingRouter(); existing != "" {
+		router, err := s.getExistingRouter(ctx, network, existing)
+		if err != nil {
+			return err
+		}
+
+		s.scope.Network().Router = pointer.String(router.SelfLink)
+	} else if network.Description == infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
 		router, err := s.createOrGetRouter(ctx, network)
 		if err != nil {
 			return err
@@ -60,7 +71,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return gcperrors.IgnoreNotFound(err)
 	}
 
-	if network.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+	if network.Description != infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
 		return nil
 	}
 
@@ -74,7 +85,8 @@ func (s *Service) Delete(ctx context.Context) error {
 		return err
 	}
 
-	if router != nil && router.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+	// An existing router of the spec is shared, only the router created by capg is deleted.
+	if router != nil && router.Description == infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
 		if err := s.routers.Delete(ctx, routerKey); err != nil && !gcperrors.IsNotFound(err) {
 			return err
 		}
@@ -90,6 +102,35 @@ func (s *Service) Delete(ctx context.Context) error {
 	return nil
 }
 
+// Orphan returns the network and the cloudnat router created by capg, which are left behind instead of being deleted.
+// Their ownership is recorded in their description, which cannot be changed.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx)
+	networkKey := meta.GlobalKey(s.scope.NetworkName())
+	log.V(2).Info("Looking for network before orphaning", "name", networkKey)
+	network, err := s.networks.Get(ctx, networkKey)
+	if err != nil {
+		return nil, gcperrors.IgnoreNotFound(err)
+	}
+
+	if network.Description != infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
+		return nil, nil
+	}
+
+	orphaned := []string{network.SelfLink}
+	routerSpec := s.scope.NatRouterSpec()
+	router, err := s.routers.Get(ctx, meta.RegionalKey(routerSpec.Name, s.scope.Region()))
+	if err != nil && !gcperrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if router != nil && router.Description == infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
+		orphaned = append(orphaned, router.SelfLink)
+	}
+
+	return orphaned, nil
+}
+
 // createOrGetNetwork creates a network if not exist otherwise return existing network.
 func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, error) {
 	log := log.FromContext(ctx)
@@ -117,6 +158,32 @@ func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, err
 	return network, nil
 }
 
+// getExistingRouter returns the existing router of the spec after checking that it is in the network of the cluster.
+func (s *Service) getExistingRouter(ctx context.Context, network *compute.Network, existing string) (*compute.Router, error) {
+	log := log.FromContext(ctx)
+	name, err := shared.ResourceRefName(existing, s.scope.Project())
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(existing, "/regions/") && !strings.Contains(existing, "/regions/"+s.scope.Region()+"/") {
+		return nil, errors.Errorf("router %s is not in the region %s of the cluster", existing, s.scope.Region())
+	}
+
+	log.V(2).Info("Looking for existing router", "name", name)
+	router, err := s.routers.Get(ctx, meta.RegionalKey(name, s.scope.Region()))
+	if err != nil {
+		log.Error(err, "Error looking for existing router", "name", name)
+		return nil, err
+	}
+
+	if path.Base(router.Network) != network.Name {
+		return nil, errors.Errorf("router %s is not in the network %s of the cluster", name, network.Name)
+	}
+
+	return router, nil
+}
+
 // createOrGetRouter creates a cloudnat router if not exist otherwise return the existing.
 func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Network) (*compute.Router, error) {
 	log := log.FromContext(ctx)
@@ -131,7 +198,7 @@ func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Networ
 		}
 
 		spec.Network = network.SelfLink
-		spec.Description = infrav1.ClusterTagKey(s.scope.Name())
+		spec.Description = infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name()))
 		log.V(2).Info("Creating a cloudnat router", "name", spec.Name)
 		if err := s.routers.Insert(ctx, routerKey, spec); err != nil {
 			log.Error(err, "Error creating a cloudnat router", "name", spec.Name)
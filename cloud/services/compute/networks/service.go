@@ -41,6 +41,7 @@ type Scope interface {
 	cloud.Cluster
 	NetworkSpec() *compute.Network
 	NatRouterSpec() *compute.Router
+	ExistingRouter() string
 }
 
 // Service implements networks reconciler.
@@ -52,6 +53,8 @@ type Service struct {
 
 var _ cloud.Reconciler = &Service{}
 
+var _ cloud.Orphaner = &Service{}
+
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
@@ -0,0 +1,206 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routes
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconcile cluster route components.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling route resources")
+
+	specs := s.scope.RouteSpecs()
+	// Stale pod CIDR routes can only be told apart once the current set of Nodes is known.
+	collectPodCIDRRoutes := true
+	if s.scope.PodCIDRRoutesEnabled() {
+		podCIDRSpecs, err := s.podCIDRRouteSpecs(ctx)
+		if err != nil {
+			// The workload cluster is not reachable until the first control plane
+			// machine is up, keep the existing pod CIDR routes until then.
+			log.V(2).Info("Unable to list workload cluster nodes, skipping pod CIDR routes", "reason", err.Error())
+			collectPodCIDRRoutes = false
+		}
+		specs = append(specs, podCIDRSpecs...)
+	}
+
+	desired := make(map[string]*compute.Route, len(specs))
+	for _, spec := range specs {
+		desired[spec.Name] = spec
+	}
+
+	owned, err := s.listOwnedRoutes(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := sets.New[string]()
+	for _, route := range owned {
+		spec, ok := desired[route.Name]
+		if ok && routeMatchesSpec(route, spec) {
+			current.Insert(route.Name)
+			continue
+		}
+
+		if !ok && !collectPodCIDRRoutes && strings.HasPrefix(route.Name, s.scope.PodCIDRRoutePrefix()) {
+			continue
+		}
+
+		// Routes are immutable, outdated routes are deleted and created again below.
+		log.V(2).Info("Deleting route", "name", route.Name)
+		if err := s.routes.Delete(ctx, meta.GlobalKey(route.Name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting route", "name", route.Name)
+			return err
+		}
+	}
+
+	for _, spec := range specs {
+		if current.Has(spec.Name) {
+			continue
+		}
+
+		log.V(2).Info("Creating route", "name", spec.Name, "destRange", spec.DestRange)
+		if err := s.routes.Insert(ctx, meta.GlobalKey(spec.Name), spec); err != nil {
+			log.Error(err, "Error creating route", "name", spec.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete delete cluster route components.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Deleting route resources")
+	owned, err := s.listOwnedRoutes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, route := range owned {
+		log.V(2).Info("Deleting route", "name", route.Name)
+		if err := s.routes.Delete(ctx, meta.GlobalKey(route.Name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting route", "name", route.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Orphan returns the routes owned by the cluster, which are left behind instead of being deleted.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	owned, err := s.listOwnedRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orphaned := make([]string, 0, len(owned))
+	for _, route := range owned {
+		orphaned = append(orphaned, route.SelfLink)
+	}
+
+	return orphaned, nil
+}
+
+// podCIDRRouteSpecs returns the route specs for the pod CIDRs of every workload cluster Node.
+func (s *Service) podCIDRRouteSpecs(ctx context.Context) ([]*compute.Route, error) {
+	log := log.FromContext(ctx)
+	nodes, err := s.scope.WorkloadNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := []*compute.Route{}
+	for _, node := range nodes {
+		if node.Spec.ProviderID == "" {
+			continue
+		}
+
+		id, err := providerid.Parse(node.Spec.ProviderID)
+		if err != nil {
+			log.V(4).Info("Skipping node with unexpected provider id", "node", node.Name, "providerID", node.Spec.ProviderID)
+			continue
+		}
+
+		podCIDRs := node.Spec.PodCIDRs
+		if len(podCIDRs) == 0 && node.Spec.PodCIDR != "" {
+			podCIDRs = []string{node.Spec.PodCIDR}
+		}
+
+		instance := path.Join("projects", id.Project(), "zones", id.Location(), "instances", id.Name())
+		for _, podCIDR := range podCIDRs {
+			specs = append(specs, s.scope.PodCIDRRouteSpec(node.Name, podCIDR, instance))
+		}
+	}
+
+	return specs, nil
+}
+
+// listOwnedRoutes returns the routes created by capg for the cluster.
+func (s *Service) listOwnedRoutes(ctx context.Context) ([]*compute.Route, error) {
+	log := log.FromContext(ctx)
+	description := infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name()))
+	routes, err := s.routes.List(ctx, filter.Regexp("description", regexp.QuoteMeta(description)))
+	if err != nil {
+		log.Error(err, "Error listing routes")
+		return nil, err
+	}
+
+	owned := make([]*compute.Route, 0, len(routes))
+	for _, route := range routes {
+		if route.Description == description {
+			owned = append(owned, route)
+		}
+	}
+
+	return owned, nil
+}
+
+// routeMatchesSpec reports whether an existing route is equivalent to the desired spec.
+func routeMatchesSpec(route, spec *compute.Route) bool {
+	return route.DestRange == spec.DestRange &&
+		route.Priority == spec.Priority &&
+		(spec.NextHopIp == "" || route.NextHopIp == spec.NextHopIp) &&
+		matchesLink(route.NextHopInstance, spec.NextHopInstance) &&
+		matchesLink(route.NextHopGateway, spec.NextHopGateway) &&
+		sets.New(route.Tags...).Equal(sets.New(spec.Tags...))
+}
+
+// matchesLink reports whether a full resource URL returned by the API refers to
+// the given, possibly partial, resource URL.
+func matchesLink(link, partial string) bool {
+	if partial == "" {
+		return link == ""
+	}
+
+	return strings.HasSuffix(link, partial)
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Routes: &infrav1.RoutesSpec{
+				PodCIDRRoutes: true,
+				StaticRoutes: []infrav1.StaticRouteSpec{
+					{
+						Name:           "egress",
+						DestRange:      "0.0.0.0/0",
+						Priority:       pointer.Int64(900),
+						NextHopGateway: pointer.String("global/gateways/default-internet-gateway"),
+					},
+				},
+			},
+		},
+	},
+}
+
+var fakeNode = corev1.Node{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "my-node",
+	},
+	Spec: corev1.NodeSpec{
+		PodCIDR:    "192.168.1.0/24",
+		ProviderID: "gce://my-proj/us-central1-a/my-node",
+	},
+}
+
+// fakeScope overrides the workload cluster access of a ClusterScope.
+type fakeScope struct {
+	*scope.ClusterScope
+	nodes    []corev1.Node
+	nodesErr error
+}
+
+func (f *fakeScope) WorkloadNodes(_ context.Context) ([]corev1.Node, error) {
+	return f.nodes, f.nodesErr
+}
+
+type testCase struct {
+	name       string
+	scope      func() Scope
+	mockRoutes *cloud.MockRoutes
+	wantErr    bool
+	assert     func(ctx context.Context, t testCase) error
+}
+
+func TestService_Reconcile(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	podCIDRRoute := clusterScope.PodCIDRRouteSpec(fakeNode.Name, fakeNode.Spec.PodCIDR, "projects/my-proj/zones/us-central1-a/instances/my-node")
+	staleRoute := clusterScope.PodCIDRRouteSpec("my-old-node", "192.168.2.0/24", "projects/my-proj/zones/us-central1-a/instances/my-old-node")
+
+	tests := []testCase{
+		{
+			name:  "routes do not exist (should create static and pod CIDR routes)",
+			scope: func() Scope { return &fakeScope{ClusterScope: clusterScope, nodes: []corev1.Node{fakeNode}} },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutesObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				egress, err := t.mockRoutes.Get(ctx, meta.GlobalKey("my-cluster-egress"))
+				if err != nil {
+					return err
+				}
+				if egress.DestRange != "0.0.0.0/0" || egress.Priority != 900 || egress.Description != infrav1.ClusterTagKey(fakeCluster.Name) {
+					return errors.New("static route was created but with wrong values")
+				}
+
+				route, err := t.mockRoutes.Get(ctx, meta.GlobalKey(podCIDRRoute.Name))
+				if err != nil {
+					return err
+				}
+				if route.DestRange != fakeNode.Spec.PodCIDR || route.NextHopInstance != podCIDRRoute.NextHopInstance {
+					return errors.New("pod CIDR route was created but with wrong values")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "stale pod CIDR route exists (should delete it)",
+			scope: func() Scope { return &fakeScope{ClusterScope: clusterScope, nodes: []corev1.Node{}} },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey(staleRoute.Name): {Obj: staleRoute},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRoutes.Get(ctx, meta.GlobalKey(staleRoute.Name)); err == nil {
+					return errors.New("stale pod CIDR route was not deleted")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "workload cluster not reachable (should keep pod CIDR routes)",
+			scope: func() Scope {
+				return &fakeScope{ClusterScope: clusterScope, nodesErr: errors.New("connection refused")}
+			},
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey(staleRoute.Name): {Obj: staleRoute},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRoutes.Get(ctx, meta.GlobalKey(staleRoute.Name)); err != nil {
+					return errors.New("pod CIDR route was deleted while nodes were unknown")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "route creation fails (should return an error)",
+			scope: func() Scope { return &fakeScope{ClusterScope: clusterScope} },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutesObj{},
+				InsertError: map[meta.Key]error{
+					*meta.GlobalKey("my-cluster-egress"): &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routes = tt.mockRoutes
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt)
+				if err != nil {
+					t.Errorf("routes were not reconciled as expected: %v", err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	podCIDRRoute := clusterScope.PodCIDRRouteSpec(fakeNode.Name, fakeNode.Spec.PodCIDR, "projects/my-proj/zones/us-central1-a/instances/my-node")
+
+	tests := []testCase{
+		{
+			name:  "owned routes exist, should delete them",
+			scope: func() Scope { return &fakeScope{ClusterScope: clusterScope} },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey(podCIDRRoute.Name): {Obj: podCIDRRoute},
+					*meta.GlobalKey("not-owned"):       {Obj: &compute.Route{Name: "not-owned", Description: "foo"}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRoutes.Get(ctx, meta.GlobalKey(podCIDRRoute.Name)); err == nil {
+					return errors.New("owned route was not deleted")
+				}
+				if _, err := t.mockRoutes.Get(ctx, meta.GlobalKey("not-owned")); err != nil {
+					return errors.New("route not owned by the cluster was deleted")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "error deleting route, should return error",
+			scope: func() Scope { return &fakeScope{ClusterScope: clusterScope} },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey(podCIDRRoute.Name): {Obj: podCIDRRoute},
+				},
+				DeleteError: map[meta.Key]error{
+					*meta.GlobalKey(podCIDRRoute.Name): &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routes = tt.mockRoutes
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt)
+				if err != nil {
+					t.Errorf("routes were not deleted as expected: %v", err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routes
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type routesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Route, error)
+	List(ctx context.Context, fl *filter.F) ([]*compute.Route, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.Route) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.ClusterGetter
+	RouteSpecs() []*compute.Route
+	PodCIDRRoutesEnabled() bool
+	PodCIDRRoutePrefix() string
+	PodCIDRRouteSpec(nodeName, podCIDR, instance string) *compute.Route
+	WorkloadNodes(ctx context.Context) ([]corev1.Node, error)
+}
+
+// Service implements routes reconciler.
+type Service struct {
+	scope  Scope
+	routes routesInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+var _ cloud.Orphaner = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope:  scope,
+		routes: scope.Cloud().Routes(),
+	}
+}
@@ -54,6 +54,28 @@ func (s *Service) Delete(ctx context.Context) error {
 	return nil
 }
 
+// Orphan returns the subnets of the spec, which are left behind instead of being deleted.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	logger := log.FromContext(ctx)
+	orphaned := []string{}
+	for _, subnetSpec := range s.scope.SubnetSpecs() {
+		logger.V(2).Info("Looking for subnet before orphaning", "name", subnetSpec.Name)
+		subnet, err := s.subnets.Get(ctx, meta.RegionalKey(subnetSpec.Name, s.scope.Region()))
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				continue
+			}
+
+			logger.Error(err, "Error looking for subnet", "name", subnetSpec.Name)
+			return nil, err
+		}
+
+		orphaned = append(orphaned, subnet.SelfLink)
+	}
+
+	return orphaned, nil
+}
+
 // createOrGetSubnets creates the subnetworks if they don't exist otherwise return the existing ones.
 func (s *Service) createOrGetSubnets(ctx context.Context) ([]*compute.Subnetwork, error) {
 	logger := log.FromContext(ctx)
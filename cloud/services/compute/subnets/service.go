@@ -44,6 +44,8 @@ type Service struct {
 
 var _ cloud.Reconciler = &Service{}
 
+var _ cloud.Orphaner = &Service{}
+
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagbindings
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// globalLocation is the location of the tag bindings of global resources.
+const globalLocation = "global"
+
+// taggedResource is a resource of the cluster bound to the resource manager tags of the cluster.
+type taggedResource struct {
+	// location is the location of the tag bindings of the resource, global or a region.
+	location string
+	// name is the full resource name of the resource, e.g.
+	// //compute.googleapis.com/projects/my-proj/global/networks/1234567890.
+	name string
+}
+
+// Reconcile binds the resource manager tags of the cluster to the resources created for the cluster,
+// and removes the bindings of the tags previously applied by CAPG which are not part of the spec anymore.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	applied := s.scope.ResourceManagerTagValues()
+	if len(s.scope.ResourceManagerTags()) == 0 && len(applied) == 0 {
+		return nil
+	}
+
+	log.Info("Reconciling tag bindings")
+
+	tagValues, err := shared.TagValueNames(ctx, s.scope.ResourceManagerTags(), s.tagValueName)
+	if err != nil {
+		return err
+	}
+
+	resources, err := s.ownedResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		bindings, err := s.tagBindings(ctx, resource.location)
+		if err != nil {
+			return err
+		}
+		if err := shared.ReconcileTagBindings(ctx, bindings, resource.name, tagValues, sets.New(applied...)); err != nil {
+			return err
+		}
+	}
+
+	// The tag values removed from the spec are forgotten once they are unbound from all the resources.
+	s.scope.SetResourceManagerTagValues(sets.List(tagValues))
+
+	return nil
+}
+
+// Delete is a no-op, the tag bindings of the resources are deleted with the resources.
+func (s *Service) Delete(_ context.Context) error {
+	return nil
+}
+
+// ownedResources returns the existing resources created for the cluster: the network, subnets,
+// firewall rules, and the address and forwarding rule of the control plane load balancer.
+func (s *Service) ownedResources(ctx context.Context) ([]taggedResource, error) {
+	var resources []taggedResource
+
+	network, err := s.networks.Get(ctx, meta.GlobalKey(s.scope.NetworkName()))
+	if err != nil && !gcperrors.IsNotFound(err) {
+		return nil, err
+	}
+	if network != nil && network.Description == infrav1.ClusterTagKey(s.scope.ResourceName(s.scope.Name())) {
+		resources = append(resources, taggedResource{location: globalLocation, name: shared.ComputeResourceName(network.SelfLink, network.Id)})
+	}
+
+	for _, spec := range s.scope.SubnetSpecs() {
+		subnet, err := s.subnets.Get(ctx, meta.RegionalKey(spec.Name, s.scope.Region()))
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if subnet.Description == spec.Description {
+			resources = append(resources, taggedResource{location: s.scope.Region(), name: shared.ComputeResourceName(subnet.SelfLink, subnet.Id)})
+		}
+	}
+
+	if s.scope.FirewallPolicy() == nil {
+		for _, spec := range s.scope.FirewallRulesSpec() {
+			firewall, err := s.firewalls.Get(ctx, meta.GlobalKey(spec.Name))
+			if err != nil {
+				if gcperrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			resources = append(resources, taggedResource{location: globalLocation, name: shared.ComputeResourceName(firewall.SelfLink, firewall.Id)})
+		}
+	}
+
+	address, err := s.addresses.Get(ctx, meta.GlobalKey(s.scope.AddressSpec().Name))
+	if err != nil && !gcperrors.IsNotFound(err) {
+		return nil, err
+	}
+	if address != nil {
+		resources = append(resources, taggedResource{location: globalLocation, name: shared.ComputeResourceName(address.SelfLink, address.Id)})
+	}
+
+	forwardingRule, err := s.forwardingrules.Get(ctx, meta.GlobalKey(s.scope.ForwardingRuleSpec().Name))
+	if err != nil && !gcperrors.IsNotFound(err) {
+		return nil, err
+	}
+	if forwardingRule != nil {
+		resources = append(resources, taggedResource{location: globalLocation, name: shared.ComputeResourceName(forwardingRule.SelfLink, forwardingRule.Id)})
+	}
+
+	return resources, nil
+}
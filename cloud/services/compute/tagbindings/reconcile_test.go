@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagbindings
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	rmpb "cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	capgcloud "sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: pointer.String("my-network"),
+		},
+		ResourceManagerTags: infrav1.ResourceManagerTags{
+			{ParentID: "my-proj", Key: "env", Value: "prod"},
+		},
+	},
+}
+
+// fakeTagBindings is an in-memory TagBindings.
+type fakeTagBindings struct {
+	bindings map[string]*rmpb.TagBinding
+}
+
+func (f *fakeTagBindings) List(_ context.Context, parent string) ([]*rmpb.TagBinding, error) {
+	var bindings []*rmpb.TagBinding
+	for _, binding := range f.bindings {
+		if binding.Parent == parent {
+			bindings = append(bindings, binding)
+		}
+	}
+
+	return bindings, nil
+}
+
+func (f *fakeTagBindings) Create(_ context.Context, parent, tagValue string) error {
+	name := fmt.Sprintf("tagBindings/%s/%s", parent, tagValue)
+	f.bindings[name] = &rmpb.TagBinding{Name: name, Parent: parent, TagValue: tagValue}
+	return nil
+}
+
+func (f *fakeTagBindings) Delete(_ context.Context, name string) error {
+	delete(f.bindings, name)
+	return nil
+}
+
+func fakeTagValueName(_ context.Context, tag infrav1.ResourceManagerTag) (string, error) {
+	return "tagValues/" + tag.Value, nil
+}
+
+func TestService_Reconcile(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "my-proj"})
+	_ = mockGCE.Networks().Insert(ctx, meta.GlobalKey("my-network"), &compute.Network{Id: 1, Description: infrav1.ClusterTagKey("my-cluster")})
+	_ = mockGCE.GlobalAddresses().Insert(ctx, meta.GlobalKey("my-cluster-apiserver"), &compute.Address{Id: 2})
+
+	global := &fakeTagBindings{bindings: map[string]*rmpb.TagBinding{}}
+	networkName := "//compute.googleapis.com/projects/my-proj/global/networks/1"
+	addressName := "//compute.googleapis.com/projects/my-proj/global/addresses/2"
+	// The binding of a tag applied by CAPG and removed from the spec.
+	_ = global.Create(ctx, networkName, "tagValues/staging")
+	clusterScope.SetResourceManagerTagValues([]string{"tagValues/prod", "tagValues/staging"})
+	// The binding of a tag bound by other means.
+	_ = global.Create(ctx, networkName, "tagValues/external")
+
+	s := New(clusterScope)
+	s.networks = mockGCE.Networks()
+	s.subnets = mockGCE.Subnetworks()
+	s.firewalls = mockGCE.Firewalls()
+	s.addresses = mockGCE.GlobalAddresses()
+	s.forwardingrules = mockGCE.GlobalForwardingRules()
+	s.tagValueName = fakeTagValueName
+	s.tagBindings = func(_ context.Context, location string) (capgcloud.TagBindings, error) {
+		if location != globalLocation {
+			t.Errorf("unexpected tag bindings location %q", location)
+		}
+		return global, nil
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := sets.New[string]()
+	for _, binding := range global.bindings {
+		got.Insert(binding.Parent + "=" + binding.TagValue)
+	}
+	want := sets.New(networkName+"=tagValues/prod", networkName+"=tagValues/external", addressName+"=tagValues/prod")
+	if !got.Equal(want) {
+		t.Errorf("tag bindings = %v, want %v", sets.List(got), sets.List(want))
+	}
+	if got, want := clusterScope.ResourceManagerTagValues(), []string{"tagValues/prod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ResourceManagerTagValues() = %v, want %v", got, want)
+	}
+}
+
+func TestService_ReconcileWithoutTags(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterScope.GCPCluster.Spec.ResourceManagerTags = nil
+
+	s := New(clusterScope)
+	s.tagBindings = func(_ context.Context, _ string) (capgcloud.TagBindings, error) {
+		t.Error("unexpected tag bindings lookup of a cluster without tags")
+		return nil, nil
+	}
+
+	if err := s.Reconcile(context.TODO()); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+}
+
+func TestService_ReconcileNetworkNotOwned(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "my-proj"})
+	_ = mockGCE.Networks().Insert(ctx, meta.GlobalKey("my-network"), &compute.Network{Id: 1, Description: "user managed"})
+
+	global := &fakeTagBindings{bindings: map[string]*rmpb.TagBinding{}}
+	s := New(clusterScope)
+	s.networks = mockGCE.Networks()
+	s.subnets = mockGCE.Subnetworks()
+	s.firewalls = mockGCE.Firewalls()
+	s.addresses = mockGCE.GlobalAddresses()
+	s.forwardingrules = mockGCE.GlobalForwardingRules()
+	s.tagValueName = fakeTagValueName
+	s.tagBindings = func(_ context.Context, _ string) (capgcloud.TagBindings, error) {
+		return global, nil
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(global.bindings) != 0 {
+		t.Errorf("expected no tag bindings on resources not owned by the cluster, got %d", len(global.bindings))
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		selfLink string
+		id       uint64
+		want     string
+	}{
+		{
+			name:     "global resource",
+			selfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/my-network",
+			id:       1234567890,
+			want:     "//compute.googleapis.com/projects/my-proj/global/networks/1234567890",
+		},
+		{
+			name:     "regional resource",
+			selfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1/subnetworks/my-subnet",
+			id:       42,
+			want:     "//compute.googleapis.com/projects/my-proj/regions/us-central1/subnetworks/42",
+		},
+		{
+			name:     "zonal resource",
+			selfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-instance",
+			id:       7,
+			want:     "//compute.googleapis.com/projects/my-proj/zones/us-central1-a/instances/7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shared.ComputeResourceName(tt.selfLink, tt.id); got != tt.want {
+				t.Errorf("ComputeResourceName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagbindings
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type networksInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Network, error)
+}
+
+type subnetsInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Subnetwork, error)
+}
+
+type firewallsInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Firewall, error)
+}
+
+type addressesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Address, error)
+}
+
+type forwardingrulesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.ForwardingRule, error)
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.ClusterGetter
+	NetworkSpec() *compute.Network
+	SubnetSpecs() []*compute.Subnetwork
+	FirewallRulesSpec() []*compute.Firewall
+	FirewallPolicy() *infrav1.FirewallPolicySpec
+	AddressSpec() *compute.Address
+	ForwardingRuleSpec() *compute.ForwardingRule
+	TagBindings(ctx context.Context, location string) (cloud.TagBindings, error)
+	ResourceManagerTagValues() []string
+	SetResourceManagerTagValues(tagValues []string)
+}
+
+// Service implements tag bindings reconciler.
+type Service struct {
+	scope           Scope
+	networks        networksInterface
+	subnets         subnetsInterface
+	firewalls       firewallsInterface
+	addresses       addressesInterface
+	forwardingrules forwardingrulesInterface
+	tagBindings     func(ctx context.Context, location string) (cloud.TagBindings, error)
+	tagValueName    func(ctx context.Context, tag infrav1.ResourceManagerTag) (string, error)
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope:           scope,
+		networks:        scope.Cloud().Networks(),
+		subnets:         scope.Cloud().Subnetworks(),
+		firewalls:       scope.Cloud().Firewalls(),
+		addresses:       scope.Cloud().GlobalAddresses(),
+		forwardingrules: scope.Cloud().GlobalForwardingRules(),
+		tagBindings:     scope.TagBindings,
+		tagValueName:    scope.TagValues().TagValueName,
+	}
+}
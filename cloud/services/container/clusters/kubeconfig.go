@@ -238,8 +238,20 @@ func (s *Service) createBaseKubeConfig(contextName string, cluster *containerpb.
 }
 
 func (s *Service) generateToken(ctx context.Context) (string, error) {
+	credential := s.scope.GetCredential()
+	if credential.ClientEmail == "" || credential.Impersonated {
+		// The credentials do not act as a service account, e.g. a federated identity
+		// granted access to the cluster directly, or already return the tokens of the
+		// impersonated service account: use their own access token.
+		token, err := credential.TokenSource.Token()
+		if err != nil {
+			return "", errors.Errorf("error getting access token: %v", err)
+		}
+		return token.AccessToken, nil
+	}
+
 	req := &credentialspb.GenerateAccessTokenRequest{
-		Name: fmt.Sprintf("projects/-/serviceAccounts/%s", s.scope.GetCredential().ClientEmail),
+		Name: fmt.Sprintf("projects/-/serviceAccounts/%s", credential.ClientEmail),
 		Scope: []string{
 			GkeScope,
 		},
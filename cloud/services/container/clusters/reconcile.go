@@ -20,15 +20,14 @@ import (
 	"context"
 	"fmt"
 
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
-	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc/codes"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -164,6 +163,14 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
+	// Reconcile tag bindings
+	tagValues, err := shared.ResourceTagBinding(ctx, s.scope.TagBindingsClient(), s.scope.TagValues().TagValueName, s.scope.GCPManagedCluster.Spec, s.scope.ClusterName(), s.scope.GCPManagedControlPlane.Status.ResourceManagerTagValues)
+	s.scope.GCPManagedControlPlane.Status.ResourceManagerTagValues = tagValues
+	if err != nil {
+		log.Error(err, "Failed to reconcile tag bindings of cluster")
+		return ctrl.Result{}, err
+	}
+
 	s.scope.SetEndpoint(cluster.Endpoint)
 	conditions.MarkTrue(s.scope.ConditionSetter(), clusterv1.ReadyCondition)
 	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition)
@@ -221,17 +228,25 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// Orphan returns the GKE cluster, which is left behind instead of being deleted.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx).WithValues("service", "container.clusters")
+	cluster, err := s.describeCluster(ctx, &log)
+	if err != nil || cluster == nil {
+		return nil, err
+	}
+
+	return []string{cluster.SelfLink}, nil
+}
+
 func (s *Service) describeCluster(ctx context.Context, log *logr.Logger) (*containerpb.Cluster, error) {
 	getClusterRequest := &containerpb.GetClusterRequest{
 		Name: s.scope.ClusterFullName(),
 	}
 	cluster, err := s.scope.ManagedControlPlaneClient().GetCluster(ctx, getClusterRequest)
 	if err != nil {
-		var e *apierror.APIError
-		if ok := errors.As(err, &e); ok {
-			if e.GRPCStatus().Code() == codes.NotFound {
-				return nil, nil
-			}
+		if gcperrors.IsNotFound(err) {
+			return nil, nil
 		}
 		log.Error(err, "Error getting GKE cluster", "name", s.scope.ClusterName())
 		return nil, err
@@ -280,12 +295,15 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 		return err
 	}
 
-	err = shared.ResourceTagBinding(
+	tagValues, err := shared.ResourceTagBinding(
 		ctx,
 		s.scope.TagBindingsClient(),
+		s.scope.TagValues().TagValueName,
 		s.scope.GCPManagedCluster.Spec,
 		s.scope.ClusterName(),
+		s.scope.GCPManagedControlPlane.Status.ResourceManagerTagValues,
 	)
+	s.scope.GCPManagedControlPlane.Status.ResourceManagerTagValues = tagValues
 	if err != nil {
 		log.Error(err, "Error binding tags to cluster resources", "name", s.scope.ClusterName())
 		return err
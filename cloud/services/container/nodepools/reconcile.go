@@ -25,14 +25,13 @@ import (
 	"sigs.k8s.io/cluster-api-provider-gcp/util/resourceurl"
 
 	"google.golang.org/api/iterator"
-	"google.golang.org/grpc/codes"
 
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
-	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
@@ -222,17 +221,25 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// Orphan returns the node pool, which is left behind with its instances instead of being deleted.
+func (s *Service) Orphan(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx)
+	nodePool, err := s.describeNodePool(ctx, &log)
+	if err != nil || nodePool == nil {
+		return nil, err
+	}
+
+	return []string{nodePool.SelfLink}, nil
+}
+
 func (s *Service) describeNodePool(ctx context.Context, log *logr.Logger) (*containerpb.NodePool, error) {
 	getNodePoolRequest := &containerpb.GetNodePoolRequest{
 		Name: s.scope.NodePoolFullName(),
 	}
 	nodePool, err := s.scope.ManagedMachinePoolClient().GetNodePool(ctx, getNodePoolRequest)
 	if err != nil {
-		var e *apierror.APIError
-		if ok := errors.As(err, &e); ok {
-			if e.GRPCStatus().Code() == codes.NotFound {
-				return nil, nil
-			}
+		if gcperrors.IsNotFound(err) {
+			return nil, nil
 		}
 		log.Error(err, "Error getting GKE node pool", "name", s.scope.GCPManagedMachinePool.Name)
 		return nil, err
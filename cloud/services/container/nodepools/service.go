@@ -28,6 +28,8 @@ type Service struct {
 
 var _ cloud.ReconcilerWithResult = &Service{}
 
+var _ cloud.Orphaner = &Service{}
+
 // New returns Service from given scope.
 func New(scope *scope.ManagedMachinePoolScope) *Service {
 	return &Service{
@@ -0,0 +1,229 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	serviceEnabled = "ENABLED"
+
+	// cpusMetric is the regional quota of the vCPUs of the machine series without a quota of their own.
+	cpusMetric           = "CPUS"
+	instancesMetric      = "INSTANCES"
+	inUseAddressesMetric = "IN_USE_ADDRESSES"
+)
+
+// ErrChecksFailed is returned when the project of a cluster fails the preflight checks. The failures are reported
+// in the PreflightChecksPassed condition.
+var ErrChecksFailed = errors.New("preflight checks failed")
+
+// Reconcile checks that the project has the APIs, permissions and quotas needed by the cluster, and reports the
+// result in the PreflightChecksPassed condition. The quotas are only checked once the APIs and permissions pass.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Running preflight checks")
+	spec, err := s.scope.PreflightSpec(ctx)
+	if err != nil {
+		return err
+	}
+
+	checker, err := s.scope.ProjectChecker(ctx)
+	if err != nil {
+		return err
+	}
+
+	failures, err := s.checkServices(ctx, checker, spec.Services)
+	if err != nil {
+		return err
+	}
+
+	missing, err := s.checkPermissions(ctx, checker, spec.Permissions)
+	if err != nil {
+		return err
+	}
+	failures = append(failures, missing...)
+
+	if len(failures) == 0 {
+		failures, err = s.checkQuotas(ctx, checker, spec.Machines)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(failures) > 0 {
+		message := strings.Join(failures, "; ")
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1.PreflightChecksPassedCondition, infrav1.PreflightChecksFailedReason, clusterv1.ConditionSeverityError, "%s", message)
+		return fmt.Errorf("%w: %s", ErrChecksFailed, message)
+	}
+
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1.PreflightChecksPassedCondition)
+	return nil
+}
+
+// checkServices returns the failures of the services which are not enabled in the project.
+func (s *Service) checkServices(ctx context.Context, checker shared.ProjectChecker, services []string) ([]string, error) {
+	log := log.FromContext(ctx)
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	states, err := checker.ServiceStates(ctx, services)
+	if err != nil {
+		if gcperrors.IsPermissionDenied(err) {
+			// The credentials may lack the permission to get the state of the APIs, the calls to a disabled API
+			// then fail when the resources are created.
+			log.V(2).Info("Skipping the check of the APIs", "reason", err.Error())
+			return nil, nil
+		}
+
+		log.Error(err, "Error getting the state of the APIs", "services", services)
+		return nil, err
+	}
+
+	failures := []string{}
+	for _, service := range services {
+		if states[service] != serviceEnabled {
+			failures = append(failures, fmt.Sprintf("API %s is not enabled in project %s, enable it with `gcloud services enable %s --project %s`",
+				service, s.scope.Project(), service, s.scope.Project()))
+		}
+	}
+
+	return failures, nil
+}
+
+// checkPermissions returns the failure listing the permissions which are not granted to the credentials.
+func (s *Service) checkPermissions(ctx context.Context, checker shared.ProjectChecker, permissions []string) ([]string, error) {
+	log := log.FromContext(ctx)
+	if len(permissions) == 0 {
+		return nil, nil
+	}
+
+	granted, err := checker.TestPermissions(ctx, permissions)
+	if err != nil {
+		log.Error(err, "Error testing the permissions of the credentials")
+		return nil, err
+	}
+
+	missing := sets.New(permissions...).Delete(granted...)
+	if missing.Len() == 0 {
+		return nil, nil
+	}
+
+	return []string{fmt.Sprintf("the credentials lack the permissions %s in project %s, grant them a role including these permissions",
+		strings.Join(sets.List(missing), ", "), s.scope.Project())}, nil
+}
+
+// checkQuotas returns the failures of the regional quotas which are too low for the planned machines: their vCPUs,
+// instances and external IP addresses.
+func (s *Service) checkQuotas(ctx context.Context, checker shared.ProjectChecker, machines []shared.PlannedMachines) ([]string, error) {
+	log := log.FromContext(ctx)
+	if len(machines) == 0 {
+		return nil, nil
+	}
+
+	region, err := checker.Region(ctx, s.scope.Region())
+	if err != nil {
+		log.Error(err, "Error looking for region", "name", s.scope.Region())
+		return nil, err
+	}
+
+	quotas := make(map[string]*compute.Quota, len(region.Quotas))
+	for _, quota := range region.Quotas {
+		quotas[quota.Metric] = quota
+	}
+
+	failures := []string{}
+	needed := map[string]int64{}
+	for _, m := range machines {
+		if m.Count == 0 {
+			continue
+		}
+
+		machineType, err := s.getMachineType(ctx, checker, region, m.MachineType)
+		if err != nil {
+			return nil, err
+		}
+		if machineType == nil {
+			failures = append(failures, fmt.Sprintf("machine type %s is not available in region %s", m.MachineType, region.Name))
+			continue
+		}
+
+		needed[cpuQuotaMetric(quotas, m.MachineType)] += m.Count * machineType.GuestCpus
+		needed[instancesMetric] += m.Count
+		needed[inUseAddressesMetric] += m.PublicIPs
+	}
+
+	for _, metric := range sets.List(sets.KeySet(needed)) {
+		quota, ok := quotas[metric]
+		if !ok || needed[metric] == 0 {
+			continue
+		}
+
+		if available := int64(quota.Limit - quota.Usage); needed[metric] > available {
+			failures = append(failures, fmt.Sprintf("quota %s of region %s is too low: %d needed, %d available of a limit of %d, request a quota increase",
+				metric, region.Name, needed[metric], available, int64(quota.Limit)))
+		}
+	}
+
+	return failures, nil
+}
+
+// getMachineType returns the machine type from the first zone of the region offering it, nil if none does.
+func (s *Service) getMachineType(ctx context.Context, checker shared.ProjectChecker, region *compute.Region, name string) (*compute.MachineType, error) {
+	log := log.FromContext(ctx)
+	for _, zone := range region.Zones {
+		machineType, err := checker.MachineType(ctx, path.Base(zone), name)
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				continue
+			}
+
+			log.Error(err, "Error looking for machine type", "name", name, "zone", path.Base(zone))
+			return nil, err
+		}
+
+		return machineType, nil
+	}
+
+	return nil, nil
+}
+
+// cpuQuotaMetric returns the regional quota of the vCPUs of the machine type: the quota of its machine series,
+// e.g. N2_CPUS, or CPUS for the series without a quota of their own.
+func cpuQuotaMetric(quotas map[string]*compute.Quota, machineType string) string {
+	metric := strings.ToUpper(strings.SplitN(machineType, "-", 2)[0]) + "_CPUS"
+	if _, ok := quotas[metric]; ok {
+		return metric
+	}
+
+	return cpusMetric
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type fakeScope struct {
+	cluster *infrav1.GCPCluster
+	spec    *shared.PreflightSpec
+	checker shared.ProjectChecker
+}
+
+func (f *fakeScope) Project() string                    { return "my-project" }
+func (f *fakeScope) Region() string                     { return "us-central1" }
+func (f *fakeScope) ConditionSetter() conditions.Setter { return f.cluster }
+
+func (f *fakeScope) PreflightSpec(_ context.Context) (*shared.PreflightSpec, error) {
+	return f.spec, nil
+}
+
+func (f *fakeScope) ProjectChecker(_ context.Context) (shared.ProjectChecker, error) {
+	return f.checker, nil
+}
+
+type fakeChecker struct {
+	region       *compute.Region
+	machineTypes map[string]*compute.MachineType
+	states       map[string]string
+	statesErr    error
+	granted      []string
+}
+
+func (f *fakeChecker) Region(_ context.Context, _ string) (*compute.Region, error) {
+	return f.region, nil
+}
+
+func (f *fakeChecker) MachineType(_ context.Context, zone, machineType string) (*compute.MachineType, error) {
+	if mt, ok := f.machineTypes[zone+"/"+machineType]; ok {
+		return mt, nil
+	}
+
+	return nil, &googleapi.Error{Code: http.StatusNotFound}
+}
+
+func (f *fakeChecker) ServiceStates(_ context.Context, _ []string) (map[string]string, error) {
+	return f.states, f.statesErr
+}
+
+func (f *fakeChecker) TestPermissions(_ context.Context, permissions []string) ([]string, error) {
+	return sets.List(sets.New(permissions...).Intersection(sets.New(f.granted...))), nil
+}
+
+func TestService_Reconcile(t *testing.T) {
+	newChecker := func() *fakeChecker {
+		return &fakeChecker{
+			region: &compute.Region{
+				Name: "us-central1",
+				Zones: []string{
+					"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a",
+					"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-b",
+				},
+				Quotas: []*compute.Quota{
+					{Metric: "CPUS", Limit: 24, Usage: 4},
+					{Metric: "N2_CPUS", Limit: 24, Usage: 20},
+					{Metric: "INSTANCES", Limit: 100, Usage: 10},
+					{Metric: "IN_USE_ADDRESSES", Limit: 8, Usage: 6},
+				},
+			},
+			machineTypes: map[string]*compute.MachineType{
+				"us-central1-a/e2-standard-4": {Name: "e2-standard-4", GuestCpus: 4},
+				"us-central1-b/n2-standard-4": {Name: "n2-standard-4", GuestCpus: 4},
+			},
+			states:  map[string]string{"compute.googleapis.com": "ENABLED"},
+			granted: []string{"compute.instances.create", "compute.networks.create"},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		machines  []shared.PlannedMachines
+		checker   func(c *fakeChecker)
+		wantError bool
+		wantMsg   string
+	}{
+		{
+			name: "project with enough quota passes",
+			machines: []shared.PlannedMachines{
+				{MachineType: "e2-standard-4", Count: 3, PublicIPs: 2},
+				{MachineType: "n2-standard-4", Count: 1},
+			},
+		},
+		{
+			name:      "disabled API fails without checking the quotas",
+			machines:  []shared.PlannedMachines{{MachineType: "n2-standard-4", Count: 10}},
+			checker:   func(c *fakeChecker) { c.states["compute.googleapis.com"] = "DISABLED" },
+			wantError: true,
+			wantMsg:   "API compute.googleapis.com is not enabled in project my-project, enable it with `gcloud services enable compute.googleapis.com --project my-project`",
+		},
+		{
+			name:     "APIs are not checked without permission",
+			machines: []shared.PlannedMachines{{MachineType: "e2-standard-4", Count: 1}},
+			checker: func(c *fakeChecker) {
+				c.states = nil
+				c.statesErr = &googleapi.Error{Code: http.StatusForbidden}
+			},
+		},
+		{
+			name:      "missing permissions fail",
+			checker:   func(c *fakeChecker) { c.granted = []string{"compute.instances.create"} },
+			wantError: true,
+			wantMsg:   "the credentials lack the permissions compute.networks.create in project my-project, grant them a role including these permissions",
+		},
+		{
+			name: "exceeded quotas fail",
+			machines: []shared.PlannedMachines{
+				{MachineType: "e2-standard-4", Count: 6, PublicIPs: 3},
+				{MachineType: "n2-standard-4", Count: 2},
+			},
+			wantError: true,
+			wantMsg: "quota CPUS of region us-central1 is too low: 24 needed, 20 available of a limit of 24, request a quota increase; " +
+				"quota IN_USE_ADDRESSES of region us-central1 is too low: 3 needed, 2 available of a limit of 8, request a quota increase; " +
+				"quota N2_CPUS of region us-central1 is too low: 8 needed, 4 available of a limit of 24, request a quota increase",
+		},
+		{
+			name:      "unknown machine type fails",
+			machines:  []shared.PlannedMachines{{MachineType: "x9-standard-4", Count: 1}},
+			wantError: true,
+			wantMsg:   "machine type x9-standard-4 is not available in region us-central1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newChecker()
+			if tt.checker != nil {
+				tt.checker(checker)
+			}
+			scope := &fakeScope{
+				cluster: &infrav1.GCPCluster{},
+				spec: &shared.PreflightSpec{
+					Services:    []string{"compute.googleapis.com"},
+					Permissions: []string{"compute.instances.create", "compute.networks.create"},
+					Machines:    tt.machines,
+				},
+				checker: checker,
+			}
+
+			err := New(scope).Reconcile(context.TODO())
+			if (err != nil) != tt.wantError {
+				t.Fatalf("Service.Reconcile() error = %v, wantError %v", err, tt.wantError)
+			}
+			if err != nil && !errors.Is(err, ErrChecksFailed) {
+				t.Errorf("Service.Reconcile() error = %v, want ErrChecksFailed", err)
+			}
+
+			condition := conditions.Get(scope.cluster, infrav1.PreflightChecksPassedCondition)
+			if condition == nil {
+				t.Fatalf("expected the %s condition to be set", infrav1.PreflightChecksPassedCondition)
+			}
+			if tt.wantError {
+				if condition.Reason != infrav1.PreflightChecksFailedReason || condition.Message != tt.wantMsg {
+					t.Errorf("condition = %s: %q, want %s: %q", condition.Reason, condition.Message, infrav1.PreflightChecksFailedReason, tt.wantMsg)
+				}
+			} else if !conditions.IsTrue(scope.cluster, infrav1.PreflightChecksPassedCondition) {
+				t.Errorf("expected the %s condition to be true, got %q", infrav1.PreflightChecksPassedCondition, condition.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	Project() string
+	Region() string
+	ConditionSetter() conditions.Setter
+	PreflightSpec(ctx context.Context) (*shared.PreflightSpec, error)
+	ProjectChecker(ctx context.Context) (shared.ProjectChecker, error)
+}
+
+// Service implements the preflight checks of a cluster.
+type Service struct {
+	scope Scope
+}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope: scope,
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+const (
+	// BootstrapResultGuestAttribute is the query path of the guest attribute reporting the result of the bootstrap
+	// of an instance.
+	BootstrapResultGuestAttribute = "capg/bootstrap"
+	// BootstrapResultSerialMarker prefixes the result of the bootstrap of an instance on its serial console.
+	BootstrapResultSerialMarker = "capg-bootstrap: "
+	// BootstrapResultSucceeded is reported when the bootstrap sentinel file of Cluster API exists.
+	BootstrapResultSucceeded = "succeeded"
+	// BootstrapResultFailed is reported when the bootstrap ended without the sentinel file of Cluster API.
+	BootstrapResultFailed = "failed"
+)
+
+// BootstrapCheckScript is the startup script of the instances with a bootstrap check. It waits for the end of
+// cloud-init, or for the sentinel file written by the bootstrap of Cluster API without cloud-init, then reports
+// the result in the guest attribute and on the serial console once per instance.
+const BootstrapCheckScript = `#!/bin/bash
+reported=/var/lib/capg/bootstrap-reported
+sentinel=/run/cluster-api/bootstrap-success.complete
+if [ -f "${reported}" ]; then
+  exit 0
+fi
+if command -v cloud-init >/dev/null 2>&1; then
+  cloud-init status --wait >/dev/null 2>&1
+else
+  until [ -f "${sentinel}" ]; do sleep 10; done
+fi
+result=` + BootstrapResultFailed + `
+if [ -f "${sentinel}" ]; then
+  result=` + BootstrapResultSucceeded + `
+fi
+curl -s -X PUT --data "${result}" -H "Metadata-Flavor: Google" \
+  "http://metadata.google.internal/computeMetadata/v1/instance/guest-attributes/` + BootstrapResultGuestAttribute + `"
+echo "` + BootstrapResultSerialMarker + `${result}" > /dev/ttyS0
+mkdir -p "$(dirname "${reported}")" && touch "${reported}"
+`
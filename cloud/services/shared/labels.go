@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+// LabelsToSet returns the labels to set on a resource owned by CAPG to reconcile its existing labels with the
+// desired ones, and whether they differ. applied are the keys of the labels previously set by CAPG: the ones removed
+// from the spec are removed from the resource, the labels set by other means, e.g. by Google services, are kept.
+func LabelsToSet(existing, desired infrav1.Labels, applied []string) (infrav1.Labels, bool) {
+	removed := sets.New(applied...)
+	labels := make(infrav1.Labels, len(existing)+len(desired))
+	changed := false
+	for k, v := range existing {
+		if _, ok := desired[k]; !ok && removed.Has(k) {
+			changed = true
+			continue
+		}
+		labels[k] = v
+	}
+	for k, v := range desired {
+		if value, ok := existing[k]; !ok || value != v {
+			changed = true
+		}
+		labels[k] = v
+	}
+
+	return labels, changed
+}
+
+// LabelKeys returns the sorted keys of the labels, to be recorded as the ones applied by CAPG.
+func LabelKeys(labels infrav1.Labels) []string {
+	return sets.List(sets.KeySet(labels))
+}
+
+// OrphanLabels returns the labels to set on a resource owned by CAPG to leave it behind, and whether they differ:
+// the CAPG labels of desired, marking the ownership and the role of the resource, are removed from the existing ones.
+func OrphanLabels(existing, desired infrav1.Labels) (infrav1.Labels, bool) {
+	labels := make(infrav1.Labels, len(existing))
+	changed := false
+	for k, v := range existing {
+		if _, ok := desired[k]; ok && strings.HasPrefix(k, infrav1.NameGCPProviderPrefix) {
+			changed = true
+			continue
+		}
+		labels[k] = v
+	}
+
+	return labels, changed
+}
+
+// SharedLabelsToSet returns the labels to set on an existing resource used by CAPG to add the desired labels,
+// and whether they differ. The other labels of the resource, which CAPG does not manage, are kept.
+func SharedLabelsToSet(existing, desired infrav1.Labels) (infrav1.Labels, bool) {
+	return LabelsToSet(existing, desired, nil)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	iampb "cloud.google.com/go/iam/apiv1/iampb"
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/serviceusage/v1"
+)
+
+// PreflightSpec is what a cluster needs from its project before its GCP resources are created.
+type PreflightSpec struct {
+	// Services are the APIs which must be enabled, e.g. compute.googleapis.com.
+	Services []string
+	// Permissions are the IAM permissions the credentials of the cluster must be granted on the project.
+	Permissions []string
+	// Machines are the machines planned in the region of the cluster.
+	Machines []PlannedMachines
+}
+
+// PlannedMachines is a group of planned machines of the same machine type.
+type PlannedMachines struct {
+	// MachineType is the machine type of the machines, e.g. n2-standard-4.
+	MachineType string
+	// Count is the number of machines.
+	Count int64
+	// PublicIPs is the number of machines with an external IP address.
+	PublicIPs int64
+}
+
+// ProjectChecker looks up the state of a project which cannot be changed by CAPG: its regional quotas, machine
+// types, enabled services and the permissions granted to the credentials.
+type ProjectChecker interface {
+	Region(ctx context.Context, region string) (*compute.Region, error)
+	MachineType(ctx context.Context, zone, machineType string) (*compute.MachineType, error)
+	// ServiceStates returns the states of the services, e.g. ENABLED, by service name.
+	ServiceStates(ctx context.Context, services []string) (map[string]string, error)
+	// TestPermissions returns the permissions granted to the credentials among the given ones.
+	TestPermissions(ctx context.Context, permissions []string) ([]string, error)
+}
+
+// NewProjectChecker returns the ProjectChecker of the project using the clients.
+func NewProjectChecker(project string, computeSvc *compute.Service, serviceUsage *serviceusage.Service, projects *resourcemanager.ProjectsClient) ProjectChecker {
+	return &projectChecker{
+		project:      project,
+		compute:      computeSvc,
+		serviceUsage: serviceUsage,
+		projects:     projects,
+	}
+}
+
+type projectChecker struct {
+	project      string
+	compute      *compute.Service
+	serviceUsage *serviceusage.Service
+	projects     *resourcemanager.ProjectsClient
+}
+
+func (c *projectChecker) Region(ctx context.Context, region string) (*compute.Region, error) {
+	return c.compute.Regions.Get(c.project, region).Context(ctx).Do()
+}
+
+func (c *projectChecker) MachineType(ctx context.Context, zone, machineType string) (*compute.MachineType, error) {
+	return c.compute.MachineTypes.Get(c.project, zone, machineType).Context(ctx).Do()
+}
+
+func (c *projectChecker) ServiceStates(ctx context.Context, services []string) (map[string]string, error) {
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, fmt.Sprintf("projects/%s/services/%s", c.project, service))
+	}
+
+	resp, err := c.serviceUsage.Services.BatchGet("projects/" + c.project).Names(names...).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]string, len(resp.Services))
+	for _, service := range resp.Services {
+		states[path.Base(service.Name)] = service.State
+	}
+
+	return states, nil
+}
+
+func (c *projectChecker) TestPermissions(ctx context.Context, permissions []string) ([]string, error) {
+	resp, err := c.projects.TestIamPermissions(ctx, &iampb.TestIamPermissionsRequest{
+		Resource:    "projects/" + c.project,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Permissions, nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ResourceRefName returns the name of an existing resource referenced by name or by self link.
+// A self link must be in the project of the cluster.
+func ResourceRefName(ref, project string) (string, error) {
+	if !strings.Contains(ref, "/") {
+		return ref, nil
+	}
+
+	if !strings.Contains(ref, "projects/"+project+"/") {
+		return "", errors.Errorf("%s is not in the project %s", ref, project)
+	}
+
+	return path.Base(ref), nil
+}
@@ -18,55 +18,190 @@ package shared
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
 	rmpb "cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+	"google.golang.org/api/iterator"
+	"k8s.io/apimachinery/pkg/util/sets"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-// ResourceTagBinding creates a TagBinding between a TagValue and a Google Cloud resource.
-// If any of the SDK calls fail, the error is logged and no action is taken.
-func ResourceTagBinding(ctx context.Context, client *resourcemanager.TagBindingsClient, spec infrav1exp.GCPManagedClusterSpec, name string) error {
-	for _, tag := range spec.ResourceManagerTags {
-		tagValue, err := getTagValues(ctx, tag)
-		if err != nil {
-			return fmt.Errorf("failed to retrieve tag value: %w", err)
-		}
-		req := &rmpb.CreateTagBindingRequest{
-			TagBinding: &rmpb.TagBinding{
-				Parent:   fmt.Sprintf("//container.googleapis.com/projects/%s/locations/%s/clusters/%s", tag.ParentID, spec.Region, name),
-				TagValue: tagValue.Name,
-			},
+// NewTagBindings returns the TagBindings managed with the client.
+func NewTagBindings(client *resourcemanager.TagBindingsClient) cloud.TagBindings {
+	return &tagBindings{client: client}
+}
+
+type tagBindings struct {
+	client *resourcemanager.TagBindingsClient
+}
+
+func (b *tagBindings) List(ctx context.Context, parent string) ([]*rmpb.TagBinding, error) {
+	var bindings []*rmpb.TagBinding
+	it := b.client.ListTagBindings(ctx, &rmpb.ListTagBindingsRequest{Parent: parent})
+	for {
+		binding, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return bindings, nil
 		}
-		op, err := client.CreateTagBinding(ctx, req)
 		if err != nil {
-			return fmt.Errorf("failed to create tag binding: %w", err)
+			return nil, err
 		}
+		bindings = append(bindings, binding)
+	}
+}
+
+func (b *tagBindings) Create(ctx context.Context, parent, tagValue string) error {
+	op, err := b.client.CreateTagBinding(ctx, &rmpb.CreateTagBindingRequest{
+		TagBinding: &rmpb.TagBinding{
+			Parent:   parent,
+			TagValue: tagValue,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tag binding: %w", err)
+	}
+
+	if _, err := op.Wait(ctx); err != nil {
+		return fmt.Errorf("tag binding operation failed: %w", err)
+	}
+
+	return nil
+}
+
+func (b *tagBindings) Delete(ctx context.Context, name string) error {
+	op, err := b.client.DeleteTagBinding(ctx, &rmpb.DeleteTagBindingRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag binding: %w", err)
+	}
+
+	if err := op.Wait(ctx); err != nil {
+		return fmt.Errorf("tag binding deletion operation failed: %w", err)
+	}
 
-		_, err = op.Wait(ctx)
+	return nil
+}
+
+// TagValueNames returns the resource names of the tag values identified by the passed resource-manager tags.
+func TagValueNames(ctx context.Context, tags infrav1.ResourceManagerTags, tagValueName func(context.Context, infrav1.ResourceManagerTag) (string, error)) (sets.Set[string], error) {
+	tagValues := sets.New[string]()
+	for _, tag := range tags {
+		tagValue, err := tagValueName(ctx, tag)
 		if err != nil {
-			return fmt.Errorf("tag binding operation failed: %w", err)
+			return nil, fmt.Errorf("failed to retrieve tag value: %w", err)
+		}
+		tagValues.Insert(tagValue)
+	}
+
+	return tagValues, nil
+}
+
+// ReconcileTagBindings binds the tag values to the resource identified by its full resource name, and deletes the
+// direct bindings of the resource to the tag values previously applied by CAPG which are not part of tagValues
+// anymore. The bindings of the resource to other tag values are left untouched.
+func ReconcileTagBindings(ctx context.Context, bindings cloud.TagBindings, parent string, tagValues, applied sets.Set[string]) error {
+	log := log.FromContext(ctx)
+	existing, err := bindings.List(ctx, parent)
+	if err != nil {
+		return fmt.Errorf("failed to list tag bindings of %s: %w", parent, err)
+	}
+
+	bound := sets.New[string]()
+	for _, binding := range existing {
+		if tagValues.Has(binding.TagValue) {
+			bound.Insert(binding.TagValue)
+			continue
+		}
+		if !applied.Has(binding.TagValue) {
+			continue
+		}
+
+		log.V(2).Info("Deleting tag binding", "resource", parent, "tagValue", binding.TagValue)
+		if err := bindings.Delete(ctx, binding.Name); err != nil && !gcperrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for _, tagValue := range sets.List(tagValues.Difference(bound)) {
+		log.V(2).Info("Creating tag binding", "resource", parent, "tagValue", tagValue)
+		if err := bindings.Create(ctx, parent, tagValue); err != nil && !gcperrors.IsAlreadyExists(err) {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// ResourceTagConvert converts the passed resource-manager tags to a GCP API valid format.
+// ComputeResourceName returns the full resource name of a compute resource from its self link and ID, e.g.
+// https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/my-network is
+// //compute.googleapis.com/projects/my-proj/global/networks/1234567890.
+func ComputeResourceName(selfLink string, id uint64) string {
+	path := selfLink
+	if i := strings.Index(path, "projects/"); i >= 0 {
+		path = path[i:]
+	}
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		path = path[:i+1] + strconv.FormatUint(id, 10)
+	}
+
+	return "//compute.googleapis.com/" + path
+}
+
+// ResourceTagBinding reconciles the TagBindings between the TagValues of the spec and a GKE cluster. applied are
+// the tag values previously bound by CAPG, the returned tag values are the ones bound by CAPG after the reconcile.
+func ResourceTagBinding(ctx context.Context, client *resourcemanager.TagBindingsClient, tagValueName func(context.Context, infrav1.ResourceManagerTag) (string, error), spec infrav1exp.GCPManagedClusterSpec, name string, applied []string) ([]string, error) {
+	if len(spec.ResourceManagerTags) == 0 && len(applied) == 0 {
+		return nil, nil
+	}
+
+	tagValues, err := TagValueNames(ctx, spec.ResourceManagerTags, tagValueName)
+	if err != nil {
+		return applied, err
+	}
+
+	parent := fmt.Sprintf("//container.googleapis.com/projects/%s/locations/%s/clusters/%s", spec.Project, spec.Region, name)
+	if err := ReconcileTagBindings(ctx, NewTagBindings(client), parent, tagValues, sets.New(applied...)); err != nil {
+		return applied, err
+	}
+
+	return sets.List(tagValues), nil
+}
+
+// TagValues looks up the tag values of resource-manager tags with the credentials of a cluster.
+type TagValues struct {
+	// source identifies the credentials, the tag values visible to them are cached separately.
+	source string
+	client func(ctx context.Context) (*resourcemanager.TagValuesClient, error)
+}
+
+// NewTagValues returns the TagValues looked up with the client returned by the getter, which is called on first
+// use only so that the clusters without resource-manager tags do not need one. source identifies the credentials
+// of the client.
+func NewTagValues(source string, client func(ctx context.Context) (*resourcemanager.TagValuesClient, error)) *TagValues {
+	return &TagValues{source: source, client: client}
+}
+
+// ResourceManagerTagsMap converts the passed resource-manager tags to a GCP API valid format.
 // Tag keys and Tag Values will be created by the user and only the Tag bindings to the Compute Instance will be
 // handled by CAPG. If the Tag Key/Tag Value cannot be retrieved or no tags are provided, this will be empty and no tags will be added.
-func ResourceTagConvert(ctx context.Context, t infrav1.ResourceManagerTags) infrav1.ResourceManagerTagsMap {
-	tagValueList := make(infrav1.ResourceManagerTagsMap, len(t))
+func (t *TagValues) ResourceManagerTagsMap(ctx context.Context, tags infrav1.ResourceManagerTags) infrav1.ResourceManagerTagsMap {
+	tagValueList := make(infrav1.ResourceManagerTagsMap, len(tags))
 	log := log.FromContext(ctx)
-	if len(t) == 0 {
+	if len(tags) == 0 {
 		return tagValueList
 	}
 
-	for _, tag := range t {
-		tagValue, err := getTagValues(ctx, tag)
+	for _, tag := range tags {
+		tagValue, err := t.get(ctx, tag)
 		if err != nil {
 			log.Error(err, "failed to retrieve tag value")
 			continue
@@ -77,22 +212,75 @@ func ResourceTagConvert(ctx context.Context, t infrav1.ResourceManagerTags) infr
 	return tagValueList
 }
 
-func getTagValues(ctx context.Context, tag infrav1.ResourceManagerTag) (*rmpb.TagValue, error) {
-	log := log.FromContext(ctx)
-	client, err := resourcemanager.NewTagValuesClient(ctx)
+// TagValueName returns the resource name of the tag value identified by the passed resource-manager tag,
+// e.g. tagValues/123.
+func (t *TagValues) TagValueName(ctx context.Context, tag infrav1.ResourceManagerTag) (string, error) {
+	tagValue, err := t.get(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+
+	return tagValue.Name, nil
+}
+
+func (t *TagValues) get(ctx context.Context, tag infrav1.ResourceManagerTag) (*rmpb.TagValue, error) {
+	name := fmt.Sprintf("%s/%s/%s", tag.ParentID, tag.Key, tag.Value)
+	if tagValue, ok := tagValues.get(t.source, name); ok {
+		return tagValue, nil
+	}
+
+	client, err := t.client(ctx)
 	if err != nil {
-		log.Error(err, "failed to create tag values client")
+		log.FromContext(ctx).Error(err, "failed to create tag values client")
 		return &rmpb.TagValue{}, err
 	}
-	defer client.Close()
 
 	req := &rmpb.GetNamespacedTagValueRequest{
-		Name: fmt.Sprintf("%s/%s/%s", tag.ParentID, tag.Key, tag.Value),
+		Name: name,
 	}
 	tagValue, err := client.GetNamespacedTagValue(ctx, req)
 	if err != nil {
 		return &rmpb.TagValue{}, err
 	}
+	tagValues.set(t.source, name, tagValue)
 
 	return tagValue, nil
 }
+
+// tagValueCacheTTL is how long the looked up tag values are cached.
+var tagValueCacheTTL = 10 * time.Minute
+
+// tagValues caches the tag values by credentials source and namespaced name, since every reconcile of the
+// clusters looks up the tag values of their resource-manager tags.
+var tagValues = &tagValueCache{entries: map[string]tagValueCacheEntry{}}
+
+type tagValueCache struct {
+	mu      sync.Mutex
+	entries map[string]tagValueCacheEntry
+}
+
+type tagValueCacheEntry struct {
+	tagValue *rmpb.TagValue
+	expires  time.Time
+}
+
+func (c *tagValueCache) get(source, name string) (*rmpb.TagValue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := source + "|" + name
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.tagValue, true
+}
+
+func (c *tagValueCache) set(source, name string, tagValue *rmpb.TagValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[source+"|"+name] = tagValueCacheEntry{tagValue: tagValue, expires: time.Now().Add(tagValueCacheTTL)}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"path"
+	"sync"
+
+	gcecloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// CallObserver implements cloud.CallObserver to trace the GCP API calls of the cloud wrapper.
+// The span of a call includes the time spent waiting for the rate limiter.
+type CallObserver struct {
+	spans sync.Map
+}
+
+var _ gcecloud.CallObserver = &CallObserver{}
+
+// Start starts the span of the call.
+func (o *CallObserver) Start(ctx context.Context, key *gcecloud.RateLimitKey) {
+	_, span := tracer().Start(ctx, key.Service+"."+key.Operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("gcp.project", key.ProjectID),
+		attribute.String("gcp.api.version", string(key.Version)),
+	))
+	o.spans.Store(key, span)
+}
+
+// End ends the span of the call.
+func (o *CallObserver) End(_ context.Context, key *gcecloud.RateLimitKey, err error) {
+	if span, ok := o.spans.LoadAndDelete(key); ok {
+		End(span.(trace.Span), err)
+	}
+}
+
+// UnaryClientInterceptor traces the calls of the gRPC clients of the GCP APIs.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (err error) {
+	// Methods are formatted as /google.container.v1.ClusterManager/GetCluster.
+	service, operation := path.Split(method)
+	ctx, span := tracer().Start(ctx, path.Base(service)+"."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { End(span, err) }()
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Reconciler wraps a cloud.Reconciler to trace its calls in spans named after the service.
+func Reconciler(name string, r cloud.Reconciler) cloud.Reconciler {
+	return &reconciler{name: name, r: r}
+}
+
+type reconciler struct {
+	name string
+	r    cloud.Reconciler
+}
+
+func (r *reconciler) Reconcile(ctx context.Context) (err error) {
+	ctx, span := tracer().Start(ctx, r.name+".Reconcile")
+	defer func() { End(span, err) }()
+
+	return r.r.Reconcile(ctx)
+}
+
+func (r *reconciler) Delete(ctx context.Context) (err error) {
+	ctx, span := tracer().Start(ctx, r.name+".Delete")
+	defer func() { End(span, err) }()
+
+	return r.r.Delete(ctx)
+}
+
+// ReconcilerWithResult wraps a cloud.ReconcilerWithResult to trace its calls in spans named after the service.
+func ReconcilerWithResult(name string, r cloud.ReconcilerWithResult) cloud.ReconcilerWithResult {
+	return &reconcilerWithResult{name: name, r: r}
+}
+
+type reconcilerWithResult struct {
+	name string
+	r    cloud.ReconcilerWithResult
+}
+
+func (r *reconcilerWithResult) Reconcile(ctx context.Context) (_ ctrl.Result, err error) {
+	ctx, span := tracer().Start(ctx, r.name+".Reconcile")
+	defer func() { End(span, err) }()
+
+	return r.r.Reconcile(ctx)
+}
+
+func (r *reconcilerWithResult) Delete(ctx context.Context) (_ ctrl.Result, err error) {
+	ctx, span := tracer().Start(ctx, r.name+".Delete")
+	defer func() { End(span, err) }()
+
+	return r.r.Delete(ctx)
+}
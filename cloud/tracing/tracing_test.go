@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gcecloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type fakeReconciler struct {
+	observer *CallObserver
+	err      error
+}
+
+func (r *fakeReconciler) Reconcile(ctx context.Context) error {
+	key := &gcecloud.RateLimitKey{ProjectID: "my-proj", Service: "Networks", Operation: "Get", Version: "ga"}
+	r.observer.Start(ctx, key)
+	r.observer.End(ctx, key, r.err)
+	return r.err
+}
+
+func (r *fakeReconciler) Delete(context.Context) error {
+	return nil
+}
+
+func TestReconcileSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	r := &fakeReconciler{observer: &CallObserver{}, err: errors.New("quota exceeded")}
+	ctx, span := StartReconcile(context.TODO(), "GCPCluster", ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-cluster"}})
+	err := Reconciler("networks", r).Reconcile(ctx)
+	End(span, err)
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+
+	wantNames := []string{"Networks.Get", "networks.Reconcile", "GCPCluster.Reconcile"}
+	for i, s := range spans {
+		if s.Name() != wantNames[i] {
+			t.Errorf("span %d name = %s, want %s", i, s.Name(), wantNames[i])
+		}
+
+		if s.Status().Code != codes.Error {
+			t.Errorf("span %s status = %v, want error", s.Name(), s.Status().Code)
+		}
+
+		if i > 0 && spans[i-1].Parent().SpanID() != s.SpanContext().SpanID() {
+			t.Errorf("span %s is not the parent of span %s", s.Name(), spans[i-1].Name())
+		}
+	}
+
+	r.observer.spans.Range(func(key, _ interface{}) bool {
+		t.Errorf("span of call %+v was not forgotten", key)
+		return true
+	})
+}
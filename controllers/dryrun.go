@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/dryrun"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// planConfigMapKey is the key of the plan in the data of the plan ConfigMaps.
+const planConfigMapKey = "plan.yaml"
+
+// isDryRun reports whether one of the objects has the dry-run annotation.
+func isDryRun(objs ...metav1.Object) bool {
+	for _, obj := range objs {
+		if obj.GetAnnotations()[infrav1.DryRunAnnotation] == "true" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// planConfigMapName returns the name of the ConfigMap of the plan of the object of the kind.
+func planConfigMapName(kind string, obj metav1.Object) string {
+	return fmt.Sprintf("%s-%s-plan", obj.GetName(), strings.ToLower(kind))
+}
+
+// writePlan writes the plan of the reconcile of the object of the kind, and the error which stopped it if any,
+// to a ConfigMap owned by the object.
+func writePlan(ctx context.Context, c client.Client, kind string, obj client.Object, plan *dryrun.Plan, reconcileErr error) error {
+	data, err := plan.YAML(reconcileErr)
+	if err != nil {
+		return fmt.Errorf("formatting the plan of %s %s: %w", kind, obj.GetName(), err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: obj.GetNamespace(),
+			Name:      planConfigMapName(kind, obj),
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, configMap, func() error {
+		configMap.Data = map[string]string{planConfigMapKey: string(data)}
+		return controllerutil.SetOwnerReference(obj, configMap, c.Scheme())
+	}); err != nil {
+		return fmt.Errorf("writing the plan of %s %s: %w", kind, obj.GetName(), err)
+	}
+
+	return nil
+}
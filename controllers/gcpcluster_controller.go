@@ -18,46 +18,87 @@ package controllers
 
 import (
 	"context"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/operations"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/discovery"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/externalresources"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/firewalls"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/routes"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/tagbindings"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/preflight"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
+	"sigs.k8s.io/cluster-api-provider-gcp/feature"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/index"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// podCIDRRoutesSyncPeriod is the interval at which the Nodes of the workload cluster are watched again while
+// it is not reachable.
+const podCIDRRoutesSyncPeriod = time.Minute
+
+// operationPollInterval is the interval at which pending GCE operations are checked.
+const operationPollInterval = 5 * time.Second
+
 // GCPClusterReconciler reconciles a GCPCluster object.
 type GCPClusterReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// DryRun plans the changes of the GCP resources of all the GCPClusters instead of applying them,
+	// as if they had the dry-run annotation.
+	DryRun bool
+	// Tracker provides the cached clients of the workload clusters, whose Nodes are watched for the pod CIDR routes.
+	Tracker *remote.ClusterCacheTracker
+
+	controller controller.Controller
 }
 
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusterstaticidentities;gcpclusterimpersonationidentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinetemplates,verbs=get;list;watch
 
 func (r *GCPClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	log := log.FromContext(ctx).WithValues("controller", "GCPCluster")
@@ -98,13 +139,68 @@ func (r *GCPClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		return errors.Wrap(err, "failed adding a watch for ready clusters")
 	}
 
+	if err = c.Watch(
+		source.Kind(mgr.GetCache(), &corev1.Secret{}),
+		handler.EnqueueRequestsFromMapFunc(r.CredentialsSecretToGCPClusters(ctx)),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for credentials secrets")
+	}
+
+	r.controller = c
 	return nil
 }
 
+// CredentialsSecretToGCPClusters is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// of the GCPClusters using a credentials Secret.
+func (r *GCPClusterReconciler) CredentialsSecretToGCPClusters(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	return func(mapCtx context.Context, o client.Object) []ctrl.Request {
+		gcpClusters, err := credentialsSecretToGCPClusters(mapCtx, r.Client, o)
+		if err != nil {
+			log.Error(err, "failed to list GCPClusters using credentials secret", "secret", klog.KObj(o))
+			return nil
+		}
+
+		result := make([]ctrl.Request, 0, len(gcpClusters))
+		for i := range gcpClusters {
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gcpClusters[i])})
+		}
+
+		return result
+	}
+}
+
+// credentialsSecretToGCPClusters returns the GCPClusters using a credentials Secret, directly or through an identity.
+func credentialsSecretToGCPClusters(ctx context.Context, c client.Client, secret client.Object) ([]infrav1.GCPCluster, error) {
+	gcpClusters := &infrav1.GCPClusterList{}
+	if err := c.List(ctx, gcpClusters, client.MatchingFields{index.GCPClusterCredentialsSecretField: index.SecretKey(secret.GetNamespace(), secret.GetName())}); err != nil {
+		return nil, err
+	}
+
+	identityKeys, err := index.SecretIdentityKeys(ctx, c, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identityKey := range identityKeys {
+		identityClusters := &infrav1.GCPClusterList{}
+		if err := c.List(ctx, identityClusters, client.MatchingFields{index.GCPClusterIdentityField: identityKey}); err != nil {
+			return nil, err
+		}
+		gcpClusters.Items = append(gcpClusters.Items, identityClusters.Items...)
+	}
+
+	return gcpClusters.Items, nil
+}
+
 func (r *GCPClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.StartReconcile(ctx, "GCPCluster", req)
+	defer func() { tracing.End(span, reterr) }()
+	ctx = observer.WithContext(ctx)
+
 	log := log.FromContext(ctx)
 	gcpCluster := &infrav1.GCPCluster{}
 	err := r.Get(ctx, req.NamespacedName, gcpCluster)
@@ -138,6 +234,8 @@ func (r *GCPClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Client:     r.Client,
 		Cluster:    cluster,
 		GCPCluster: gcpCluster,
+		Tracker:    r.Tracker,
+		DryRun:     r.DryRun || isDryRun(gcpCluster),
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -145,14 +243,19 @@ func (r *GCPClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Always close the scope when exiting this function so we can persist any GCPMachine changes.
 	defer func() {
+		metrics.RecordReady("GCPCluster", gcpCluster, infrav1.ClusterFinalizer, gcpCluster.Status.Ready)
 		if err := clusterScope.Close(); err != nil && reterr == nil {
 			reterr = err
 		}
 	}()
 
+	if clusterScope.Plan() != nil {
+		return r.reconcilePlan(ctx, clusterScope)
+	}
+
 	// Handle deleted clusters
 	if !gcpCluster.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, r.reconcileDelete(ctx, clusterScope)
+		return r.reconcileDelete(ctx, clusterScope)
 	}
 
 	// Handle non-deleted clusters
@@ -163,11 +266,35 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	log := log.FromContext(ctx)
 	log.Info("Reconciling GCPCluster")
 
+	clusterScope.DefaultResourceNaming()
 	controllerutil.AddFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer)
 	if err := clusterScope.PatchObject(); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if err := clusterScope.ValidateCredentials(ctx); err != nil {
+		log.Error(err, "Invalid credentials")
+		conditions.MarkFalse(clusterScope.GCPCluster, infrav1.CredentialsValidCondition, infrav1.CredentialsInvalidReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return ctrl.Result{}, err
+	}
+	conditions.MarkTrue(clusterScope.GCPCluster, infrav1.CredentialsValidCondition)
+
+	// The project is checked until the cluster is ready, the usage of the quotas then includes its own machines.
+	if reconciler.IsPreflightChecksSkipped(clusterScope.GCPCluster) {
+		conditions.Delete(clusterScope.GCPCluster, infrav1.PreflightChecksPassedCondition)
+	} else if !clusterScope.GCPCluster.Status.Ready && !conditions.IsTrue(clusterScope.GCPCluster, infrav1.PreflightChecksPassedCondition) {
+		if err := preflight.New(clusterScope).Reconcile(ctx); err != nil {
+			if errors.Is(err, preflight.ErrChecksFailed) {
+				log.Info("GCPCluster failed preflight checks", "reason", err.Error())
+				record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Preflight checks failed - %v", err)
+				return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+			}
+
+			log.Error(err, "Error running preflight checks")
+			return reconciler.HandleGCPError(clusterScope.GCPCluster, infrav1.PreflightChecksPassedCondition, err)
+		}
+	}
+
 	region, err := clusterScope.Cloud().Regions().Get(ctx, meta.GlobalKey(clusterScope.Region()))
 	if err != nil {
 		return ctrl.Result{}, err
@@ -180,38 +307,74 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 
 	failureDomains := make(clusterv1.FailureDomains, len(zones))
 	for _, zone := range zones {
-		if len(clusterScope.GCPCluster.Spec.FailureDomains) > 0 {
-			for _, fd := range clusterScope.GCPCluster.Spec.FailureDomains {
-				if fd == zone.Name {
-					failureDomains[zone.Name] = clusterv1.FailureDomainSpec{
-						ControlPlane: true,
-					}
-				}
+		failureDomain := clusterv1.FailureDomainSpec{
+			ControlPlane: true,
+			Attributes:   map[string]string{},
+		}
+
+		if specs := clusterScope.FailureDomainSpecs(); len(specs) > 0 {
+			spec := findFailureDomainSpec(specs, zone.Name)
+			if spec == nil {
+				continue
+			}
+
+			failureDomain.ControlPlane = pointer.BoolDeref(spec.ControlPlane, true)
+			if spec.Weight != nil {
+				failureDomain.Attributes[infrav1.WeightAttribute] = strconv.Itoa(int(*spec.Weight))
 			}
-		} else {
-			failureDomains[zone.Name] = clusterv1.FailureDomainSpec{
-				ControlPlane: true,
+			for k, v := range spec.Attributes {
+				failureDomain.Attributes[k] = v
 			}
 		}
+
+		if _, ok := failureDomain.Attributes[infrav1.MachineSeriesAttribute]; !ok {
+			// The machine series are only listed for the zones that were not already reported.
+			if series, ok := clusterScope.FailureDomains()[zone.Name].Attributes[infrav1.MachineSeriesAttribute]; ok {
+				failureDomain.Attributes[infrav1.MachineSeriesAttribute] = series
+			} else {
+				series, err := clusterScope.ZoneMachineSeries(ctx, zone.Name)
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+
+				failureDomain.Attributes[infrav1.MachineSeriesAttribute] = strings.Join(series, ",")
+			}
+		}
+
+		failureDomains[zone.Name] = failureDomain
 	}
 
 	clusterScope.SetFailureDomains(failureDomains)
 
+	if result, err := r.reconcileOperations(ctx, clusterScope); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	reconcilers := []cloud.Reconciler{
-		networks.New(clusterScope),
-		firewalls.New(clusterScope),
-		loadbalancers.New(clusterScope),
-		subnets.New(clusterScope),
+		tracing.Reconciler("discovery", discovery.New(clusterScope)),
+		tracing.Reconciler("networks", networks.New(clusterScope)),
+		tracing.Reconciler("firewalls", firewalls.New(clusterScope)),
+		tracing.Reconciler("loadbalancers", loadbalancers.New(clusterScope)),
+		tracing.Reconciler("subnets", subnets.New(clusterScope)),
+		tracing.Reconciler("routes", routes.New(clusterScope)),
+		tracing.Reconciler("tagbindings", tagbindings.New(clusterScope)),
 	}
 
 	for _, r := range reconcilers {
 		if err := r.Reconcile(ctx); err != nil {
+			if operations.Track(clusterScope.PendingOperations(), err) {
+				log.Info("Waiting for GCE operation", "reason", err.Error())
+				return ctrl.Result{RequeueAfter: operationPollInterval}, nil
+			}
+
 			log.Error(err, "Reconcile error")
 			record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+			return reconciler.HandleGCPError(clusterScope.GCPCluster, infrav1.NetworkInfrastructureReadyCondition, err)
 		}
 	}
 
+	conditions.MarkTrue(clusterScope.GCPCluster, infrav1.NetworkInfrastructureReadyCondition)
+
 	controlPlaneEndpoint := clusterScope.ControlPlaneEndpoint()
 	if controlPlaneEndpoint.Host == "" {
 		log.Info("GCPCluster does not have control-plane endpoint yet. Reconciling")
@@ -222,29 +385,189 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	record.Eventf(clusterScope.GCPCluster, "GCPClusterReconcile", "Got control-plane endpoint - %s", controlPlaneEndpoint.Host)
 	clusterScope.SetReady()
 	record.Event(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconciled")
+	if clusterScope.PodCIDRRoutesEnabled() {
+		if err := r.watchWorkloadNodes(ctx, clusterScope); err != nil {
+			// The workload cluster is not reachable until its first control plane machine is up.
+			log.V(2).Info("Unable to watch workload cluster nodes, retrying", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: podCIDRRoutesSyncPeriod}, nil
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
+// watchWorkloadNodes watches the Nodes of the workload cluster, so that the pod CIDR routes are reconciled when their
+// provider ID or pod CIDRs change.
+func (r *GCPClusterReconciler) watchWorkloadNodes(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	if r.Tracker == nil || r.controller == nil {
+		return errors.New("no workload cluster tracker")
+	}
+
+	return r.Tracker.Watch(ctx, remote.WatchInput{
+		Name:         "gcpcluster-watchNodes",
+		Cluster:      client.ObjectKeyFromObject(clusterScope.Cluster),
+		Watcher:      r.controller,
+		Kind:         &corev1.Node{},
+		EventHandler: handler.EnqueueRequestsFromMapFunc(nodeToGCPCluster(client.ObjectKeyFromObject(clusterScope.GCPCluster))),
+		Predicates:   []predicate.Predicate{nodeRoutingChanged()},
+	})
+}
+
+// nodeToGCPCluster maps the Nodes of a workload cluster to its GCPCluster. The watch of the tracker is per
+// cluster, so the GCPCluster is known without waiting for Cluster API to annotate the Nodes.
+func nodeToGCPCluster(gcpCluster client.ObjectKey) handler.MapFunc {
+	return func(_ context.Context, _ client.Object) []ctrl.Request {
+		return []ctrl.Request{{NamespacedName: gcpCluster}}
+	}
+}
+
+// nodeRoutingChanged filters the Node events which can change the pod CIDR routes, ignoring the status updates.
+func nodeRoutingChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+
+			return oldNode.Spec.ProviderID != newNode.Spec.ProviderID ||
+				oldNode.Spec.PodCIDR != newNode.Spec.PodCIDR ||
+				!reflect.DeepEqual(oldNode.Spec.PodCIDRs, newNode.Spec.PodCIDRs)
+		},
+	}
+}
+
+func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPCluster")
 
+	if result, err := r.reconcileOperations(ctx, clusterScope); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	if reconciler.IsOrphanOnDelete(clusterScope.GCPCluster) {
+		return r.reconcileOrphan(ctx, clusterScope)
+	}
+
+	// The status may have been lost, the delete of the other services relies on it.
 	reconcilers := []cloud.Reconciler{
-		subnets.New(clusterScope),
-		loadbalancers.New(clusterScope),
-		firewalls.New(clusterScope),
-		networks.New(clusterScope),
+		tracing.Reconciler("discovery", discovery.New(clusterScope)),
 	}
+	if feature.Gates.Enabled(feature.ExternalResourceGC) {
+		// Resources created by the cloud controller manager block the deletion of the network.
+		reconcilers = append(reconcilers, tracing.Reconciler("externalresources", externalresources.New(clusterScope)))
+	}
+
+	reconcilers = append(reconcilers,
+		tracing.Reconciler("routes", routes.New(clusterScope)),
+		tracing.Reconciler("subnets", subnets.New(clusterScope)),
+		tracing.Reconciler("loadbalancers", loadbalancers.New(clusterScope)),
+		tracing.Reconciler("firewalls", firewalls.New(clusterScope)),
+		tracing.Reconciler("networks", networks.New(clusterScope)),
+	)
 
 	for _, r := range reconcilers {
 		if err := r.Delete(ctx); err != nil {
+			if operations.Track(clusterScope.PendingOperations(), err) {
+				log.Info("Waiting for GCE operation", "reason", err.Error())
+				return ctrl.Result{RequeueAfter: operationPollInterval}, nil
+			}
+
 			log.Error(err, "Reconcile error")
 			record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
-			return err
+			return reconciler.HandleGCPError(clusterScope.GCPCluster, infrav1.NetworkInfrastructureReadyCondition, err)
 		}
 	}
 
 	controllerutil.RemoveFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer)
 	record.Event(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconciled")
+	return ctrl.Result{}, nil
+}
+
+// reconcileOrphan removes the finalizer of a deleted cluster with the orphan-on-delete annotation without deleting
+// its GCP resources, which are left behind without their CAPG labels.
+func (r *GCPClusterReconciler) reconcileOrphan(ctx context.Context, clusterScope *scope.ClusterScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Orphaning GCPCluster resources")
+
+	// The status may have been lost, the instance groups of the load balancer are found from it.
+	if err := tracing.Reconciler("discovery", discovery.New(clusterScope)).Delete(ctx); err != nil {
+		log.Error(err, "Reconcile error")
+		record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
+		return reconciler.HandleGCPError(clusterScope.GCPCluster, infrav1.NetworkInfrastructureReadyCondition, err)
+	}
+
+	orphaned, err := reconciler.Orphan(ctx,
+		routes.New(clusterScope),
+		subnets.New(clusterScope),
+		loadbalancers.New(clusterScope),
+		firewalls.New(clusterScope),
+		networks.New(clusterScope),
+	)
+	if err != nil {
+		log.Error(err, "Error orphaning GCPCluster resources")
+		record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Orphan error - %v", err)
+		return reconciler.HandleGCPError(clusterScope.GCPCluster, infrav1.NetworkInfrastructureReadyCondition, err)
+	}
+
+	controllerutil.RemoveFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer)
+	record.Eventf(clusterScope.GCPCluster, "GCPClusterReconcile", "Orphaned %d GCP resources - %s", len(orphaned), strings.Join(orphaned, ", "))
+	return ctrl.Result{}, nil
+}
+
+// reconcilePlan runs the reconcile, or the delete, of a cluster in dry run and writes the changes of its GCP
+// resources to its plan ConfigMap. The scope of the dry run does not patch the GCPCluster, so a deleted
+// cluster keeps its finalizer until the dry run is disabled.
+func (r *GCPClusterReconciler) reconcilePlan(ctx context.Context, clusterScope *scope.ClusterScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Planning GCPCluster changes")
+
+	var err error
+	if !clusterScope.GCPCluster.DeletionTimestamp.IsZero() {
+		_, err = r.reconcileDelete(ctx, clusterScope)
+	} else {
+		_, err = r.reconcile(ctx, clusterScope)
+	}
+
+	if err := writePlan(ctx, r.Client, "GCPCluster", clusterScope.GCPCluster, clusterScope.Plan(), err); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	record.Eventf(clusterScope.GCPCluster, "GCPClusterPlan", "Planned %d changes of GCP resources in ConfigMap %s",
+		len(clusterScope.Plan().Changes()), planConfigMapName("GCPCluster", clusterScope.GCPCluster))
+	return ctrl.Result{}, err
+}
+
+// reconcileOperations checks the GCE operations started by previous reconciles. It requeues
+// while some of them are running, and returns the errors of the ones that failed.
+func (r *GCPClusterReconciler) reconcileOperations(ctx context.Context, clusterScope *scope.ClusterScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	running, err := clusterScope.PollOperations(ctx, clusterScope.PendingOperations())
+	if err != nil {
+		log.Error(err, "Error checking pending operations")
+		record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Operation error - %v", err)
+		return reconciler.HandleGCPError(clusterScope.GCPCluster, infrav1.NetworkInfrastructureReadyCondition, err)
+	}
+
+	if running {
+		log.Info("GCPCluster has pending operations", "count", len(*clusterScope.PendingOperations()))
+		return ctrl.Result{RequeueAfter: operationPollInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// findFailureDomainSpec returns the failure domain spec of the given zone, nil if the zone is not listed.
+func findFailureDomainSpec(specs []infrav1.FailureDomainSpec, zone string) *infrav1.FailureDomainSpec {
+	for i := range specs {
+		if specs[i].Zone == zone {
+			return &specs[i]
+		}
+	}
+
 	return nil
 }
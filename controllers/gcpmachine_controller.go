@@ -19,18 +19,27 @@ package controllers
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/operations"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/instances"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -42,15 +51,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// bootstrapPollInterval is the interval at which the bootstrap result of a running instance is checked.
+const bootstrapPollInterval = 15 * time.Second
+
 // GCPMachineReconciler reconciles a GCPMachine object.
 type GCPMachineReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// DryRun plans the changes of the GCP resources of all the GCPMachines instead of applying them,
+	// as if they had the dry-run annotation.
+	DryRun bool
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachines/status,verbs=get;update;patch
@@ -88,9 +104,37 @@ func (r *GCPMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		return errors.Wrap(err, "failed adding a watch for ready clusters")
 	}
 
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &corev1.Secret{}),
+		handler.EnqueueRequestsFromMapFunc(r.CredentialsSecretToGCPMachines(ctx)),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for credentials secrets")
+	}
+
 	return nil
 }
 
+// CredentialsSecretToGCPMachines is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// of the GCPMachines of the GCPClusters using a credentials Secret.
+func (r *GCPMachineReconciler) CredentialsSecretToGCPMachines(ctx context.Context) handler.MapFunc {
+	log := ctrl.LoggerFrom(ctx)
+	gcpClusterToGCPMachines := r.GCPClusterToGCPMachines(ctx)
+	return func(mapCtx context.Context, o client.Object) []ctrl.Request {
+		gcpClusters, err := credentialsSecretToGCPClusters(mapCtx, r.Client, o)
+		if err != nil {
+			log.Error(err, "failed to list GCPClusters using credentials secret", "secret", klog.KObj(o))
+			return nil
+		}
+
+		result := []ctrl.Request{}
+		for i := range gcpClusters {
+			result = append(result, gcpClusterToGCPMachines(mapCtx, &gcpClusters[i])...)
+		}
+
+		return result
+	}
+}
+
 // GCPClusterToGCPMachines is a handler.ToRequestsFunc to be used to enqeue requests for reconciliation
 // of GCPMachines.
 func (r *GCPMachineReconciler) GCPClusterToGCPMachines(ctx context.Context) handler.MapFunc {
@@ -135,6 +179,10 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.StartReconcile(ctx, "GCPMachine", req)
+	defer func() { tracing.End(span, reterr) }()
+	ctx = observer.WithContext(ctx)
+
 	log := ctrl.LoggerFrom(ctx)
 	gcpMachine := &infrav1.GCPMachine{}
 	err := r.Get(ctx, req.NamespacedName, gcpMachine)
@@ -179,11 +227,15 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	// The machines of a cluster in dry run are planned too, the resources of the cluster do not exist yet.
+	dryRun := r.DryRun || isDryRun(gcpMachine, gcpCluster)
+
 	// Create the cluster scope
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
 		Client:     r.Client,
 		Cluster:    cluster,
 		GCPCluster: gcpCluster,
+		DryRun:     dryRun,
 	})
 	if err != nil {
 		return ctrl.Result{}, err
@@ -195,6 +247,7 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Machine:       machine,
 		GCPMachine:    gcpMachine,
 		ClusterGetter: clusterScope,
+		DryRun:        dryRun,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -202,33 +255,87 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Always close the scope when exiting this function so we can persist any GCPMachine changes.
 	defer func() {
+		metrics.RecordInstanceStatus(gcpMachine, infrav1.MachineFinalizer, gcpMachine.Status.InstanceStatus)
 		if err := machineScope.Close(); err != nil && reterr == nil {
 			reterr = err
 		}
 	}()
 
+	if dryRun {
+		return r.reconcilePlan(ctx, clusterScope, machineScope)
+	}
+
 	// Handle deleted machines
 	if !gcpMachine.ObjectMeta.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, r.reconcileDelete(ctx, machineScope)
+		return r.reconcileDelete(ctx, clusterScope, machineScope)
 	}
 
 	// Handle non-deleted machines
-	return r.reconcile(ctx, machineScope)
+	return r.reconcile(ctx, clusterScope, machineScope)
+}
+
+// reconcilePlan runs the reconcile, or the delete, of a machine in dry run and writes the changes of its GCP
+// resources to its plan ConfigMap. The scope of the dry run does not patch the GCPMachine, so a deleted
+// machine keeps its finalizer until the dry run is disabled.
+func (r *GCPMachineReconciler) reconcilePlan(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Planning GCPMachine changes")
+
+	var err error
+	if !machineScope.GCPMachine.DeletionTimestamp.IsZero() {
+		_, err = r.reconcileDelete(ctx, clusterScope, machineScope)
+	} else {
+		_, err = r.reconcile(ctx, clusterScope, machineScope)
+	}
+
+	if err := writePlan(ctx, r.Client, "GCPMachine", machineScope.GCPMachine, clusterScope.Plan(), err); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	record.Eventf(machineScope.GCPMachine, "GCPMachinePlan", "Planned %d changes of GCP resources in ConfigMap %s",
+		len(clusterScope.Plan().Changes()), planConfigMapName("GCPMachine", machineScope.GCPMachine))
+	return ctrl.Result{}, err
 }
 
-func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scope.MachineScope) (ctrl.Result, error) {
+func (r *GCPMachineReconciler) reconcile(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling GCPMachine")
 
 	controllerutil.AddFinalizer(machineScope.GCPMachine, infrav1.MachineFinalizer)
+	if err := machineScope.ReconcileZone(); err != nil {
+		log.Error(err, "Error placing GCPMachine in a zone")
+		return ctrl.Result{}, err
+	}
+
 	if err := machineScope.PatchObject(); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if err := instances.New(machineScope).Reconcile(ctx); err != nil {
+	if result, err := r.reconcileOperations(ctx, clusterScope, machineScope); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	if err := tracing.Reconciler("instances", instances.New(machineScope)).Reconcile(ctx); err != nil {
+		if operations.Track(machineScope.PendingOperations(), err) {
+			log.Info("Waiting for GCE operation", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: operationPollInterval}, nil
+		}
+
+		var bootstrapErr *instances.BootstrapFailedError
+		if errors.As(err, &bootstrapErr) {
+			log.Info("GCPMachine instance bootstrap failed", "reason", bootstrapErr.Reason, "message", bootstrapErr.Message)
+			record.Warnf(machineScope.GCPMachine, "GCPMachineBootstrap", "Bootstrap failed - %v, serial console output:\n%s", bootstrapErr, bootstrapErr.SerialOutputTail)
+			machineScope.SetBootstrapFailed(bootstrapErr.Reason, bootstrapErr)
+			return ctrl.Result{}, nil
+		}
+
 		log.Error(err, "Error reconciling instance resources")
 		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Reconcile error - %v", err)
-		return ctrl.Result{}, err
+		return handleInstanceError(machineScope, err)
+	}
+
+	if conditions.IsFalse(machineScope.GCPMachine, infrav1.InstanceAdoptedCondition) {
+		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Adopted instance - %s", conditions.GetMessage(machineScope.GCPMachine, infrav1.InstanceAdoptedCondition))
 	}
 
 	instanceState := *machineScope.GetInstanceStatus()
@@ -236,30 +343,114 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 	case infrav1.InstanceStatusProvisioning, infrav1.InstanceStatusStaging:
 		log.Info("GCPMachine instance is pending", "instance-id", *machineScope.GetInstanceID())
 		record.Eventf(machineScope.GCPMachine, "GCPMachineReconcile", "GCPMachine instance is pending - instance-id: %s", *machineScope.GetInstanceID())
+		conditions.MarkFalse(machineScope.GCPMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotRunningReason, clusterv1.ConditionSeverityInfo, "Instance is %s", instanceState)
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	case infrav1.InstanceStatusRunning:
 		log.Info("GCPMachine instance is running", "instance-id", *machineScope.GetInstanceID())
 		record.Eventf(machineScope.GCPMachine, "GCPMachineReconcile", "GCPMachine instance is running - instance-id: %s", *machineScope.GetInstanceID())
+		conditions.MarkTrue(machineScope.GCPMachine, infrav1.InstanceReadyCondition)
+		if machineScope.BootstrapCheck() != nil && !conditions.IsTrue(machineScope.GCPMachine, infrav1.BootstrapSucceededCondition) {
+			if machineScope.IsBootstrapPending() {
+				log.Info("GCPMachine instance bootstrap is pending", "instance-id", *machineScope.GetInstanceID())
+				return ctrl.Result{RequeueAfter: bootstrapPollInterval}, nil
+			}
+
+			return ctrl.Result{}, nil
+		}
+
 		record.Event(machineScope.GCPMachine, "GCPMachineReconcile", "Reconciled")
 		machineScope.SetReady()
 		return ctrl.Result{}, nil
 	default:
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
 		machineScope.SetFailureMessage(errors.Errorf("GCPMachine instance state %s is unexpected", instanceState))
+		conditions.MarkFalse(machineScope.GCPMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotRunningReason, clusterv1.ConditionSeverityError, "Instance state %s is unexpected", instanceState)
 		return ctrl.Result{Requeue: true}, nil
 	}
 }
 
-func (r *GCPMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope) error {
+func (r *GCPMachineReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPMachine")
 
-	if err := instances.New(machineScope).Delete(ctx); err != nil {
+	if result, err := r.reconcileOperations(ctx, clusterScope, machineScope); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	if reconciler.IsOrphanOnDelete(machineScope.GCPMachine, clusterScope.GCPCluster) {
+		return r.reconcileOrphan(ctx, machineScope)
+	}
+
+	if err := tracing.Reconciler("instances", instances.New(machineScope)).Delete(ctx); err != nil {
+		if operations.Track(machineScope.PendingOperations(), err) {
+			log.Info("Waiting for GCE operation", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: operationPollInterval}, nil
+		}
+
 		log.Error(err, "Error deleting instance resources")
-		return err
+		return reconciler.HandleGCPError(machineScope.GCPMachine, infrav1.InstanceReadyCondition, err)
 	}
 
 	controllerutil.RemoveFinalizer(machineScope.GCPMachine, infrav1.MachineFinalizer)
 	record.Event(machineScope.GCPMachine, "GCPMachineReconcile", "Reconciled")
-	return nil
+	return ctrl.Result{}, nil
+}
+
+// reconcileOrphan removes the finalizer of a deleted machine with the orphan-on-delete annotation, or of an orphaned
+// cluster, without deleting its instance, which is left behind without its CAPG labels.
+func (r *GCPMachineReconciler) reconcileOrphan(ctx context.Context, machineScope *scope.MachineScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Orphaning GCPMachine resources")
+
+	orphaned, err := reconciler.Orphan(ctx, instances.New(machineScope))
+	if err != nil {
+		log.Error(err, "Error orphaning instance resources")
+		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Orphan error - %v", err)
+		return reconciler.HandleGCPError(machineScope.GCPMachine, infrav1.InstanceReadyCondition, err)
+	}
+
+	controllerutil.RemoveFinalizer(machineScope.GCPMachine, infrav1.MachineFinalizer)
+	record.Eventf(machineScope.GCPMachine, "GCPMachineReconcile", "Orphaned %d GCP resources - %s", len(orphaned), strings.Join(orphaned, ", "))
+	return ctrl.Result{}, nil
+}
+
+// handleInstanceError reports an error of the GCE instance of a machine. An invalid machine type or image
+// is also reported as the failure of the machine as it requires a new GCPMachine.
+func handleInstanceError(machineScope *scope.MachineScope, err error) (ctrl.Result, error) {
+	if isInvalidInstanceConfiguration(err) {
+		machineScope.SetFailureReason(capierrors.InvalidConfigurationMachineError)
+		machineScope.SetFailureMessage(err)
+	}
+
+	return reconciler.HandleGCPError(machineScope.GCPMachine, infrav1.InstanceReadyCondition, err)
+}
+
+// isInvalidInstanceConfiguration reports whether err was returned by the creation of an instance because
+// its machine type or image is invalid, which cannot be fixed by retrying.
+func isInvalidInstanceConfiguration(err error) bool {
+	field, ok := gcperrors.InvalidField(err)
+	if !ok {
+		return false
+	}
+
+	return field == "resource.machineType" || strings.HasSuffix(field, ".initializeParams.sourceImage")
+}
+
+// reconcileOperations checks the GCE operations started by previous reconciles. It requeues
+// while some of them are running, and returns the errors of the ones that failed.
+func (r *GCPMachineReconciler) reconcileOperations(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	running, err := clusterScope.PollOperations(ctx, machineScope.PendingOperations())
+	if err != nil {
+		log.Error(err, "Error checking pending operations")
+		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Operation error - %v", err)
+		return reconciler.HandleGCPError(machineScope.GCPMachine, infrav1.InstanceReadyCondition, err)
+	}
+
+	if running {
+		log.Info("GCPMachine has pending operations", "count", len(*machineScope.PendingOperations()))
+		return ctrl.Result{RequeueAfter: operationPollInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
 }
@@ -59,6 +59,17 @@ type GCPManagedClusterSpec struct {
 	// supplied then the credentials of the controller will be used.
 	// +optional
 	CredentialsRef *infrav1.ObjectReference `json:"credentialsRef,omitempty"`
+
+	// ServiceAccountImpersonation is an optional service account impersonated with the credentials to provision this
+	// cluster, which lets a single identity of the controller manage the clusters of many projects without storing
+	// their keys.
+	// +optional
+	ServiceAccountImpersonation *infrav1.ServiceAccountImpersonation `json:"serviceAccountImpersonation,omitempty"`
+
+	// IdentityRef is an optional reference to a cluster identity to use for provisioning this cluster, instead of
+	// CredentialsRef and ServiceAccountImpersonation. The namespace of the cluster must be allowed by the identity.
+	// +optional
+	IdentityRef *infrav1.GCPIdentityReference `json:"identityRef,omitempty"`
 }
 
 // GCPManagedClusterStatus defines the observed state of GCPManagedCluster.
@@ -97,6 +108,16 @@ type GCPManagedClusterList struct {
 	Items           []GCPManagedCluster `json:"items"`
 }
 
+// GetConditions returns the managed cluster conditions.
+func (r *GCPManagedCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the status conditions for the GCPManagedCluster.
+func (r *GCPManagedCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&GCPManagedCluster{}, &GCPManagedClusterList{})
 }
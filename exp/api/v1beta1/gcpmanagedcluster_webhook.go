@@ -21,6 +21,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -52,8 +53,13 @@ var _ webhook.Validator = &GCPManagedCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *GCPManagedCluster) ValidateCreate() (admission.Warnings, error) {
 	gcpmanagedclusterlog.Info("validate create", "name", r.Name)
+	allErrs := infrav1.ValidateIdentityRef(r.Spec.IdentityRef, r.Spec.CredentialsRef, r.Spec.ServiceAccountImpersonation, field.NewPath("spec"))
+	allErrs = append(allErrs, infrav1.ValidateCredentialsRef(r.Namespace, r.Spec.CredentialsRef, field.NewPath("spec", "credentialsRef"))...)
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
 
-	return nil, nil
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedCluster").GroupKind(), r.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -83,6 +89,22 @@ func (r *GCPManagedCluster) ValidateUpdate(oldRaw runtime.Object) (admission.War
 		)
 	}
 
+	if !cmp.Equal(r.Spec.ServiceAccountImpersonation, old.Spec.ServiceAccountImpersonation) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "ServiceAccountImpersonation"),
+				r.Spec.ServiceAccountImpersonation, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.IdentityRef, old.Spec.IdentityRef) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "IdentityRef"),
+				r.Spec.IdentityRef, "field is immutable"),
+		)
+	}
+
+	allErrs = append(allErrs, infrav1.ValidateResourceNamingUpdate(r.Annotations, old.Annotations)...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
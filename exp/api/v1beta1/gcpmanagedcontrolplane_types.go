@@ -77,6 +77,12 @@ type GCPManagedControlPlaneStatus struct {
 	// CurrentVersion shows the current version of the GKE control plane.
 	// +optional
 	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// ResourceManagerTagValues are the resource names of the tag values bound by CAPG to the GKE cluster,
+	// e.g. tagValues/123. Only the bindings of these tag values are deleted when tags are removed from the spec of
+	// the GCPManagedCluster, the bindings created by other means are left untouched.
+	// +optional
+	ResourceManagerTagValues []string `json:"resourceManagerTagValues,omitempty"`
 }
 
 // +kubebuilder:object:root=true
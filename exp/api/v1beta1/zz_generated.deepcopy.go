@@ -108,6 +108,16 @@ func (in *GCPManagedClusterSpec) DeepCopyInto(out *GCPManagedClusterSpec) {
 		*out = new(apiv1beta1.ObjectReference)
 		**out = **in
 	}
+	if in.ServiceAccountImpersonation != nil {
+		in, out := &in.ServiceAccountImpersonation, &out.ServiceAccountImpersonation
+		*out = new(apiv1beta1.ServiceAccountImpersonation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(apiv1beta1.GCPIdentityReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterSpec.
@@ -250,6 +260,11 @@ func (in *GCPManagedControlPlaneStatus) DeepCopyInto(out *GCPManagedControlPlane
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourceManagerTagValues != nil {
+		in, out := &in.ResourceManagerTagValues, &out.ResourceManagerTagValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneStatus.
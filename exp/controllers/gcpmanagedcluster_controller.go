@@ -19,23 +19,31 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/index"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -59,6 +67,7 @@ type GCPManagedClusterReconciler struct {
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusterstaticidentities;gcpclusterimpersonationidentities,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -66,6 +75,10 @@ func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.StartReconcile(ctx, "GCPManagedCluster", req)
+	defer func() { tracing.End(span, reterr) }()
+	ctx = observer.WithContext(ctx)
+
 	log := log.FromContext(ctx)
 
 	gcpCluster := &infrav1exp.GCPManagedCluster{}
@@ -124,6 +137,7 @@ func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	// Always close the scope when exiting this function so we can persist any GCPMachine changes.
 	defer func() {
+		metrics.RecordReady("GCPManagedCluster", gcpCluster, infrav1exp.ClusterFinalizer, gcpCluster.Status.Ready)
 		if err := clusterScope.Close(); err != nil && reterr == nil {
 			reterr = err
 		}
@@ -135,7 +149,7 @@ func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// Handle non-deleted clusters
-	return ctrl.Result{}, r.reconcile(ctx, clusterScope)
+	return r.reconcile(ctx, clusterScope)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -164,26 +178,41 @@ func (r *GCPManagedClusterReconciler) SetupWithManager(ctx context.Context, mgr
 		return fmt.Errorf("adding watch for ready clusters: %v", err)
 	}
 
+	if err = c.Watch(
+		source.Kind(mgr.GetCache(), &corev1.Secret{}),
+		handler.EnqueueRequestsFromMapFunc(r.credentialsSecretMapper()),
+	); err != nil {
+		return fmt.Errorf("adding watch for credentials secrets: %v", err)
+	}
+
 	return nil
 }
 
-func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScope *scope.ManagedClusterScope) error {
+func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScope *scope.ManagedClusterScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("controller", "gcpmanagedcluster")
 	log.Info("Reconciling GCPManagedCluster")
 
+	clusterScope.DefaultResourceNaming()
 	controllerutil.AddFinalizer(clusterScope.GCPManagedCluster, infrav1exp.ClusterFinalizer)
 	if err := clusterScope.PatchObject(); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
+	if err := clusterScope.ValidateCredentials(ctx); err != nil {
+		log.Error(err, "Invalid credentials")
+		conditions.MarkFalse(clusterScope.GCPManagedCluster, infrav1.CredentialsValidCondition, infrav1.CredentialsInvalidReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return ctrl.Result{}, err
+	}
+	conditions.MarkTrue(clusterScope.GCPManagedCluster, infrav1.CredentialsValidCondition)
+
 	region, err := clusterScope.Cloud().Regions().Get(ctx, meta.GlobalKey(clusterScope.Region()))
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	zones, err := clusterScope.Cloud().Zones().List(ctx, filter.Regexp("region", region.SelfLink))
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	failureDomains := make(clusterv1.FailureDomains, len(zones))
@@ -201,13 +230,15 @@ func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScop
 
 	for name, r := range reconcilers {
 		log.V(4).Info("Calling reconciler", "reconciler", name)
-		if err := r.Reconcile(ctx); err != nil {
+		if err := tracing.Reconciler(name, r).Reconcile(ctx); err != nil {
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
-			return err
+			return reconciler.HandleGCPError(clusterScope.GCPManagedCluster, infrav1.NetworkInfrastructureReadyCondition, err)
 		}
 	}
 
+	conditions.MarkTrue(clusterScope.GCPManagedCluster, infrav1.NetworkInfrastructureReadyCondition)
+
 	clusterScope.SetReady()
 	record.Event(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Ready")
 
@@ -221,7 +252,7 @@ func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScop
 		record.Eventf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Got control-plane endpoint - %s", controlPlaneEndpoint.Host)
 	}
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ManagedClusterScope) (ctrl.Result, error) {
@@ -233,6 +264,19 @@ func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clust
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	}
 
+	if reconciler.IsOrphanOnDelete(clusterScope.GCPManagedCluster) {
+		orphaned, err := reconciler.Orphan(ctx, subnets.New(clusterScope), networks.New(clusterScope))
+		if err != nil {
+			log.Error(err, "Error orphaning GCPManagedCluster resources")
+			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Orphan error - %v", err)
+			return reconciler.HandleGCPError(clusterScope.GCPManagedCluster, infrav1.NetworkInfrastructureReadyCondition, err)
+		}
+
+		controllerutil.RemoveFinalizer(clusterScope.GCPManagedCluster, infrav1exp.ClusterFinalizer)
+		record.Eventf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Orphaned %d GCP resources - %s", len(orphaned), strings.Join(orphaned, ", "))
+		return ctrl.Result{}, nil
+	}
+
 	reconcilers := map[string]cloud.Reconciler{
 		"subnets":  subnets.New(clusterScope),
 		"networks": networks.New(clusterScope),
@@ -240,10 +284,10 @@ func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clust
 
 	for name, r := range reconcilers {
 		log.V(4).Info("Calling reconciler delete", "reconciler", name)
-		if err := r.Delete(ctx); err != nil {
+		if err := tracing.Reconciler(name, r).Delete(ctx); err != nil {
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+			return reconciler.HandleGCPError(clusterScope.GCPManagedCluster, infrav1.NetworkInfrastructureReadyCondition, err)
 		}
 	}
 
@@ -295,3 +339,68 @@ func (r *GCPManagedClusterReconciler) managedControlPlaneMapper() handler.MapFun
 		}
 	}
 }
+
+func (r *GCPManagedClusterReconciler) credentialsSecretMapper() handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		log := ctrl.LoggerFrom(ctx).WithValues("objectMapper", "secretTomc", "secret", klog.KObj(o))
+
+		gcpManagedClusters, err := credentialsSecretToGCPManagedClusters(ctx, r.Client, o)
+		if err != nil {
+			log.Error(err, "failed to list GCPManagedClusters using credentials secret")
+			return nil
+		}
+
+		result := make([]ctrl.Request, 0, len(gcpManagedClusters))
+		for i := range gcpManagedClusters {
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&gcpManagedClusters[i])})
+		}
+
+		return result
+	}
+}
+
+// credentialsSecretToGCPManagedClusters returns the GCPManagedClusters using a credentials Secret, directly or through
+// an identity.
+func credentialsSecretToGCPManagedClusters(ctx context.Context, c client.Client, secret client.Object) ([]infrav1exp.GCPManagedCluster, error) {
+	gcpManagedClusters := &infrav1exp.GCPManagedClusterList{}
+	if err := c.List(ctx, gcpManagedClusters, client.MatchingFields{index.GCPManagedClusterCredentialsSecretField: index.SecretKey(secret.GetNamespace(), secret.GetName())}); err != nil {
+		return nil, err
+	}
+
+	identityKeys, err := index.SecretIdentityKeys(ctx, c, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identityKey := range identityKeys {
+		identityClusters := &infrav1exp.GCPManagedClusterList{}
+		if err := c.List(ctx, identityClusters, client.MatchingFields{index.GCPManagedClusterIdentityField: identityKey}); err != nil {
+			return nil, err
+		}
+		gcpManagedClusters.Items = append(gcpManagedClusters.Items, identityClusters.Items...)
+	}
+
+	return gcpManagedClusters.Items, nil
+}
+
+// credentialsSecretToClusters returns the Clusters of the GCPManagedClusters using a credentials Secret.
+func credentialsSecretToClusters(ctx context.Context, c client.Client, secret client.Object) ([]*clusterv1.Cluster, error) {
+	gcpManagedClusters, err := credentialsSecretToGCPManagedClusters(ctx, c, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]*clusterv1.Cluster, 0, len(gcpManagedClusters))
+	for i := range gcpManagedClusters {
+		cluster, err := util.GetOwnerCluster(ctx, c, gcpManagedClusters[i].ObjectMeta)
+		if err != nil {
+			return nil, err
+		}
+		if cluster == nil {
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, nil
+}
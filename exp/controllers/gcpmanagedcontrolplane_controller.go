@@ -19,15 +19,24 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"sigs.k8s.io/cluster-api/util/annotations"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/clusters"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/preflight"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -81,13 +90,47 @@ func (r *GCPManagedControlPlaneReconciler) SetupWithManager(ctx context.Context,
 		return fmt.Errorf("failed adding a watch for ready clusters: %w", err)
 	}
 
+	if err = c.Watch(
+		source.Kind(mgr.GetCache(), &corev1.Secret{}),
+		handler.EnqueueRequestsFromMapFunc(r.credentialsSecretMapper()),
+	); err != nil {
+		return fmt.Errorf("failed adding a watch for credentials secrets: %w", err)
+	}
+
 	return nil
 }
 
+func (r *GCPManagedControlPlaneReconciler) credentialsSecretMapper() handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		log := ctrl.LoggerFrom(ctx).WithValues("objectMapper", "secretTomcp", "secret", klog.KObj(o))
+
+		clusters, err := credentialsSecretToClusters(ctx, r.Client, o)
+		if err != nil {
+			log.Error(err, "failed to get clusters using credentials secret")
+			return nil
+		}
+
+		var result []ctrl.Request
+		for _, cluster := range clusters {
+			controlPlaneRef := cluster.Spec.ControlPlaneRef
+			if controlPlaneRef == nil || controlPlaneRef.Kind != "GCPManagedControlPlane" {
+				continue
+			}
+			result = append(result, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: controlPlaneRef.Namespace, Name: controlPlaneRef.Name}})
+		}
+
+		return result
+	}
+}
+
 func (r *GCPManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.StartReconcile(ctx, "GCPManagedControlPlane", req)
+	defer func() { tracing.End(span, reterr) }()
+	ctx = observer.WithContext(ctx)
+
 	log := ctrl.LoggerFrom(ctx)
 
 	// Get the control plane instance
@@ -138,6 +181,7 @@ func (r *GCPManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 
 	// Always close the scope when exiting this function so we can persist any GCPMachine changes.
 	defer func() {
+		metrics.RecordReady("GCPManagedControlPlane", gcpManagedControlPlane, infrav1exp.ManagedControlPlaneFinalizer, gcpManagedControlPlane.Status.Ready)
 		if err := managedControlPlaneScope.Close(); err != nil && reterr == nil {
 			reterr = err
 		}
@@ -161,21 +205,44 @@ func (r *GCPManagedControlPlaneReconciler) reconcile(ctx context.Context, manage
 		return ctrl.Result{}, err
 	}
 
+	if err := managedControlPlaneScope.ValidateCredentials(ctx); err != nil {
+		log.Error(err, "Invalid credentials")
+		conditions.MarkFalse(managedControlPlaneScope.GCPManagedControlPlane, infrav1.CredentialsValidCondition, infrav1.CredentialsInvalidReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return ctrl.Result{}, err
+	}
+	conditions.MarkTrue(managedControlPlaneScope.GCPManagedControlPlane, infrav1.CredentialsValidCondition)
+
 	if !managedControlPlaneScope.GCPManagedCluster.Status.Ready {
 		log.Info("GCPManagedCluster not ready yet, retry later")
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	}
 
+	// The project is checked until the control plane is ready, the usage of the quotas then includes its own nodes.
+	if reconciler.IsPreflightChecksSkipped(managedControlPlaneScope.GCPManagedControlPlane, managedControlPlaneScope.GCPManagedCluster) {
+		conditions.Delete(managedControlPlaneScope.GCPManagedControlPlane, infrav1.PreflightChecksPassedCondition)
+	} else if !managedControlPlaneScope.GCPManagedControlPlane.Status.Ready && !conditions.IsTrue(managedControlPlaneScope.GCPManagedControlPlane, infrav1.PreflightChecksPassedCondition) {
+		if err := preflight.New(managedControlPlaneScope).Reconcile(ctx); err != nil {
+			if errors.Is(err, preflight.ErrChecksFailed) {
+				log.Info("GCPManagedControlPlane failed preflight checks", "reason", err.Error())
+				record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Preflight checks failed - %v", err)
+				return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+			}
+
+			log.Error(err, "Error running preflight checks")
+			return reconciler.HandleGCPError(managedControlPlaneScope.GCPManagedControlPlane, infrav1.PreflightChecksPassedCondition, err)
+		}
+	}
+
 	reconcilers := map[string]cloud.ReconcilerWithResult{
 		"container_clusters": clusters.New(managedControlPlaneScope),
 	}
 
 	for name, r := range reconcilers {
-		res, err := r.Reconcile(ctx)
+		res, err := tracing.ReconcilerWithResult(name, r).Reconcile(ctx)
 		if err != nil {
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+			return reconciler.HandleGCPError(managedControlPlaneScope.GCPManagedControlPlane, infrav1exp.GKEControlPlaneReadyCondition, err)
 		}
 		if res.RequeueAfter > 0 {
 			log.V(4).Info("Reconciler requested requeueAfter", "reconciler", name, "after", res.RequeueAfter)
@@ -194,16 +261,29 @@ func (r *GCPManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 	log := log.FromContext(ctx).WithValues("controller", "gcpmanagedcontrolplane", "action", "delete")
 	log.Info("Deleting GCPManagedControlPlane")
 
+	if reconciler.IsOrphanOnDelete(managedControlPlaneScope.GCPManagedControlPlane, managedControlPlaneScope.GCPManagedCluster) {
+		orphaned, err := reconciler.Orphan(ctx, clusters.New(managedControlPlaneScope))
+		if err != nil {
+			log.Error(err, "Error orphaning GCPManagedControlPlane resources")
+			record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Orphan error - %v", err)
+			return reconciler.HandleGCPError(managedControlPlaneScope.GCPManagedControlPlane, infrav1exp.GKEControlPlaneReadyCondition, err)
+		}
+
+		controllerutil.RemoveFinalizer(managedControlPlaneScope.GCPManagedControlPlane, infrav1exp.ManagedControlPlaneFinalizer)
+		record.Eventf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Orphaned %d GCP resources - %s", len(orphaned), strings.Join(orphaned, ", "))
+		return ctrl.Result{}, nil
+	}
+
 	reconcilers := map[string]cloud.ReconcilerWithResult{
 		"container_clusters": clusters.New(managedControlPlaneScope),
 	}
 
 	for name, r := range reconcilers {
-		res, err := r.Delete(ctx)
+		res, err := tracing.ReconcilerWithResult(name, r).Delete(ctx)
 		if err != nil {
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+			return reconciler.HandleGCPError(managedControlPlaneScope.GCPManagedControlPlane, infrav1exp.GKEControlPlaneReadyCondition, err)
 		}
 		if res.RequeueAfter > 0 {
 			log.V(4).Info("Reconciler requested requeueAfter", "reconciler", name, "after", res.RequeueAfter)
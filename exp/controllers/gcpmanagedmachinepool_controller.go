@@ -19,16 +19,17 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/nodepools"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -38,7 +39,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/observer"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -145,6 +149,35 @@ func managedControlPlaneToManagedMachinePoolMapFunc(c client.Client, gvk schema.
 	}
 }
 
+func credentialsSecretToManagedMachinePoolMapFunc(c client.Client, gvk schema.GroupVersionKind, log logr.Logger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		clusters, err := credentialsSecretToClusters(ctx, c, o)
+		if err != nil {
+			log.Error(err, "couldn't get clusters using credentials secret")
+			return nil
+		}
+
+		mapFunc := machinePoolToInfrastructureMapFunc(gvk)
+
+		var results []ctrl.Request
+		for _, cluster := range clusters {
+			managedPoolForClusterList := expclusterv1.MachinePoolList{}
+			if err := c.List(
+				ctx, &managedPoolForClusterList, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+			); err != nil {
+				log.Error(err, "couldn't list pools for cluster")
+				return nil
+			}
+
+			for i := range managedPoolForClusterList.Items {
+				results = append(results, mapFunc(ctx, &managedPoolForClusterList.Items[i])...)
+			}
+		}
+
+		return results
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GCPManagedMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	log := log.FromContext(ctx).WithValues("controller", "GCPManagedMachinePool")
@@ -185,6 +218,13 @@ func (r *GCPManagedMachinePoolReconciler) SetupWithManager(ctx context.Context,
 		return errors.Wrap(err, "failed adding a watch for ready clusters")
 	}
 
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &corev1.Secret{}),
+		handler.EnqueueRequestsFromMapFunc(credentialsSecretToManagedMachinePoolMapFunc(r.Client, gvk, log)),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for credentials secrets")
+	}
+
 	return nil
 }
 
@@ -227,6 +267,10 @@ func (r *GCPManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.StartReconcile(ctx, "GCPManagedMachinePool", req)
+	defer func() { tracing.End(span, reterr) }()
+	ctx = observer.WithContext(ctx)
+
 	log := ctrl.LoggerFrom(ctx)
 
 	// Get the managed machine pool
@@ -324,24 +368,28 @@ func (r *GCPManagedMachinePoolReconciler) reconcile(ctx context.Context, managed
 		return ctrl.Result{}, err
 	}
 
+	if err := managedMachinePoolScope.ValidateCredentials(ctx); err != nil {
+		log.Error(err, "Invalid credentials")
+		conditions.MarkFalse(managedMachinePoolScope.GCPManagedMachinePool, infrav1.CredentialsValidCondition, infrav1.CredentialsInvalidReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return ctrl.Result{}, err
+	}
+	conditions.MarkTrue(managedMachinePoolScope.GCPManagedMachinePool, infrav1.CredentialsValidCondition)
+
 	reconcilers := map[string]cloud.ReconcilerWithResult{
 		"nodepools": nodepools.New(managedMachinePoolScope),
 	}
 
 	for name, r := range reconcilers {
 		log.V(4).Info("Calling reconciler", "reconciler", name)
-		res, err := r.Reconcile(ctx)
+		res, err := tracing.ReconcilerWithResult(name, r).Reconcile(ctx)
 		if err != nil {
-			var e *apierror.APIError
-			if ok := errors.As(err, &e); ok {
-				if e.GRPCStatus().Code() == codes.FailedPrecondition {
-					log.Info("Cannot perform update when there's other operation, retry later", "reconciler", name)
-					return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
-				}
+			if gcperrors.IsConflict(err) {
+				log.Info("Cannot perform update when there's other operation, retry later", "reconciler", name)
+				return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 			}
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+			return reconciler.HandleGCPError(managedMachinePoolScope.GCPManagedMachinePool, infrav1exp.GKEMachinePoolReadyCondition, err)
 		}
 		if res.RequeueAfter > 0 {
 			log.V(4).Info("Reconciler requested requeueAfter", "reconciler", name, "after", res.RequeueAfter)
@@ -360,17 +408,30 @@ func (r *GCPManagedMachinePoolReconciler) reconcileDelete(ctx context.Context, m
 	log := log.FromContext(ctx).WithValues("controller", "gcpmanagedmachinepool", "action", "delete")
 	log.Info("Deleting GCPManagedMachinePool")
 
+	if reconciler.IsOrphanOnDelete(managedMachinePoolScope.GCPManagedMachinePool, managedMachinePoolScope.GCPManagedControlPlane, managedMachinePoolScope.GCPManagedCluster) {
+		orphaned, err := reconciler.Orphan(ctx, nodepools.New(managedMachinePoolScope))
+		if err != nil {
+			log.Error(err, "Error orphaning GCPManagedMachinePool resources")
+			record.Warnf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Orphan error - %v", err)
+			return reconciler.HandleGCPError(managedMachinePoolScope.GCPManagedMachinePool, infrav1exp.GKEMachinePoolReadyCondition, err)
+		}
+
+		controllerutil.RemoveFinalizer(managedMachinePoolScope.GCPManagedMachinePool, infrav1exp.ManagedMachinePoolFinalizer)
+		record.Eventf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Orphaned %d GCP resources - %s", len(orphaned), strings.Join(orphaned, ", "))
+		return ctrl.Result{}, nil
+	}
+
 	reconcilers := map[string]cloud.ReconcilerWithResult{
 		"nodepools": nodepools.New(managedMachinePoolScope),
 	}
 
 	for name, r := range reconcilers {
 		log.V(4).Info("Calling reconciler delete", "reconciler", name)
-		res, err := r.Delete(ctx)
+		res, err := tracing.ReconcilerWithResult(name, r).Delete(ctx)
 		if err != nil {
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Reconcile error - %v", err)
-			return ctrl.Result{}, err
+			return reconciler.HandleGCPError(managedMachinePoolScope.GCPManagedMachinePool, infrav1exp.GKEMachinePoolReadyCondition, err)
 		}
 		if res.RequeueAfter > 0 {
 			log.V(4).Info("Reconciler requested requeueAfter", "reconciler", name, "after", res.RequeueAfter)
@@ -30,6 +30,11 @@ const (
 	// owner: @richardchen331 & @richardcase
 	// alpha: v0.1
 	GKE featuregate.Feature = "GKE"
+
+	// ExternalResourceGC is used to enable the garbage collection of the resources created by the
+	// workload cluster cloud controller manager and CSI driver on cluster deletion.
+	// alpha: v1.5
+	ExternalResourceGC featuregate.Feature = "ExternalResourceGC"
 )
 
 func init() {
@@ -39,5 +44,6 @@ func init() {
 // defaultCAPGFeatureGates consists of all known capg-specific feature keys.
 // To add a new feature, define a key for it above and add it here.
 var defaultCAPGFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	GKE: {Default: false, PreRelease: featuregate.Alpha},
+	GKE:                {Default: false, PreRelease: featuregate.Alpha},
+	ExternalResourceGC: {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -36,13 +36,17 @@ import (
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-gcp/api/v1alpha3" //nolint: staticcheck
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-gcp/api/v1alpha4" //nolint: staticcheck
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/ratelimit"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/tracing"
 	"sigs.k8s.io/cluster-api-provider-gcp/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	expcontrollers "sigs.k8s.io/cluster-api-provider-gcp/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-gcp/feature"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/index"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-gcp/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -84,6 +88,11 @@ var (
 	leaderElectionLeaseDuration time.Duration
 	leaderElectionRenewDeadline time.Duration
 	leaderElectionRetryPeriod   time.Duration
+	gcpAPIQPS                   float64
+	gcpAPIBurst                 int
+	gcpAPIRateLimits            map[string]string
+	tracingOptions              tracing.Options
+	dryRun                      bool
 )
 
 func main() {
@@ -114,6 +123,23 @@ func main() {
 
 	setupLog.Info(fmt.Sprintf("feature gates: %+v\n", feature.Gates))
 
+	rateLimits, err := ratelimit.ParseLimits(gcpAPIRateLimits)
+	if err != nil {
+		setupLog.Error(err, "invalid GCP API rate limits")
+		os.Exit(1)
+	}
+
+	rateLimitConfig := ratelimit.DefaultConfig()
+	rateLimitConfig.Default = ratelimit.Limit{QPS: gcpAPIQPS, Burst: gcpAPIBurst}
+	if err := rateLimitConfig.Default.Validate(); err != nil {
+		setupLog.Error(err, "invalid GCP API rate limit, check --gcp-api-qps and --gcp-api-burst")
+		os.Exit(1)
+	}
+	for name, limit := range rateLimits {
+		rateLimitConfig.Overrides[name] = limit
+	}
+	ratelimit.Configure(rateLimitConfig)
+
 	// Machine and cluster operations can create enough events to trigger the event recorder spam filter
 	// Setting the burst size higher ensures all events will be recorded and submitted to the API
 	broadcaster := cgrecord.NewBroadcasterWithCorrelatorOptions(cgrecord.CorrelatorOptions{
@@ -147,6 +173,12 @@ func main() {
 	// Setup the context that's going to be used in controllers and for the manager.
 	ctx := ctrl.SetupSignalHandler()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracingOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to setup tracing")
+		os.Exit(1)
+	}
+
 	if setupErr := setupReconcilers(ctx, mgr); setupErr != nil {
 		setupLog.Error(err, "unable to setup reconcilers")
 		os.Exit(1)
@@ -168,20 +200,48 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "problem flushing traces")
+	}
 }
 
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
+	if err := index.AddGCPClusterIndexes(ctx, mgr); err != nil {
+		return fmt.Errorf("setting up GCPCluster indexes: %w", err)
+	}
+
 	if err := (&controllers.GCPMachineReconciler{
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		DryRun:           dryRun,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
 		return fmt.Errorf("setting up GCPMachine controller: %w", err)
 	}
+	log := ctrl.Log.WithName("remote").WithName("ClusterCacheTracker")
+	tracker, err := remote.NewClusterCacheTracker(mgr, remote.ClusterCacheTrackerOptions{
+		ControllerName: "capg",
+		Log:            &log,
+	})
+	if err != nil {
+		return fmt.Errorf("setting up cluster cache tracker: %w", err)
+	}
+
+	if err := (&remote.ClusterCacheReconciler{
+		Client:           mgr.GetClient(),
+		Tracker:          tracker,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
+		return fmt.Errorf("setting up cluster cache reconciler: %w", err)
+	}
+
 	if err := (&controllers.GCPClusterReconciler{
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		DryRun:           dryRun,
+		Tracker:          tracker,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
 		return fmt.Errorf("setting up GCPCluster controller: %w", err)
 	}
@@ -189,6 +249,10 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 	if feature.Gates.Enabled(feature.GKE) {
 		setupLog.Info("Enabling GKE reconcilers")
 
+		if err := index.AddGCPManagedClusterIndexes(ctx, mgr); err != nil {
+			return fmt.Errorf("setting up GCPManagedCluster indexes: %w", err)
+		}
+
 		if err := (&expcontrollers.GCPManagedClusterReconciler{
 			Client:           mgr.GetClient(),
 			ReconcileTimeout: reconcileTimeout,
@@ -366,5 +430,47 @@ func initFlags(fs *pflag.FlagSet) {
 		"The maximum duration a reconcile loop can run (e.g. 90m)",
 	)
 
+	fs.Float64Var(&gcpAPIQPS,
+		"gcp-api-qps",
+		ratelimit.DefaultQPS,
+		"Maximum rate of the GCP API calls of a project that have no rate limit of their own",
+	)
+
+	fs.IntVar(&gcpAPIBurst,
+		"gcp-api-burst",
+		ratelimit.DefaultBurst,
+		"Maximum burst of the GCP API calls of a project that have no rate limit of their own",
+	)
+
+	fs.StringToStringVar(&gcpAPIRateLimits,
+		"gcp-api-rate-limits",
+		map[string]string{},
+		"Rate limits of the GCP API calls of a project by service or operation, formatted as qps or qps:burst (e.g. Instances.Insert=5:10,Operations.Get=10)",
+	)
+
+	fs.StringVar(&tracingOptions.Endpoint,
+		"tracing-otlp-endpoint",
+		"",
+		"Address of the OTLP gRPC collector the traces are exported to (e.g. otel-collector:4317). If unspecified, tracing is disabled.",
+	)
+
+	fs.BoolVar(&tracingOptions.Insecure,
+		"tracing-otlp-insecure",
+		false,
+		"Connect to the OTLP collector without transport security",
+	)
+
+	fs.Float64Var(&tracingOptions.SamplingRatio,
+		"tracing-sampling-ratio",
+		1,
+		"Fraction of the reconcile loops that are traced, between 0 and 1",
+	)
+
+	fs.BoolVar(&dryRun,
+		"dry-run",
+		false,
+		"Plan the changes of the GCP resources of all the GCPClusters and GCPMachines in ConfigMaps instead of applying them",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
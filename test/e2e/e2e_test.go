@@ -157,7 +157,7 @@ var _ = Describe("Workload cluster creation", func() {
 			secretData := map[string][]byte{
 				"credentials": data,
 			}
-			err = createSecret(ctx, "test-creds", "default", secretData, bootstrapClusterProxy)
+			err = createSecret(ctx, "test-creds", namespace.Name, secretData, bootstrapClusterProxy)
 			Expect(err).NotTo(HaveOccurred(), "failed creating credentials sercret")
 
 			By("Initializes with 1 worker node")
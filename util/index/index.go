@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package index implements the field indexes of the controllers.
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// GCPClusterCredentialsSecretField indexes the GCPClusters by the key of the Secret referenced by their CredentialsRef.
+	GCPClusterCredentialsSecretField = "spec.credentialsRef"
+	// GCPManagedClusterCredentialsSecretField indexes the GCPManagedClusters by the key of the Secret referenced by their
+	// CredentialsRef.
+	GCPManagedClusterCredentialsSecretField = "spec.credentialsRef"
+	// GCPClusterIdentityField indexes the GCPClusters by the kind and name of the identity referenced by their IdentityRef.
+	GCPClusterIdentityField = "spec.identityRef"
+	// GCPManagedClusterIdentityField indexes the GCPManagedClusters by the kind and name of the identity referenced by
+	// their IdentityRef.
+	GCPManagedClusterIdentityField = "spec.identityRef"
+)
+
+// SecretKey returns the key of a Secret in the credentials indexes.
+func SecretKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// IdentityKey returns the key of an identity in the identity indexes.
+func IdentityKey(kind infrav1.GCPIdentityKind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// SecretIdentityKeys returns the keys of the identities whose credentials are stored in a Secret, so the clusters
+// using the Secret through an identity can be listed with the identity indexes.
+func SecretIdentityKeys(ctx context.Context, c client.Reader, secret client.Object) ([]string, error) {
+	secretRef := infrav1.ObjectReference{Namespace: secret.GetNamespace(), Name: secret.GetName()}
+	keys := []string{}
+
+	staticIdentities := &infrav1.GCPClusterStaticIdentityList{}
+	if err := c.List(ctx, staticIdentities); err != nil {
+		return nil, err
+	}
+	for _, identity := range staticIdentities.Items {
+		if identity.Spec.SecretRef == secretRef {
+			keys = append(keys, IdentityKey(infrav1.GCPClusterStaticIdentityKind, identity.Name))
+		}
+	}
+
+	impersonationIdentities := &infrav1.GCPClusterImpersonationIdentityList{}
+	if err := c.List(ctx, impersonationIdentities); err != nil {
+		return nil, err
+	}
+	for _, identity := range impersonationIdentities.Items {
+		if identity.Spec.SecretRef != nil && *identity.Spec.SecretRef == secretRef {
+			keys = append(keys, IdentityKey(infrav1.GCPClusterImpersonationIdentityKind, identity.Name))
+		}
+	}
+
+	return keys, nil
+}
+
+// AddGCPClusterIndexes adds the GCPCluster indexes to the manager.
+func AddGCPClusterIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &infrav1.GCPCluster{}, GCPClusterCredentialsSecretField, gcpClusterByCredentialsSecret); err != nil {
+		return errors.Wrap(err, "error setting index field for GCPCluster credentials secret")
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &infrav1.GCPCluster{}, GCPClusterIdentityField, gcpClusterByIdentity); err != nil {
+		return errors.Wrap(err, "error setting index field for GCPCluster identity")
+	}
+
+	return nil
+}
+
+// AddGCPManagedClusterIndexes adds the GCPManagedCluster indexes to the manager.
+func AddGCPManagedClusterIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &infrav1exp.GCPManagedCluster{}, GCPManagedClusterCredentialsSecretField, gcpManagedClusterByCredentialsSecret); err != nil {
+		return errors.Wrap(err, "error setting index field for GCPManagedCluster credentials secret")
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &infrav1exp.GCPManagedCluster{}, GCPManagedClusterIdentityField, gcpManagedClusterByIdentity); err != nil {
+		return errors.Wrap(err, "error setting index field for GCPManagedCluster identity")
+	}
+
+	return nil
+}
+
+func gcpClusterByCredentialsSecret(o client.Object) []string {
+	gcpCluster, ok := o.(*infrav1.GCPCluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a GCPCluster but got a %T", o))
+	}
+
+	return credentialsSecretKeys(gcpCluster.Spec.CredentialsRef)
+}
+
+func gcpManagedClusterByCredentialsSecret(o client.Object) []string {
+	gcpManagedCluster, ok := o.(*infrav1exp.GCPManagedCluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a GCPManagedCluster but got a %T", o))
+	}
+
+	return credentialsSecretKeys(gcpManagedCluster.Spec.CredentialsRef)
+}
+
+func gcpClusterByIdentity(o client.Object) []string {
+	gcpCluster, ok := o.(*infrav1.GCPCluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a GCPCluster but got a %T", o))
+	}
+
+	return identityKeys(gcpCluster.Spec.IdentityRef)
+}
+
+func gcpManagedClusterByIdentity(o client.Object) []string {
+	gcpManagedCluster, ok := o.(*infrav1exp.GCPManagedCluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a GCPManagedCluster but got a %T", o))
+	}
+
+	return identityKeys(gcpManagedCluster.Spec.IdentityRef)
+}
+
+func identityKeys(identityRef *infrav1.GCPIdentityReference) []string {
+	if identityRef == nil {
+		return nil
+	}
+
+	return []string{IdentityKey(identityRef.Kind, identityRef.Name)}
+}
+
+func credentialsSecretKeys(credentialsRef *infrav1.ObjectReference) []string {
+	if credentialsRef == nil {
+		return nil
+	}
+
+	return []string{SecretKey(credentialsRef.Namespace, credentialsRef.Name)}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCredentialsSecretIndexes(t *testing.T) {
+	credentialsRef := &infrav1.ObjectReference{Namespace: "capg-system", Name: "credentials"}
+
+	tests := []struct {
+		name    string
+		object  client.Object
+		indexer func(client.Object) []string
+		want    []string
+	}{
+		{
+			name:    "GCPCluster with credentials",
+			object:  &infrav1.GCPCluster{Spec: infrav1.GCPClusterSpec{CredentialsRef: credentialsRef}},
+			indexer: gcpClusterByCredentialsSecret,
+			want:    []string{"capg-system/credentials"},
+		},
+		{
+			name:    "GCPCluster without credentials",
+			object:  &infrav1.GCPCluster{},
+			indexer: gcpClusterByCredentialsSecret,
+		},
+		{
+			name:    "GCPManagedCluster with credentials",
+			object:  &infrav1exp.GCPManagedCluster{Spec: infrav1exp.GCPManagedClusterSpec{CredentialsRef: credentialsRef}},
+			indexer: gcpManagedClusterByCredentialsSecret,
+			want:    []string{"capg-system/credentials"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.indexer(tt.object); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("indexer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentityIndexes(t *testing.T) {
+	identityRef := &infrav1.GCPIdentityReference{Kind: infrav1.GCPClusterStaticIdentityKind, Name: "tenant"}
+
+	tests := []struct {
+		name    string
+		object  client.Object
+		indexer func(client.Object) []string
+		want    []string
+	}{
+		{
+			name:    "GCPCluster with identity",
+			object:  &infrav1.GCPCluster{Spec: infrav1.GCPClusterSpec{IdentityRef: identityRef}},
+			indexer: gcpClusterByIdentity,
+			want:    []string{"GCPClusterStaticIdentity/tenant"},
+		},
+		{
+			name:    "GCPCluster without identity",
+			object:  &infrav1.GCPCluster{},
+			indexer: gcpClusterByIdentity,
+		},
+		{
+			name:    "GCPManagedCluster with identity",
+			object:  &infrav1exp.GCPManagedCluster{Spec: infrav1exp.GCPManagedClusterSpec{IdentityRef: identityRef}},
+			indexer: gcpManagedClusterByIdentity,
+			want:    []string{"GCPClusterStaticIdentity/tenant"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.indexer(tt.object); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("indexer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretIdentityKeys(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	secretRef := infrav1.ObjectReference{Namespace: "capg-system", Name: "credentials"}
+	otherSecretRef := infrav1.ObjectReference{Namespace: "capg-system", Name: "other"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&infrav1.GCPClusterStaticIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "static"},
+			Spec:       infrav1.GCPClusterStaticIdentitySpec{SecretRef: secretRef},
+		},
+		&infrav1.GCPClusterStaticIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec:       infrav1.GCPClusterStaticIdentitySpec{SecretRef: otherSecretRef},
+		},
+		&infrav1.GCPClusterImpersonationIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "impersonation"},
+			Spec:       infrav1.GCPClusterImpersonationIdentitySpec{SecretRef: &secretRef},
+		},
+		&infrav1.GCPClusterImpersonationIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-credentials"},
+		},
+	).Build()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "capg-system", Name: "credentials"}}
+	got, err := SecretIdentityKeys(context.TODO(), c, secret)
+	if err != nil {
+		t.Fatalf("SecretIdentityKeys() error = %v", err)
+	}
+
+	want := []string{"GCPClusterStaticIdentity/static", "GCPClusterImpersonationIdentity/impersonation"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SecretIdentityKeys() = %v, want %v", got, want)
+	}
+}
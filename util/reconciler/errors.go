@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// HandleGCPError reports a reconcile error on the given condition of obj, with the category of the error as reason,
+// and returns the result of the reconcile:
+//   - terminal errors are not returned, retrying cannot succeed until the spec is changed, unless obj is being
+//     deleted: the spec of a deleted object cannot be changed, so they are retried to not leak its resources;
+//   - the errors of the other categories are retried after a delay fitting their category;
+//   - the errors which are not classified are returned, to be retried with the default backoff.
+func HandleGCPError(obj conditions.Setter, condition clusterv1.ConditionType, err error) (ctrl.Result, error) {
+	category := gcperrors.Classify(err)
+	switch {
+	case category.IsTerminal() && !obj.GetDeletionTimestamp().IsZero():
+		conditions.MarkFalse(obj, condition, string(category), clusterv1.ConditionSeverityError, "%s", err.Error())
+		return ctrl.Result{}, err
+	case category.IsTerminal():
+		conditions.MarkFalse(obj, condition, string(category), clusterv1.ConditionSeverityError, "%s", err.Error())
+		return ctrl.Result{}, nil
+	case category.RetryAfter() > 0:
+		conditions.MarkFalse(obj, condition, string(category), clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return ctrl.Result{RequeueAfter: category.RetryAfter()}, nil
+	default:
+		conditions.MarkFalse(obj, condition, infrav1.ReconcileFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return ctrl.Result{}, err
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestHandleGCPError(t *testing.T) {
+	invalid := &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "invalid"}}}
+	cases := []struct {
+		Name           string
+		Deleting       bool
+		Err            error
+		ExpectedResult ctrl.Result
+		ExpectedErr    bool
+	}{
+		{
+			Name: "TerminalErrorIsNotRetried",
+			Err:  invalid,
+		},
+		{
+			Name:        "TerminalErrorIsRetriedOnDelete",
+			Deleting:    true,
+			Err:         invalid,
+			ExpectedErr: true,
+		},
+		{
+			Name:        "BadRequestWithoutReasonIsRetried",
+			Err:         &googleapi.Error{Code: http.StatusBadRequest},
+			ExpectedErr: true,
+		},
+		{
+			Name:           "ConflictIsRequeued",
+			Deleting:       true,
+			Err:            &googleapi.Error{Code: http.StatusPreconditionFailed},
+			ExpectedResult: ctrl.Result{RequeueAfter: 15 * time.Second},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			cluster := &infrav1.GCPCluster{}
+			if c.Deleting {
+				now := metav1.Now()
+				cluster.DeletionTimestamp = &now
+			}
+
+			result, err := reconciler.HandleGCPError(cluster, infrav1.NetworkInfrastructureReadyCondition, c.Err)
+			g.Expect(result).To(gomega.Equal(c.ExpectedResult))
+			g.Expect(err != nil).To(gomega.Equal(c.ExpectedErr))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+// IsOrphanOnDelete reports whether one of the objects has the orphan-on-delete annotation, in which case the GCP
+// resources of the first object are left behind when it is deleted.
+func IsOrphanOnDelete(objs ...metav1.Object) bool {
+	for _, obj := range objs {
+		if obj.GetAnnotations()[infrav1.OrphanOnDeleteAnnotation] == "true" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Orphan orphans the resources of the services in order, and returns all the resources left behind.
+func Orphan(ctx context.Context, orphaners ...cloud.Orphaner) ([]string, error) {
+	orphaned := []string{}
+	for _, o := range orphaners {
+		resources, err := o.Orphan(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		orphaned = append(orphaned, resources...)
+	}
+
+	return orphaned, nil
+}